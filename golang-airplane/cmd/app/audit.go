@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang-airplane/internal/components/audit"
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/core/domain"
+)
+
+// auditListCommand lists the audit log entries of an entity, an actor or a time range
+func (app *App) auditListCommand(args []string, in io.Reader, out io.Writer) error {
+	var filter domain.AuditFilter
+	var format string
+	fs := newFlagSet("audit list", &format)
+	fs.StringVar(&filter.EntityType, "entity", "", "entity type: "+strings.Join(domain.AuditEntityTypes, ", "))
+	fs.StringVar(&filter.EntityID, "id", "", "flight number, reservation ID, airplane registration or crew ID")
+	fs.StringVar(&filter.Actor, "actor", "", "only the changes of this user")
+	fs.Var(timeValue{&filter.From, timeLayouts}, "from", "only the changes from this time, dd/mm/yyyy-HH:mm")
+	fs.Var(timeValue{&filter.To, timeLayouts}, "to", "only the changes before this time, dd/mm/yyyy-HH:mm")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if filter.EntityType != "" && !isAuditEntityType(filter.EntityType) {
+		return usagef("the entity type must be one of %s", strings.Join(domain.AuditEntityTypes, ", "))
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return usagef("-from must be before -to")
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "read the audit log"); err != nil {
+		return err
+	}
+
+	entries, err := app.auditLog.Query(filter)
+	if err != nil {
+		return err
+	}
+	return writeAuditEntries(out, format, entries)
+}
+
+// auditVerifyCommand checks the hash chain of the audit log
+func (app *App) auditVerifyCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("audit verify", nil)
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "verify the audit log"); err != nil {
+		return err
+	}
+
+	count, head, err := app.auditLog.Verify()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Audit log intact: %d entries, last hash %s\n", count, head)
+	return nil
+}
+
+// isAuditEntityType reports whether a name is an audited entity type
+func isAuditEntityType(name string) bool {
+	for _, entityType := range domain.AuditEntityTypes {
+		if entityType == name {
+			return true
+		}
+	}
+	return false
+}
+
+// auditMenu shows the audit log of a record, or verifies the log
+func (app *App) auditMenu() {
+	if err := auth.Require(app.user, domain.RoleAdmin, "read the audit log"); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	choice := app.validation.GetInteger("1. History of a record - 2. Changes of a user - 3. Verify audit log - 4. Back: ",
+		"Must be an integer between 1 and 4", 1, 4)
+
+	var filter domain.AuditFilter
+	switch choice {
+	case 1:
+		filter.EntityID = app.validation.GetString("Enter flight number, reservation ID, airplane registration or crew ID: ",
+			"ID cannot be empty", false)
+	case 2:
+		filter.Actor = app.validation.GetString("Enter username: ", "Username cannot be empty", false)
+	case 3:
+		count, head, err := app.auditLog.Verify()
+		var chainErr *audit.ChainError
+		switch {
+		case errors.As(err, &chainErr):
+			fmt.Printf("THE AUDIT LOG HAS BEEN ALTERED: %v\n", err)
+		case err != nil:
+			fmt.Printf("Error verifying the audit log: %v\n", err)
+		default:
+			fmt.Printf("Audit log intact: %d entries, last hash %s\n", count, head)
+		}
+		return
+	case 4:
+		return
+	}
+
+	entries, err := app.auditLog.Query(filter)
+	if err != nil {
+		fmt.Printf("Error reading the audit log: %v\n", err)
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Println("No changes recorded.")
+		return
+	}
+	for _, entry := range entries {
+		fmt.Printf("\n#%d %s %s %s %s %s\n", entry.Sequence, entry.At.Local().Format("02/01/2006-15:04:05"), entry.Actor,
+			entry.Operation, entry.EntityType, entry.EntityID)
+		for _, change := range entry.Changes {
+			fmt.Printf("    %-28s %s -> %s\n", change.Field, auditValue(change.Before), auditValue(change.After))
+		}
+	}
+}
+
+// auditValue returns a JSON value of an audit entry for display, with a dash for an absent one
+func auditValue(value []byte) string {
+	if len(value) == 0 {
+		return "-"
+	}
+	return string(value)
+}
+
+// writeAuditEntries writes audit entries; text and CSV have a row per changed field
+func writeAuditEntries(out io.Writer, format string, entries []*domain.AuditEntry) error {
+	if entries == nil {
+		entries = []*domain.AuditEntry{}
+	}
+
+	t := table{columns: []string{"sequence", "at", "actor", "entity_type", "entity_id", "operation", "field", "before", "after"}}
+	for _, e := range entries {
+		for _, change := range e.Changes {
+			t.rows = append(t.rows, []string{fmt.Sprint(e.Sequence), e.At.Format(time.RFC3339), e.Actor, e.EntityType,
+				e.EntityID, e.Operation, change.Field, auditValue(change.Before), auditValue(change.After)})
+		}
+	}
+	return t.write(out, format, entries)
+}
@@ -0,0 +1,523 @@
+package main
+
+import (
+	stdjson "encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang-airplane/internal/components/audit"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/tui"
+	"golang-airplane/internal/utils"
+)
+
+// Exit codes of the subcommands
+const (
+	exitOK       = 0
+	exitFailure  = 1 // The services failed or refused the command
+	exitUsage    = 2 // Unknown command, bad flags or invalid input
+	exitNotFound = 3 // The flight or reservation does not exist
+	exitConflict = 4 // The record already exists, or the booking or check-in was refused
+	exitDenied   = 5 // Not logged in, or the user's roles do not allow the command
+	exitTampered = 6 // The audit log has been altered
+)
+
+// Layouts accepted for times and dates, the menu's first
+var (
+	timeLayouts = []string{"02/01/2006-15:04", time.RFC3339}
+	dateLayouts = []string{"02/01/2006", "2006-01-02"}
+)
+
+// command is a subcommand run without the interactive menu
+type command struct {
+	name      string // One or two words, such as "flight add"
+	summary   string
+	run       func(app *App, args []string, in io.Reader, out io.Writer) error
+	anonymous bool // Runs without credentials, which only creating the first user does
+}
+
+// commands lists the subcommands
+var commands = []command{
+	{name: "flight add", summary: "Add a flight", run: (*App).flightAddCommand},
+	{name: "flight list", summary: "List all flights, newest departure first", run: (*App).flightListCommand},
+	{name: "flight search", summary: "Search the flights of a location on a date", run: (*App).flightSearchCommand},
+	{name: "booking create", summary: "Book a flight", run: (*App).bookingCreateCommand},
+	{name: "checkin", summary: "Check a reservation in, on a chosen or an assigned seat", run: (*App).checkInCommand},
+	{name: "crew assign", summary: "Assign the crew of a flight", run: (*App).crewAssignCommand},
+	{name: "reservations list", summary: "List the reservations of a flight", run: (*App).reservationsListCommand},
+	{name: "tui", summary: "Open the full-screen terminal interface", run: (*App).tuiCommand},
+	{name: "user add", summary: "Create a user account, or the first administrator", run: (*App).userAddCommand, anonymous: true},
+	{name: "user list", summary: "List the user accounts", run: (*App).userListCommand},
+	{name: "user roles", summary: "Replace the roles of a user", run: (*App).userRolesCommand},
+	{name: "user passwd", summary: "Change a password", run: (*App).userPasswordCommand},
+	{name: "user disable", summary: "Disable a user account", run: (*App).userDisableCommand},
+	{name: "user enable", summary: "Re-enable a user account", run: (*App).userEnableCommand},
+	{name: "activity list", summary: "List the changes made by users", run: (*App).activityListCommand},
+	{name: "audit list", summary: "List the audit log entries of a record, a user or a time range", run: (*App).auditListCommand},
+	{name: "audit verify", summary: "Check that the audit log has not been altered", run: (*App).auditVerifyCommand},
+	{name: "events list", summary: "List the published domain events", run: (*App).eventsListCommand},
+	{name: "events replay", summary: "Deliver past domain events to a subscriber again", run: (*App).eventsReplayCommand},
+	{name: "webhook add", summary: "Subscribe a partner URL to event types", run: (*App).webhookAddCommand},
+	{name: "webhook list", summary: "List the webhook subscriptions", run: (*App).webhookListCommand},
+	{name: "webhook remove", summary: "Remove a webhook subscription", run: (*App).webhookRemoveCommand},
+	{name: "webhook log", summary: "List the webhook delivery attempts", run: (*App).webhookLogCommand},
+	{name: "webhook queue", summary: "List the webhook deliveries waiting for a retry, or given up on", run: (*App).webhookQueueCommand},
+	{name: "webhook redrive", summary: "Send deliveries of the dead-letter queue again", run: (*App).webhookRedriveCommand},
+}
+
+// usageError reports a command used the wrong way
+type usageError struct {
+	message string
+}
+
+// Error returns the message
+func (e *usageError) Error() string {
+	return e.message
+}
+
+// usagef returns a usageError
+func usagef(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// runCommand runs the subcommand named by args and returns the exit code
+func (app *App) runCommand(args []string, in io.Reader, out, errOut io.Writer) int {
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printCommands(out)
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != cmd.name {
+			continue
+		}
+
+		err := app.logInFromEnv()
+		if cmd.anonymous && os.Getenv(usernameEnv) == "" {
+			err = nil
+		}
+		if err == nil {
+			err = cmd.run(app, args[len(words):], in, out)
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		if err != nil {
+			fmt.Fprintf(errOut, "%s: %v\n", cmd.name, err)
+		}
+		return exitCode(err)
+	}
+
+	fmt.Fprintf(errOut, "unknown command %q\n\n", strings.Join(args, " "))
+	printCommands(errOut)
+	return exitUsage
+}
+
+// printCommands lists the subcommands
+func printCommands(w io.Writer) {
+	fmt.Fprintln(w, "Usage: app [command] [flags]")
+	fmt.Fprintln(w, "\nWithout a command the interactive menu starts. Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-20s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nCommands log in with the %s and %s environment variables.\n", usernameEnv, passwordEnv)
+	fmt.Fprintln(w, "Run 'app <command> -h' for the flags of a command.")
+}
+
+// exitCode returns the exit code for the error of a command
+func exitCode(err error) int {
+	var usage *usageError
+	var rejection *domain.CheckInRejection
+	var chainErr *audit.ChainError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &usage):
+		return exitUsage
+	case errors.Is(err, domain.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, domain.ErrUnauthorized), errors.Is(err, domain.ErrForbidden):
+		return exitDenied
+	case errors.As(err, &chainErr):
+		return exitTampered
+	case errors.Is(err, domain.ErrAlreadyExists), errors.As(err, &rejection),
+		errors.Is(err, flight.ErrNoSeatsAvailable), errors.Is(err, flight.ErrDeniedBoarding):
+		return exitConflict
+	default:
+		return exitFailure
+	}
+}
+
+// newFlagSet creates the flag set of a command, with the --format flag when it prints records
+func newFlagSet(name string, format *string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if format != nil {
+		fs.StringVar(format, "format", formatText, "output format: text, json or csv")
+	}
+	return fs
+}
+
+// parseFlags parses the flags of a command; when input is not nil the values may instead come from
+// a JSON file given with -input
+func parseFlags(fs *flag.FlagSet, args []string, in io.Reader, input interface{}) error {
+	var path string
+	if input != nil {
+		fs.StringVar(&path, "input", "", "JSON file with the values instead of the flags, or - for standard input")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{message: err.Error()}
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+	if path == "" {
+		return nil
+	}
+
+	var combined []string
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name != "input" && f.Name != "format" {
+			combined = append(combined, "-"+f.Name)
+		}
+	})
+	if len(combined) > 0 {
+		return usagef("-input cannot be combined with %s", strings.Join(combined, ", "))
+	}
+
+	return readInput(path, in, input)
+}
+
+// readInput decodes a JSON input file, or standard input for -, into a value
+func readInput(path string, in io.Reader, value interface{}) error {
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return &usageError{message: err.Error()}
+		}
+		defer file.Close()
+		in = file
+	}
+
+	decoder := stdjson.NewDecoder(in)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		return usagef("invalid input %s: %v", path, err)
+	}
+	return nil
+}
+
+// timeValue is a flag holding a time in one of a list of layouts
+type timeValue struct {
+	target  *time.Time
+	layouts []string
+}
+
+// String returns the time in the first layout
+func (v timeValue) String() string {
+	if v.target == nil || v.target.IsZero() {
+		return ""
+	}
+	return v.target.Format(v.layouts[0])
+}
+
+// Set parses a time in any of the layouts
+func (v timeValue) Set(value string) error {
+	for _, layout := range v.layouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			*v.target = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("expected the format %s", strings.Join(v.layouts, " or "))
+}
+
+// crewValue is a repeatable flag adding a crew member given as Name:Position
+type crewValue struct {
+	target *[]domain.Crew
+}
+
+// String returns the crew members as Name:Position pairs
+func (v crewValue) String() string {
+	if v.target == nil {
+		return ""
+	}
+	pairs := make([]string, 0, len(*v.target))
+	for _, member := range *v.target {
+		pairs = append(pairs, member.Name+":"+member.Position)
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// Set adds a crew member
+func (v crewValue) Set(value string) error {
+	separator := strings.LastIndex(value, ":")
+	if separator < 0 {
+		return errors.New("expected Name:Position")
+	}
+	*v.target = append(*v.target, domain.Crew{
+		Name:     strings.TrimSpace(value[:separator]),
+		Position: strings.TrimSpace(value[separator+1:]),
+	})
+	return nil
+}
+
+// flightInput holds the values of a new flight
+type flightInput struct {
+	FlightNumber    string    `json:"flight_number"`
+	DepartureCity   string    `json:"departure_city"`
+	DestinationCity string    `json:"destination_city"`
+	DepartureTime   time.Time `json:"departure_time"`
+	ArrivalTime     time.Time `json:"arrival_time"`
+	Capacity        int       `json:"capacity"`
+}
+
+// flightAddCommand adds a flight
+func (app *App) flightAddCommand(args []string, in io.Reader, out io.Writer) error {
+	var input flightInput
+	var format string
+	fs := newFlagSet("flight add", &format)
+	fs.StringVar(&input.FlightNumber, "number", "", "flight number, Fxxxx")
+	fs.StringVar(&input.DepartureCity, "from", "", "departure city")
+	fs.StringVar(&input.DestinationCity, "to", "", "destination city")
+	fs.Var(timeValue{&input.DepartureTime, timeLayouts}, "departure", "departure time, dd/mm/yyyy-HH:mm")
+	fs.Var(timeValue{&input.ArrivalTime, timeLayouts}, "arrival", "arrival time, dd/mm/yyyy-HH:mm")
+	fs.IntVar(&input.Capacity, "capacity", 0, "number of seats, 36 to 853")
+	if err := parseFlags(fs, args, in, &input); err != nil {
+		return err
+	}
+
+	departureCity, destinationCity := strings.TrimSpace(input.DepartureCity), strings.TrimSpace(input.DestinationCity)
+	switch {
+	case !utils.IsFlightNumber(input.FlightNumber):
+		return usagef("the flight number must match the format Fxxxx")
+	case departureCity == "" || destinationCity == "":
+		return usagef("the departure and destination cities are required")
+	case strings.EqualFold(departureCity, destinationCity):
+		return usagef("the departure and destination cities must differ")
+	case input.Capacity < domain.MinFlightCapacity || input.Capacity > domain.MaxFlightCapacity:
+		return usagef("the capacity must be between %d and %d", domain.MinFlightCapacity, domain.MaxFlightCapacity)
+	}
+	if err := utils.CheckDates(input.DepartureTime, input.ArrivalTime, time.Now()); err != nil {
+		return &usageError{message: err.Error()}
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	newFlight, err := app.flightService.AddFlight(input.FlightNumber, departureCity, destinationCity,
+		input.DepartureTime, input.ArrivalTime, input.Capacity)
+	if err != nil {
+		return err
+	}
+	return writeFlights(out, format, []*domain.Flight{newFlight})
+}
+
+// flightListCommand lists all flights
+func (app *App) flightListCommand(args []string, in io.Reader, out io.Writer) error {
+	var format string
+	fs := newFlagSet("flight list", &format)
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	flights, err := app.flightService.ListAllFlights()
+	if err != nil {
+		return err
+	}
+	return writeFlights(out, format, flights)
+}
+
+// flightSearchCommand searches the flights of a location on a date
+func (app *App) flightSearchCommand(args []string, in io.Reader, out io.Writer) error {
+	var location, format string
+	var date time.Time
+	fs := newFlagSet("flight search", &format)
+	fs.StringVar(&location, "location", "", "departure or destination city")
+	fs.Var(timeValue{&date, dateLayouts}, "date", "departure or arrival date, dd/mm/yyyy")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if strings.TrimSpace(location) == "" || date.IsZero() {
+		return usagef("-location and -date are required")
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	flights, err := app.flightService.SearchFlights(strings.TrimSpace(location), date)
+	if err != nil {
+		return err
+	}
+	return writeFlights(out, format, flights)
+}
+
+// bookingInput holds the values of a new reservation
+type bookingInput struct {
+	Name               string `json:"name"`
+	Address            string `json:"address"`
+	PhoneNumber        int64  `json:"phone_number"`
+	IdentityCardNumber int64  `json:"identity_card_number"`
+	FlightNumber       string `json:"flight_number"`
+	Class              string `json:"class"`
+}
+
+// bookingCreateCommand books a flight
+func (app *App) bookingCreateCommand(args []string, in io.Reader, out io.Writer) error {
+	input := bookingInput{Class: domain.ClassEconomy}
+	var format string
+	fs := newFlagSet("booking create", &format)
+	fs.StringVar(&input.Name, "name", "", "passenger name")
+	fs.StringVar(&input.Address, "address", "", "passenger address")
+	fs.Int64Var(&input.PhoneNumber, "phone", 0, "phone number")
+	fs.Int64Var(&input.IdentityCardNumber, "id-card", 0, "identity card number")
+	fs.StringVar(&input.FlightNumber, "flight", "", "flight number")
+	fs.StringVar(&input.Class, "class", domain.ClassEconomy, "Economy or Business")
+	if err := parseFlags(fs, args, in, &input); err != nil {
+		return err
+	}
+
+	name, address := strings.TrimSpace(input.Name), strings.TrimSpace(input.Address)
+	switch {
+	case name == "" || address == "":
+		return usagef("the name and address are required")
+	case input.PhoneNumber <= 0 || input.IdentityCardNumber <= 0:
+		return usagef("the phone and identity card numbers must be positive")
+	case !utils.IsFlightNumber(input.FlightNumber):
+		return usagef("the flight number must match the format Fxxxx")
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	reservation, err := app.reservationService.BookFlight(name, address, input.PhoneNumber, input.IdentityCardNumber,
+		input.FlightNumber, input.Class, app.session)
+	if err != nil {
+		return err
+	}
+	return writeReservations(out, format, []*domain.Reservation{reservation})
+}
+
+// checkInInput holds the values of a check-in
+type checkInInput struct {
+	ReservationID string `json:"reservation_id"`
+	Seat          string `json:"seat"`
+}
+
+// checkInCommand checks a reservation in
+func (app *App) checkInCommand(args []string, in io.Reader, out io.Writer) error {
+	var input checkInInput
+	var format string
+	fs := newFlagSet("checkin", &format)
+	fs.StringVar(&input.ReservationID, "reservation", "", "reservation ID")
+	fs.StringVar(&input.Seat, "seat", "", "seat number; left out, the seat that suits the passenger best is assigned")
+	if err := parseFlags(fs, args, in, &input); err != nil {
+		return err
+	}
+	if strings.TrimSpace(input.ReservationID) == "" {
+		return usagef("the reservation ID is required")
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	reservationID := strings.TrimSpace(input.ReservationID)
+	if err := app.reservationService.CheckIn(reservationID, strings.ToUpper(strings.TrimSpace(input.Seat)), app.session); err != nil {
+		return err
+	}
+
+	reservation, err := app.reservationService.GetReservation(reservationID)
+	if err != nil {
+		return err
+	}
+	return writeReservations(out, format, []*domain.Reservation{reservation})
+}
+
+// crewInput holds the crew of a flight
+type crewInput struct {
+	FlightNumber string        `json:"flight_number"`
+	Crew         []domain.Crew `json:"crew"`
+}
+
+// crewAssignCommand assigns the crew of a flight
+func (app *App) crewAssignCommand(args []string, in io.Reader, out io.Writer) error {
+	var input crewInput
+	var format string
+	fs := newFlagSet("crew assign", &format)
+	fs.StringVar(&input.FlightNumber, "flight", "", "flight number")
+	fs.Var(crewValue{&input.Crew}, "member", "crew member as Name:Position, repeated for each member")
+	if err := parseFlags(fs, args, in, &input); err != nil {
+		return err
+	}
+	if !utils.IsFlightNumber(input.FlightNumber) {
+		return usagef("the flight number must match the format Fxxxx")
+	}
+	if err := domain.ValidateCrew(input.Crew); err != nil {
+		return &usageError{message: err.Error()}
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	if err := app.flightService.AssignCrew(input.FlightNumber, input.Crew); err != nil {
+		return err
+	}
+
+	crewedFlight, err := app.flightService.GetFlight(input.FlightNumber)
+	if err != nil {
+		return err
+	}
+	return writeCrew(out, format, crewedFlight)
+}
+
+// reservationsListCommand lists the reservations of a flight
+func (app *App) reservationsListCommand(args []string, in io.Reader, out io.Writer) error {
+	var flightNumber, format string
+	fs := newFlagSet("reservations list", &format)
+	fs.StringVar(&flightNumber, "flight", "", "flight number")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if !utils.IsFlightNumber(flightNumber) {
+		return usagef("the flight number must match the format Fxxxx")
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	if _, err := app.flightService.GetFlight(flightNumber); err != nil {
+		return err
+	}
+	reservations, err := app.reservationService.GetReservationsForFlight(flightNumber)
+	if err != nil {
+		return err
+	}
+	return writeReservations(out, format, reservations)
+}
+
+// tuiCommand opens the full-screen terminal interface
+func (app *App) tuiCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("tui", nil)
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+
+	terminal, ok := in.(*os.File)
+	if !ok {
+		return usagef("the terminal interface needs a terminal")
+	}
+	return tui.New(app.flightService, app.reservationService, app.seatService, app.session).Run(terminal, out)
+}
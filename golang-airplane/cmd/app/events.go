@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/core/domain"
+)
+
+// eventsListCommand lists the domain events of the outbox, or those still owed to asynchronous subscribers
+func (app *App) eventsListCommand(args []string, in io.Reader, out io.Writer) error {
+	var filter domain.EventFilter
+	var format string
+	var pending bool
+	fs := newFlagSet("events list", &format)
+	fs.StringVar(&filter.Type, "type", "", "event type: "+strings.Join(domain.EventTypes, ", "))
+	fs.Var(timeValue{&filter.From, timeLayouts}, "from", "only the events from this time, dd/mm/yyyy-HH:mm")
+	fs.Var(timeValue{&filter.To, timeLayouts}, "to", "only the events before this time, dd/mm/yyyy-HH:mm")
+	fs.BoolVar(&pending, "pending", false, "only the events not yet delivered to every asynchronous subscriber")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if err := checkEventFilter(filter); err != nil {
+		return err
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "read the domain events"); err != nil {
+		return err
+	}
+
+	var messages []*domain.EventMessage
+	var err error
+	if pending {
+		messages, err = app.eventBus.Pending()
+	} else {
+		messages, err = app.eventBus.Messages(filter)
+	}
+	if err != nil {
+		return err
+	}
+	if pending {
+		selected := []*domain.EventMessage{}
+		for _, message := range messages {
+			if filter.Matches(message) {
+				selected = append(selected, message)
+			}
+		}
+		messages = selected
+	}
+	return writeEvents(out, format, messages)
+}
+
+// eventsReplayCommand delivers past domain events to a subscriber again
+func (app *App) eventsReplayCommand(args []string, in io.Reader, out io.Writer) error {
+	var filter domain.EventFilter
+	var subscriber string
+	fs := newFlagSet("events replay", nil)
+	fs.StringVar(&subscriber, "subscriber", "", "subscriber to deliver the events to: "+strings.Join(app.eventBus.Subscribers(), ", "))
+	fs.StringVar(&filter.Type, "type", "", "only the events of this type")
+	fs.Var(timeValue{&filter.From, timeLayouts}, "from", "only the events from this time, dd/mm/yyyy-HH:mm")
+	fs.Var(timeValue{&filter.To, timeLayouts}, "to", "only the events before this time, dd/mm/yyyy-HH:mm")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if subscriber == "" {
+		return usagef("-subscriber is required")
+	}
+	if err := checkEventFilter(filter); err != nil {
+		return err
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "replay domain events"); err != nil {
+		return err
+	}
+
+	replayed, err := app.eventBus.Replay(subscriber, filter)
+	if err != nil {
+		return fmt.Errorf("replayed %d events: %w", replayed, err)
+	}
+	fmt.Fprintf(out, "Replayed %d events to %s\n", replayed, subscriber)
+	return nil
+}
+
+// checkEventFilter rejects an unknown event type or an empty time range
+func checkEventFilter(filter domain.EventFilter) error {
+	if filter.Type != "" {
+		if _, err := domain.NewEvent(filter.Type); err != nil {
+			return usagef("the event type must be one of %s", strings.Join(domain.EventTypes, ", "))
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return usagef("-from must be before -to")
+	}
+	return nil
+}
+
+// writeEvents writes outbox messages; text and CSV list the asynchronous subscribers still owed each event
+func writeEvents(out io.Writer, format string, messages []*domain.EventMessage) error {
+	if messages == nil {
+		messages = []*domain.EventMessage{}
+	}
+
+	t := table{columns: []string{"id", "occurred_at", "type", "payload", "pending"}}
+	for _, m := range messages {
+		var pending []string
+		for _, name := range m.Subscribers {
+			if m.IsPending(name) {
+				pending = append(pending, name)
+			}
+		}
+		t.rows = append(t.rows, []string{m.ID, m.OccurredAt.Format(time.RFC3339), m.Type, string(m.Payload),
+			strings.Join(pending, " ")})
+	}
+	return t.write(out, format, messages)
+}
@@ -1,21 +1,58 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
-	// "golang-airplane/internal/components/airplane"
+	"golang-airplane/internal/components/airplane"
+	"golang-airplane/internal/components/audit"
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/components/boardingpass"
+	"golang-airplane/internal/components/crew"
+	"golang-airplane/internal/components/documents"
+	"golang-airplane/internal/components/events"
 	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/components/manifest"
+	"golang-airplane/internal/components/seatmap"
+	"golang-airplane/internal/components/webhook"
 	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
 	"golang-airplane/internal/storage/json"
 	"golang-airplane/internal/utils"
 )
 
 // App represents the main application
 type App struct {
-	flightService      *flight.Service
-	reservationService *flight.ReservationService
+	session            string // Token tying seat holds to this run of the application
+	holdService        *flight.HoldService
+	authService        *auth.Service
+	auditLog           *audit.Log
+	eventBus           *events.Bus
+	webhookService     *webhook.Service
+	user               *domain.User // Logged-in user the services act for
+	airplaneService    ports.AirplaneService
+	flightService      ports.FlightService
+	reservationService ports.ReservationService
+	waitlistService    *flight.WaitlistService
+	overbookingService *flight.OverbookingService
+	checkInRules       *flight.CheckInRules
+	boardingPasses     *boardingpass.Service
+	documents          *documents.Renderer
+	boardingService    *flight.BoardingService
+	manifestService    *manifest.Service
+	baggageService     *flight.BaggageService
+	ssrService         *flight.SSRService
+	seatService        *flight.SeatService
+	documentDir        string
+	crewService        *crew.Service
+	rosterGenerator    *crew.RosterGenerator
 	validation         *utils.ValidationService
 	dataManager        *utils.DataManager
 }
@@ -26,24 +63,123 @@ func main() {
 	
 	// Setup storage
 	storage := json.NewStorage(dataDir)
-	flightRepo := json.NewFlightRepository(storage)
-	reservationRepo := json.NewReservationRepository(storage)
+	auditLog := audit.NewLog(json.NewAuditRepository(storage), audit.SystemActor)
+	flightRepo := audit.Flights(json.NewFlightRepository(storage), auditLog)
+	reservationRepo := audit.Reservations(json.NewReservationRepository(storage), auditLog)
+	crewRepo := audit.Crew(json.NewCrewRepository(storage), auditLog)
+	crewChangeRepo := json.NewCrewChangeRepository(storage)
+	airplaneRepo := audit.Airplanes(json.NewAirplaneRepository(storage), auditLog)
+	waitlistRepo := json.NewWaitlistRepository(storage)
+	notificationLog := json.NewNotificationLog(storage)
+	overbookingPolicyRepo := json.NewOverbookingPolicyRepository(storage)
+	seatHoldRepo := json.NewSeatHoldRepository(storage)
+	checkInPolicyRepo := json.NewCheckInPolicyRepository(storage)
+	brandRepo := json.NewBrandRepository(storage)
+	boardingRepo := json.NewBoardingSessionRepository(storage)
+	manifestRepo := json.NewManifestRepository(storage)
+	userRepo := json.NewUserRepository(storage)
+	activityRepo := json.NewActivityRepository(storage)
 	
 	// Setup services
-	flightService := flight.NewService(flightRepo, reservationRepo)
-	reservationService := flight.NewReservationService(flightRepo, reservationRepo)
+	eventBus := events.NewBus(json.NewOutboxRepository(storage), storage, events.DefaultRetention)
+	webhookService := webhook.NewService(json.NewWebhookSubscriptionRepository(storage), json.NewWebhookDeliveryRepository(storage),
+		json.NewWebhookAttemptRepository(storage), nil, webhook.DefaultRetryPolicy)
+	holdService := flight.NewHoldService(flightRepo, seatHoldRepo, flight.DefaultHoldTTL)
+	flightService := flight.NewService(flightRepo, reservationRepo, crewChangeRepo, airplaneRepo, holdService, eventBus)
+	airplaneService := airplane.NewAirplaneService(airplaneRepo, flightRepo)
+	overbookingService := flight.NewOverbookingService(flightRepo, reservationRepo, overbookingPolicyRepo)
+	checkInRules := flight.NewCheckInRules(checkInPolicyRepo)
+	reservationService := flight.NewReservationService(flightRepo, reservationRepo, overbookingService, holdService, checkInRules, eventBus)
+	waitlistService := flight.NewWaitlistService(flightRepo, reservationRepo, waitlistRepo, notificationLog, flight.DefaultWaitlistHold)
+	boardingPasses := boardingpass.NewService(flightRepo, reservationRepo, boardingpass.DefaultCarrier)
+	documentRenderer := documents.NewRenderer(flightRepo, reservationRepo, brandRepo, boardingPasses)
+	boardingService := flight.NewBoardingService(flightRepo, reservationRepo, boardingRepo, boardingPasses)
+	baggageService := flight.NewBaggageService(flightRepo, reservationRepo, flight.DefaultCarrierCode, flight.DefaultBaggagePolicy)
+	ssrService := flight.NewSSRService(flightRepo, reservationRepo)
+	seatService := flight.NewSeatService(flightRepo, reservationRepo, holdService)
+	manifestService := manifest.NewService(flightService, reservationService, flightRepo, boardingRepo, manifestRepo)
+	crewService := crew.NewService(crewRepo)
+	rosterGenerator := crew.NewRosterGenerator(flightRepo, crewRepo, crew.DefaultDutyLimits())
+	authService := auth.NewService(userRepo, activityRepo)
 	validation := utils.NewValidationService()
 	dataManager := utils.NewDataManager(dataDir)
 	
+	// Released seats go to the waitlist first
+	eventBus.Subscribe("waitlist", waitlistService.HandleSeatReleased, domain.EventSeatReleased)
+	
+	// Partners are told of the events they subscribed to
+	eventBus.SubscribeAsync(webhook.SubscriberName, webhookService.HandleEvent)
+	
+	// Release expired seat holds in the background
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	holdService.StartReaper(ctx, time.Minute, func(err error) {
+		fmt.Printf("Error releasing expired seat holds: %v\n", err)
+	})
+	
+	// Release the seats of expired waitlist offers in the background
+	waitlistService.StartReaper(ctx, time.Minute, func(err error) {
+		fmt.Printf("Error releasing expired waitlist offers: %v\n", err)
+	})
+	
+	// Deliver events to the asynchronous subscribers in the background
+	eventBus.StartDelivery(ctx, events.DefaultRetryInterval, func(err error) {
+		fmt.Printf("Error delivering events: %v\n", err)
+	})
+	webhookService.StartDispatcher(ctx, webhook.DefaultCheckInterval, func(err error) {
+		fmt.Printf("Error sending webhooks: %v\n", err)
+	})
+	
+	// Each run of the application is one booking session
+	session, err := flight.NewSessionToken()
+	if err != nil {
+		fmt.Printf("Error starting session: %v\n", err)
+		return
+	}
+	defer holdService.ReleaseSession(session)
+	
 	// Create app
 	app := &App{
+		session:            session,
+		holdService:        holdService,
+		authService:        authService,
+		auditLog:           auditLog,
+		eventBus:           eventBus,
+		webhookService:     webhookService,
+		airplaneService:    airplaneService,
 		flightService:      flightService,
 		reservationService: reservationService,
+		waitlistService:    waitlistService,
+		overbookingService: overbookingService,
+		checkInRules:       checkInRules,
+		boardingPasses:     boardingPasses,
+		documents:          documentRenderer,
+		boardingService:    boardingService,
+		manifestService:    manifestService,
+		baggageService:     baggageService,
+		ssrService:         ssrService,
+		seatService:        seatService,
+		documentDir:        filepath.Join(dataDir, "documents"),
+		crewService:        crewService,
+		rosterGenerator:    rosterGenerator,
 		validation:         validation,
 		dataManager:        dataManager,
 	}
 	
-	// Run the app
+	// Run a command given on the command line, or the interactive menu
+	if len(os.Args) > 1 {
+		code := app.runCommand(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+		holdService.ReleaseSession(session)
+		
+		// Hand the command's events over before exiting; undelivered ones stay in the outbox for the next run
+		if err := eventBus.DeliverPending(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error delivering events: %v\n", err)
+		}
+		if err := webhookService.SendDue(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error sending webhooks: %v\n", err)
+		}
+		os.Exit(code)
+	}
 	app.run()
 }
 
@@ -53,6 +189,10 @@ func (app *App) run() {
 	fmt.Println("|             AIRLINE MANAGEMENT SYSTEM                     |")
 	fmt.Println("+-----------------------------------------------------------+")
 	
+	if !app.logInMenu() {
+		return
+	}
+	
 	menu := []string{
 		"Add a Flight",
 		"Book a Flight",
@@ -60,6 +200,22 @@ func (app *App) run() {
 		"Assign Crew to Flight",
 		"Display All Flights",
 		"Display Reservations of a Flight",
+		"Register Crew Member",
+		"Generate Crew Roster",
+		"Edit Crew of a Flight",
+		"Manage Airplanes",
+		"Cancel a Reservation",
+		"Manage Waitlist",
+		"Manage Overbooking",
+		"Manage Check-in Rules",
+		"Boarding Passes and Documents",
+		"Manage Fares and Gates",
+		"Gate Boarding",
+		"Flight Manifest",
+		"Baggage",
+		"Special Service Requests",
+		"Manage Seats",
+		"Users, Activity and Audit Log",
 		"Exit",
 	}
 	
@@ -88,6 +244,38 @@ func (app *App) run() {
 		case 6:
 			app.displayFlightReservationsMenu()
 		case 7:
+			app.registerCrewMenu()
+		case 8:
+			app.generateRosterMenu()
+		case 9:
+			app.editCrewMenu()
+		case 10:
+			app.airplaneMenu()
+		case 11:
+			app.cancelReservationMenu()
+		case 12:
+			app.waitlistMenu()
+		case 13:
+			app.overbookingMenu()
+		case 14:
+			app.checkInRulesMenu()
+		case 15:
+			app.boardingPassMenu()
+		case 16:
+			app.faresMenu()
+		case 17:
+			app.gateBoardingMenu()
+		case 18:
+			app.manifestMenu()
+		case 19:
+			app.baggageMenu()
+		case 20:
+			app.specialServicesMenu()
+		case 21:
+			app.seatsMenu()
+		case 22:
+			app.usersMenu()
+		case 23:
 			fmt.Println("Exiting program. Goodbye!")
 			return
 		default:
@@ -204,31 +392,999 @@ func (app *App) bookFlightMenu() {
 		selectedFlight := flights[selectedIndex-1]
 		
 		// Check available seats
-		if selectedFlight.AvailableSeat <= 0 {
+		bookable, err := app.reservationService.BookableSeats(selectedFlight.FlightNumber)
+		if err != nil {
+			fmt.Printf("Error checking available seats: %v\n", err)
+			continue
+		}
+		if bookable <= 0 {
 			fmt.Println("Available slots for the flight are running out. Cannot add a reservation.")
+			if app.validation.CheckYesOrNo("Do you want to join the waitlist of this flight? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+				app.joinWaitlist(selectedFlight.FlightNumber)
+			}
+			return
+		}
+		
+		// Hold a seat while the customer information is entered
+		hold, err := app.reservationService.HoldInventory(app.session, selectedFlight.FlightNumber, 1)
+		if err != nil {
+			fmt.Printf("Cannot hold a seat on this flight: %v\n", err)
+			continue
+		}
+		fmt.Printf("A seat is held for you until %s\n", hold.ExpiresAt.Format("15:04:05"))
+		
+		// Enter customer information
+		name := app.validation.GetString("Enter name: ", "Name cannot be empty", false)
+		address := app.validation.GetString("Enter address: ", "Address cannot be empty", false)
+		phoneNumber := app.validation.GetLong("Enter phone number: ", "Phone number must be a valid number", false)
+		idCardNumber := app.validation.GetLong("Enter identity card number: ", "ID card number must be a valid number", false)
+		class := app.inputClass()
+		
+		// Create reservation
+		reservation, err := app.reservationService.BookFlight(name, address, phoneNumber, idCardNumber, selectedFlight.FlightNumber, class, app.session)
+		if err != nil {
+			app.holdService.Release(app.session, hold.ID)
+			fmt.Printf("Error booking flight: %v\n", err)
+			continue
+		}
+		
+		fmt.Printf("Reservation ID: %s added successfully.\nReservation ID is required for check-in progress, selecting a seat, and receiving a boarding pass\n\n",
+			reservation.ReservationID)
+		fmt.Println(reservation)
+		fmt.Println("When you go to the airport, please select the 'Flight check-in' option to choose your seat and receive your boarding pass.")
+		fmt.Println()
+		
+		if !app.validation.CheckYesOrNo("Do you want to create another reservation? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+			break
+		}
+	}
+}
+
+// inputClass asks for the cabin class of a booking
+func (app *App) inputClass() string {
+	choice := app.validation.GetInteger("Select class (1. Economy - 2. Business): ",
+		"Must be an integer between 1 and 2", 1, 2)
+	if choice == 2 {
+		return domain.ClassBusiness
+	}
+	return domain.ClassEconomy
+}
+
+// joinWaitlist adds a passenger to the waitlist of a sold-out flight
+func (app *App) joinWaitlist(flightNumber string) {
+	name := app.validation.GetString("Enter name: ", "Name cannot be empty", false)
+	address := app.validation.GetString("Enter address: ", "Address cannot be empty", false)
+	phoneNumber := app.validation.GetLong("Enter phone number: ", "Phone number must be a valid number", false)
+	idCardNumber := app.validation.GetLong("Enter identity card number: ", "ID card number must be a valid number", false)
+	class := app.inputClass()
+
+	entry, err := app.waitlistService.JoinWaitlist(name, address, phoneNumber, idCardNumber, flightNumber, class)
+	if err != nil {
+		fmt.Printf("Error joining waitlist: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Added to the waitlist of flight %s with waitlist ID %s.\n", flightNumber, entry.ID)
+	fmt.Println("The passenger will be notified when a seat becomes available.")
+}
+
+// cancelReservationMenu cancels a reservation and releases its seat
+func (app *App) cancelReservationMenu() {
+	fmt.Println("\n--- Cancel Reservation ---")
+
+	reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+
+	reservation, err := app.reservationService.GetReservation(reservationID)
+	if err != nil {
+		fmt.Printf("No such reservation ID found: %v\n", err)
+		return
+	}
+	fmt.Println(reservation)
+
+	if !app.validation.CheckYesOrNo("Do you want to cancel this reservation? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+		return
+	}
+
+	if err := app.reservationService.CancelReservation(reservationID); err != nil {
+		fmt.Printf("Error cancelling reservation: %v\n", err)
+		return
+	}
+	fmt.Printf("Reservation %s cancelled\n", reservationID)
+}
+
+// waitlistMenu shows flight waitlists, confirms offers and changes flight capacity
+func (app *App) waitlistMenu() {
+	for {
+		fmt.Println("\n--- Manage Waitlist ---")
+		choice := app.validation.GetInteger("1. Show waitlist of a flight - 2. Confirm waitlist offer - 3. Change flight capacity - 4. Back: ",
+			"Must be an integer between 1 and 4", 1, 4)
+
+		switch choice {
+		case 1:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+
+			entries, err := app.waitlistService.GetWaitlist(flightNumber)
+			if err != nil {
+				fmt.Printf("Error retrieving waitlist: %v\n", err)
+				continue
+			}
+			if len(entries) == 0 {
+				fmt.Println("Nobody is waiting for this flight")
+				continue
+			}
+
+			fmt.Println("+--------+--------------------+----------+----------+------------------+----------------+")
+			fmt.Println("|   ID   |        Name        |  Class   |  Status  |   Offer expires  |  Reservation   |")
+			fmt.Println("+--------+--------------------+----------+----------+------------------+----------------+")
+			for _, entry := range entries {
+				expires := ""
+				if entry.Status == domain.WaitlistOffered {
+					expires = entry.OfferExpiresAt.Format("02/01/2006-15:04")
+				}
+				fmt.Printf("| %-6s | %-18s | %-8s | %-8s | %-16s | %-14s |\n", entry.ID, entry.Name, entry.Class,
+					entry.Status, expires, entry.ReservationID)
+			}
+			fmt.Println("+--------+--------------------+----------+----------+------------------+----------------+")
+		case 2:
+			entryID := app.validation.GetString("Enter waitlist ID: ", "Waitlist ID cannot be empty", false)
+
+			reservation, err := app.waitlistService.ConfirmOffer(entryID)
+			if err != nil {
+				fmt.Printf("Error confirming offer: %v\n", err)
+				continue
+			}
+			fmt.Printf("Reservation ID: %s confirmed.\n", reservation.ReservationID)
+			fmt.Println(reservation)
+		case 3:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			capacity := app.validation.GetInteger("Enter new seat capacity: ",
+				fmt.Sprintf("Available seats of commercial flight must be between %d and %d", domain.MinFlightCapacity, domain.MaxFlightCapacity),
+				domain.MinFlightCapacity, domain.MaxFlightCapacity)
+
+			if err := app.flightService.ChangeCapacity(flightNumber, capacity); err != nil {
+				fmt.Printf("Error changing capacity: %v\n", err)
+				continue
+			}
+			fmt.Printf("Capacity of flight %s changed to %d\n", flightNumber, capacity)
+		case 4:
+			return
+		}
+	}
+}
+
+// overbookingMenu configures route overbooking and records denied-boarding volunteers
+func (app *App) overbookingMenu() {
+	for {
+		fmt.Println("\n--- Manage Overbooking ---")
+		choice := app.validation.GetInteger("1. Set route policy - 2. Show flight overbooking - 3. Register volunteer - 4. Back: ",
+			"Must be an integer between 1 and 4", 1, 4)
+
+		switch choice {
+		case 1:
+			departureCity := app.validation.GetString("Enter departure city: ", "Departure city cannot be empty", false)
+			destinationCity := app.validation.GetString("Enter destination city: ", "Destination city cannot be empty", false)
+			fromHistory := app.validation.CheckYesOrNo("Derive the allowance from the route's no-show rate? \nChoose 'Y' for YES || Choose 'N' for NO : ")
+
+			prompt := "Enter seats that may be sold above capacity: "
+			if fromHistory {
+				prompt = "Enter the maximum seats that may be sold above capacity (0 for no maximum): "
+			}
+			limit := app.validation.GetInteger(prompt, "Must be an integer between 0 and 100", 0, 100)
+
+			if _, err := app.overbookingService.SetPolicy(departureCity, destinationCity, limit, fromHistory); err != nil {
+				fmt.Printf("Error saving policy: %v\n", err)
+				continue
+			}
+			fmt.Printf("Overbooking policy for %s - %s saved\n", departureCity, destinationCity)
+		case 2:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+
+			selectedFlight, err := app.flightService.GetFlight(flightNumber)
+			if err != nil {
+				fmt.Printf("Flight number does not exist: %v\n", err)
+				continue
+			}
+
+			stats, err := app.overbookingService.NoShowStats(selectedFlight.DepartureCity, selectedFlight.DestinationCity)
+			if err != nil {
+				fmt.Printf("Error computing no-show rate: %v\n", err)
+				continue
+			}
+			allowance, err := app.overbookingService.AuthorizedOverbook(selectedFlight)
+			if err != nil {
+				fmt.Printf("Error computing overbooking allowance: %v\n", err)
+				continue
+			}
+
+			fmt.Printf("Route no-show rate: %.1f%% (%d of %d reservations on %d departed flights)\n",
+				stats.Rate*100, stats.NoShows, stats.Reservations, stats.Flights)
+			fmt.Printf("Capacity %d, unsold %d, authorized overbooking %d\n",
+				selectedFlight.FlightCapacity, selectedFlight.AvailableSeat, allowance)
+		case 3:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+
+			if err := app.overbookingService.Volunteer(reservationID); err != nil {
+				fmt.Printf("Error registering volunteer: %v\n", err)
+				continue
+			}
+			fmt.Printf("Reservation %s will give up its seat first if the flight is oversold\n", reservationID)
+		case 4:
+			return
+		}
+	}
+}
+
+// checkInRulesMenu configures check-in windows and documents, and records passenger
+// documents and flight status changes that affect check-in
+func (app *App) checkInRulesMenu() {
+	for {
+		fmt.Println("\n--- Manage Check-in Rules ---")
+		choice := app.validation.GetInteger("1. Set route rules - 2. Show flight check-in window - 3. Add travel document - 4. Update flight status - 5. Back: ",
+			"Must be an integer between 1 and 5", 1, 5)
+
+		switch choice {
+		case 1:
+			departureCity := app.validation.GetString("Enter departure city: ", "Departure city cannot be empty", false)
+			destinationCity := app.validation.GetString("Enter destination city: ", "Destination city cannot be empty", false)
+			opens := app.validation.GetInteger("Enter how many hours before departure check-in opens: ",
+				"Must be an integer between 1 and 168", 1, 168)
+			closes := app.validation.GetInteger("Enter how many minutes before departure check-in closes: ",
+				"Must be an integer between 0 and 240", 0, 240)
+
+			var documents []string
+			for _, document := range []string{domain.DocumentPassport, domain.DocumentVisa, domain.DocumentIDCard} {
+				if app.validation.CheckYesOrNo(fmt.Sprintf("Is a %s required? \nChoose 'Y' for YES || Choose 'N' for NO : ", document)) {
+					documents = append(documents, document)
+				}
+			}
+
+			if _, err := app.checkInRules.SetPolicy(departureCity, destinationCity, opens*60, closes, documents); err != nil {
+				fmt.Printf("Error saving check-in rules: %v\n", err)
+				continue
+			}
+			fmt.Printf("Check-in rules for %s - %s saved\n", departureCity, destinationCity)
+		case 2:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+
+			selectedFlight, err := app.flightService.GetFlight(flightNumber)
+			if err != nil {
+				fmt.Printf("Flight number does not exist: %v\n", err)
+				continue
+			}
+
+			policy, err := app.checkInRules.PolicyFor(selectedFlight)
+			if err != nil {
+				fmt.Printf("Error loading check-in rules: %v\n", err)
+				continue
+			}
+
+			opens, closes := policy.Window(selectedFlight)
+			fmt.Printf("Flight %s is %s\n", selectedFlight.FlightNumber, selectedFlight.CurrentStatus())
+			fmt.Printf("Check-in opens %s and closes %s\n", opens.Format("02/01/2006-15:04"), closes.Format("02/01/2006-15:04"))
+			if len(policy.RequiredDocuments) > 0 {
+				fmt.Printf("Required documents: %s\n", strings.Join(policy.RequiredDocuments, ", "))
+			}
+		case 3:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+			documentType := app.validation.GetString("Enter document type (passport, visa or id_card): ",
+				"Document type cannot be empty", false)
+			number := app.validation.GetString("Enter document number: ", "Document number cannot be empty", false)
+			expiry := app.validation.GetDate("Enter expiry date (format dd/MM/yyyy): ",
+				"Please follow our format, try again", "02/01/2006", false)
+
+			document := domain.TravelDocument{Type: strings.ToLower(documentType), Number: number, ExpiryAt: expiry}
+			if err := app.reservationService.AddTravelDocument(reservationID, document); err != nil {
+				fmt.Printf("Error adding travel document: %v\n", err)
+				continue
+			}
+			fmt.Printf("%s %s recorded for reservation %s\n", document.Type, number, reservationID)
+		case 4:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			status := app.validation.GetString("Enter new status (scheduled, delayed, cancelled or departed): ",
+				"Status cannot be empty", false)
+
+			if err := app.flightService.UpdateStatus(flightNumber, strings.ToLower(status)); err != nil {
+				fmt.Printf("Error updating flight status: %v\n", err)
+				continue
+			}
+			fmt.Printf("Flight %s is now %s\n", flightNumber, strings.ToLower(status))
+		case 5:
+			return
+		}
+	}
+}
+
+// boardingPassMenu prints bar coded boarding passes and documents, and reads scanned passes back
+func (app *App) boardingPassMenu() {
+	for {
+		fmt.Println("\n--- Boarding Passes and Documents ---")
+		choice := app.validation.GetInteger("1. Print boarding pass - 2. Save boarding pass images - 3. Save PDF boarding pass - "+
+			"4. Save PDF booking confirmation - 5. Scan boarding pass - 6. Configure airline brand - 7. Back: ",
+			"Must be an integer between 1 and 7", 1, 7)
+
+		switch choice {
+		case 1:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+			symbology := boardingpass.SymbologyQR
+			if app.validation.CheckYesOrNo("Print as PDF417 instead of QR? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+				symbology = boardingpass.SymbologyPDF417
+			}
+			app.printBarcode(reservationID, symbology)
+		case 2:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+			data, err := app.boardingPasses.IssueData(reservationID)
+			if err != nil {
+				fmt.Printf("Error issuing boarding pass: %v\n", err)
+				continue
+			}
+
+			for _, symbology := range []boardingpass.Symbology{boardingpass.SymbologyPDF417, boardingpass.SymbologyQR} {
+				symbology := symbology
+				path := filepath.Join(app.documentDir, fmt.Sprintf("%s-%s.png", reservationID, symbology))
+				if err := app.saveDocument(path, func(w io.Writer) error {
+					return boardingpass.WritePNG(w, data, symbology, 4)
+				}); err != nil {
+					fmt.Printf("Error saving boarding pass: %v\n", err)
+					continue
+				}
+				fmt.Printf("Saved %s\n", path)
+			}
+		case 3, 4:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+			brandID := app.validation.GetString("Enter brand ID (leave empty for the default brand): ", "", true)
+
+			kind, render := "boarding-pass", app.documents.BoardingPass
+			if choice == 4 {
+				kind, render = "confirmation", app.documents.Receipt
+			}
+
+			path := filepath.Join(app.documentDir, fmt.Sprintf("%s-%s.pdf", reservationID, kind))
+			if err := app.saveDocument(path, func(w io.Writer) error {
+				return render(w, reservationID, brandID)
+			}); err != nil {
+				fmt.Printf("Error saving document: %v\n", err)
+				continue
+			}
+			fmt.Printf("Saved %s\n", path)
+		case 5:
+			data := app.validation.GetString("Scan or paste the boarding pass data: ", "Boarding pass data cannot be empty", false)
+			reservation, scannedFlight, err := app.boardingPasses.Scan(data)
+			if err != nil {
+				fmt.Printf("Boarding pass rejected: %v\n", err)
+				continue
+			}
+			fmt.Printf("Valid boarding pass: reservation %s, %s, flight %s %s - %s, seat %s\n",
+				reservation.ReservationID, reservation.Name, scannedFlight.FlightNumber,
+				scannedFlight.DepartureCity, scannedFlight.DestinationCity, reservation.SeatLocation)
+		case 6:
+			app.configureBrand()
+		case 7:
+			return
+		}
+	}
+}
+
+// printBarcode prints the bar coded boarding pass of a reservation to the terminal
+func (app *App) printBarcode(reservationID string, symbology boardingpass.Symbology) {
+	data, err := app.boardingPasses.IssueData(reservationID)
+	if err != nil {
+		fmt.Printf("Error issuing boarding pass: %v\n", err)
+		return
+	}
+
+	code, err := boardingpass.ANSI(data, symbology)
+	if err != nil {
+		fmt.Printf("Error rendering boarding pass: %v\n", err)
+		return
+	}
+
+	fmt.Print(code)
+	fmt.Printf("BCBP: %s\n", data)
+}
+
+// saveDocument creates a file in the document directory and writes it
+func (app *App) saveDocument(path string, write func(w io.Writer) error) error {
+	if err := os.MkdirAll(app.documentDir, 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+
+	return file.Close()
+}
+
+// configureBrand creates or updates the airline brand used on printed documents
+func (app *App) configureBrand() {
+	id := app.validation.GetString("Enter brand ID: ", "Brand ID cannot be empty", false)
+
+	brand, err := app.documents.Brand(id)
+	if err != nil {
+		defaults := domain.DefaultBrand
+		brand = &defaults
+		brand.ID = id
+	}
+
+	input := func(prompt string, value *string) {
+		if entered := app.validation.GetString(fmt.Sprintf("%s [%s]: ", prompt, *value), "", true); entered != "" {
+			*value = entered
+		}
+	}
+	input("Airline name", &brand.AirlineName)
+	input("Primary colour (#RRGGBB)", &brand.PrimaryColor)
+	input("Accent colour (#RRGGBB)", &brand.AccentColor)
+	input("Font (DejaVu Sans)", &brand.Font)
+	input("Logo file (PNG or JPEG)", &brand.LogoPath)
+	input("Boarding pass footer template", &brand.BoardingPassFooter)
+	input("Booking confirmation footer template", &brand.ReceiptFooter)
+
+	if err := app.documents.SaveBrand(brand); err != nil {
+		fmt.Printf("Error saving brand: %v\n", err)
+		return
+	}
+	fmt.Printf("Brand %s saved\n", brand.ID)
+}
+
+// gateBoardingMenu runs boarding of a flight at the gate
+func (app *App) gateBoardingMenu() {
+	flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+		"Flight number should match the format Fxxxx", false)
+
+	for {
+		fmt.Printf("\n--- Gate Boarding: %s ---\n", flightNumber)
+		choice := app.validation.GetInteger("1. Open boarding - 2. Call next group - 3. Scan boarding pass - 4. Board by reservation ID - "+
+			"5. Boarding status - 6. Close boarding - 7. Back: ",
+			"Must be an integer between 1 and 7", 1, 7)
+
+		switch choice {
+		case 1:
+			scheme, groups := domain.BoardingByClass, 0
+			if app.validation.CheckYesOrNo("Board economy by seat rows from the back? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+				scheme = domain.BoardingByRows
+				groups = app.validation.GetInteger("Enter number of economy groups: ", "Must be an integer between 1 and 9", 1, 9)
+			}
+
+			session, err := app.boardingService.OpenBoarding(flightNumber, scheme, groups)
+			if err != nil {
+				fmt.Printf("Error opening boarding: %v\n", err)
+				continue
+			}
+			fmt.Printf("Boarding of flight %s is open with %d groups. Now boarding group 1.\n", flightNumber, session.GroupCount())
+		case 2:
+			group, err := app.boardingService.CallNextGroup(flightNumber)
+			if err != nil {
+				fmt.Printf("Error calling next group: %v\n", err)
+				continue
+			}
+			fmt.Printf("Now boarding group %d\n", group)
+		case 3, 4:
+			var reservation *domain.Reservation
+			var err error
+			if choice == 3 {
+				data := app.validation.GetString("Scan or paste the boarding pass data: ", "Boarding pass data cannot be empty", false)
+				reservation, err = app.boardingService.Scan(flightNumber, data)
+			} else {
+				reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+				reservation, err = app.boardingService.Board(flightNumber, reservationID)
+			}
+			if err != nil {
+				fmt.Printf("REJECTED: %v\n", err)
+				continue
+			}
+			fmt.Printf("BOARDED: %s, seat %s\n", reservation.Name, reservation.SeatLocation)
+		case 5:
+			status, err := app.boardingService.Status(flightNumber)
+			if err != nil {
+				fmt.Printf("Error loading boarding status: %v\n", err)
+				continue
+			}
+
+			state := fmt.Sprintf("open, boarding group %d", status.Session.CalledGroup)
+			if !status.Session.IsOpen() {
+				state = "closed at " + status.Session.ClosedAt.Format("15:04")
+			}
+			fmt.Printf("Boarding %s (%s scheme)\n", state, status.Session.Scheme)
+			fmt.Println("+-------+-----------+---------+")
+			fmt.Println("| Group | Checked in| Boarded |")
+			fmt.Println("+-------+-----------+---------+")
+			for _, group := range status.Groups {
+				fmt.Printf("| %-5d | %-9d | %-7d |\n", group.Group, group.CheckedIn, group.Boarded)
+			}
+			fmt.Println("+-------+-----------+---------+")
+		case 6:
+			if !app.validation.CheckYesOrNo("Close boarding and offload passengers who have not boarded? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+				continue
+			}
+
+			reconciliation, err := app.boardingService.CloseBoarding(flightNumber)
+			if err != nil {
+				fmt.Printf("Error closing boarding: %v\n", err)
+				continue
+			}
+			fmt.Printf("Boarding closed: %d checked in, %d boarded, %d offloaded\n",
+				reconciliation.CheckedIn, reconciliation.Boarded, len(reconciliation.Offloaded))
+			if len(reconciliation.Offloaded) > 0 {
+				fmt.Printf("Offloaded reservations: %s\n", strings.Join(reconciliation.Offloaded, ", "))
+			}
+		case 7:
+			return
+		}
+	}
+}
+
+// manifestMenu displays and exports flight manifests and closes flights
+func (app *App) manifestMenu() {
+	flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+		"Flight number should match the format Fxxxx", false)
+
+	for {
+		fmt.Printf("\n--- Flight Manifest: %s ---\n", flightNumber)
+		choice := app.validation.GetInteger("1. Display manifest - 2. Export manifest - 3. Close flight - 4. Back: ",
+			"Must be an integer between 1 and 4", 1, 4)
+
+		switch choice {
+		case 1:
+			flightManifest, err := app.manifestService.Generate(flightNumber)
+			if err != nil {
+				fmt.Printf("Error generating manifest: %v\n", err)
+				continue
+			}
+			app.displayManifest(flightManifest)
+		case 2:
+			flightManifest, err := app.manifestService.Generate(flightNumber)
+			if err != nil {
+				fmt.Printf("Error generating manifest: %v\n", err)
+				continue
+			}
+
+			formats := []string{manifest.FormatCSV, manifest.FormatJSON, manifest.FormatAPIS, manifest.FormatBags}
+			format := formats[app.validation.GetInteger("Select format (1. CSV - 2. JSON - 3. APIS fixed-width - 4. Baggage load list CSV): ",
+				"Must be an integer between 1 and 4", 1, 4)-1]
+			name := "manifest." + format
+			switch format {
+			case manifest.FormatAPIS:
+				name = "manifest.txt"
+			case manifest.FormatBags:
+				name = "loadlist.csv"
+			}
+
+			path := filepath.Join(app.documentDir, fmt.Sprintf("%s-%s", flightNumber, name))
+			if err := app.saveDocument(path, func(w io.Writer) error {
+				return manifest.Write(w, flightManifest, format)
+			}); err != nil {
+				fmt.Printf("Error exporting manifest: %v\n", err)
+				continue
+			}
+			fmt.Printf("Saved %s\n", path)
+		case 3:
+			if !app.validation.CheckYesOrNo("Close the flight and freeze its manifest? No booking, check-in or cancellation will be possible afterwards. \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+				continue
+			}
+
+			flightManifest, err := app.manifestService.CloseFlight(flightNumber)
+			if err != nil {
+				fmt.Printf("Error closing flight: %v\n", err)
+				continue
+			}
+			fmt.Printf("Flight %s closed with %d passengers (%d boarded) and %d crew\n", flightNumber,
+				len(flightManifest.Passengers), flightManifest.Boarded(), len(flightManifest.Crew))
+		case 4:
+			return
+		}
+	}
+}
+
+// displayManifest prints the passenger and crew lists of a manifest
+func (app *App) displayManifest(flightManifest *domain.Manifest) {
+	state := "PRELIMINARY"
+	if flightManifest.Final {
+		state = "FINAL"
+	}
+	fmt.Printf("%s manifest of flight %s %s - %s, departing %s\n", state, flightManifest.FlightNumber,
+		flightManifest.DepartureCity, flightManifest.DestinationCity, flightManifest.DepartureTime.Format("02/01/2006-15:04"))
+
+	fmt.Println("+--------------+--------------------+--------------------+------+------------------+---------+---------+")
+	fmt.Println("|ReservationID |        Name        |   ID Card Number   | Seat |      Status      | Checked | Boarded |")
+	fmt.Println("+--------------+--------------------+--------------------+------+------------------+---------+---------+")
+	for _, passenger := range flightManifest.Passengers {
+		fmt.Printf("| %-12s | %-18s | %-18d | %-4s | %-16s | %-7t | %-7t |\n", passenger.ReservationID, passenger.Name,
+			passenger.IdentityCardNumber, passenger.Seat, passenger.Status, passenger.CheckedIn, passenger.Boarded)
+	}
+	fmt.Println("+--------------+--------------------+--------------------+------+------------------+---------+---------+")
+
+	for _, passenger := range flightManifest.Passengers {
+		if len(passenger.SpecialServices) > 0 {
+			fmt.Printf("  %s %s: %s\n", passenger.ReservationID, passenger.Name, strings.Join(passenger.SpecialServices, " "))
+		}
+	}
+
+	fmt.Println("Crew:")
+	for _, crew := range flightManifest.Crew {
+		fmt.Printf("  %-8s %-20s %s\n", crew.ID, crew.Name, crew.Position)
+	}
+
+	if len(flightManifest.Bags) > 0 {
+		fmt.Printf("Baggage load list (%.1f kg to load):\n", flightManifest.BaggageWeight())
+		for _, bag := range flightManifest.Bags {
+			action := ""
+			if bag.Offload {
+				action = "OFFLOAD"
+			}
+			fmt.Printf("  %s  %-8s %-20s %5.1f kg  %-10s %s\n", bag.TagNumber, bag.ReservationID, bag.Name, bag.WeightKg, bag.Status, action)
+		}
+	}
+}
+
+// baggageMenu checks bags at check-in and tracks them until they arrive
+func (app *App) baggageMenu() {
+	for {
+		fmt.Println("\n--- Baggage ---")
+		choice := app.validation.GetInteger("1. Check a bag - 2. Track a bag - 3. Update bag status - 4. Load flight - 5. Back: ",
+			"Must be an integer between 1 and 5", 1, 5)
+
+		switch choice {
+		case 1:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+			reservation, err := app.reservationService.GetReservation(reservationID)
+			if err != nil {
+				fmt.Printf("No such reservation ID found: %v\n", err)
+				continue
+			}
+
+			allowance := app.baggageService.Allowance(reservation)
+			fmt.Printf("Allowance: %d piece(s) of up to %.0f kg, %d already checked\n",
+				allowance.Pieces, allowance.MaxPieceKg, len(reservation.Bags))
+
+			weight := app.validation.GetInteger("Enter bag weight in kg: ", "Must be an integer between 1 and 32", 1, 32)
+			bag, err := app.baggageService.CheckBag(reservationID, float64(weight))
+			if err != nil {
+				fmt.Printf("Error checking bag: %v\n", err)
+				continue
+			}
+
+			fmt.Printf("Bag tag %s issued\n", bag.TagNumber)
+			if bag.Charge > 0 {
+				fmt.Printf("Excess baggage charge: %s\n", domain.FormatAmount(bag.Charge, flight.DefaultFareRules.Currency))
+			}
+		case 2:
+			tagNumber := app.validation.GetString("Enter bag tag number: ", "Bag tag number cannot be empty", false)
+			reservation, bag, err := app.baggageService.FindBag(tagNumber)
+			if err != nil {
+				fmt.Printf("Error finding bag: %v\n", err)
+				continue
+			}
+
+			fmt.Printf("Bag %s of %s (reservation %s, flight %s), %.1f kg, %s\n", bag.TagNumber, reservation.Name,
+				reservation.ReservationID, reservation.ReservationFlightNumber, bag.WeightKg, bag.Status)
+			for _, event := range bag.History {
+				fmt.Printf("  %s  %-10s %s\n", event.At.Format("02/01/2006-15:04"), event.Status, event.Location)
+			}
+		case 3:
+			tagNumber := app.validation.GetString("Enter bag tag number: ", "Bag tag number cannot be empty", false)
+			statuses := []string{domain.BagLoaded, domain.BagArrived, domain.BagMishandled}
+			status := statuses[app.validation.GetInteger("Select status (1. Loaded - 2. Arrived - 3. Mishandled): ",
+				"Must be an integer between 1 and 3", 1, 3)-1]
+			location := app.validation.GetString("Enter location: ", "Location cannot be empty", false)
+
+			if _, err := app.baggageService.UpdateBagStatus(tagNumber, status, location); err != nil {
+				fmt.Printf("Error updating bag: %v\n", err)
+				continue
+			}
+			fmt.Printf("Bag %s is now %s\n", tagNumber, status)
+		case 4:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+
+			loaded, err := app.baggageService.LoadFlight(flightNumber)
+			if err != nil {
+				fmt.Printf("Error loading bags: %v\n", err)
+				continue
+			}
+			fmt.Printf("%d bag(s) loaded on flight %s\n", loaded, flightNumber)
+		case 5:
+			return
+		}
+	}
+}
+
+// specialServicesMenu records special service requests and reports them for catering and ground handling
+func (app *App) specialServicesMenu() {
+	for {
+		fmt.Println("\n--- Special Service Requests ---")
+		choice := app.validation.GetInteger("1. Add request - 2. Remove request - 3. Set exit rows - 4. Set flight limit - 5. Flight summary - 6. Back: ",
+			"Must be an integer between 1 and 6", 1, 6)
+
+		switch choice {
+		case 1:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+			fmt.Println("Codes:")
+			for _, code := range domain.SpecialServiceCodes() {
+				fmt.Printf("  %s  %s\n", code, domain.SpecialServices[code].Description)
+			}
+			code := app.validation.GetString("Enter code: ", "Code cannot be empty", false)
+			text := app.validation.GetString("Enter details (optional): ", "", true)
+
+			if err := app.ssrService.AddSpecialService(reservationID, code, text); err != nil {
+				fmt.Printf("Error adding request: %v\n", err)
+				continue
+			}
+			fmt.Printf("%s added to reservation %s\n", strings.ToUpper(code), reservationID)
+		case 2:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+			code := app.validation.GetString("Enter code: ", "Code cannot be empty", false)
+
+			if err := app.ssrService.RemoveSpecialService(reservationID, code); err != nil {
+				fmt.Printf("Error removing request: %v\n", err)
+				continue
+			}
+			fmt.Printf("%s removed from reservation %s\n", strings.ToUpper(code), reservationID)
+		case 3:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			input := app.validation.GetString("Enter exit rows separated by commas (empty for none): ", "", true)
+
+			rows := []int{}
+			valid := true
+			for _, field := range strings.Split(input, ",") {
+				field = strings.TrimSpace(field)
+				if field == "" {
+					continue
+				}
+				row, err := strconv.Atoi(field)
+				if err != nil {
+					fmt.Printf("Invalid row %q\n", field)
+					valid = false
+					break
+				}
+				rows = append(rows, row)
+			}
+			if !valid {
+				continue
+			}
+
+			if err := app.ssrService.SetExitRows(flightNumber, rows); err != nil {
+				fmt.Printf("Error setting exit rows: %v\n", err)
+				continue
+			}
+			fmt.Println("Exit rows updated")
+		case 4:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			code := app.validation.GetString("Enter code: ", "Code cannot be empty", false)
+			limit := app.validation.GetInteger("Enter maximum requests on the flight (0 for no limit): ",
+				"Must be an integer between 0 and 999", 0, 999)
+
+			if err := app.ssrService.SetLimit(flightNumber, code, limit); err != nil {
+				fmt.Printf("Error setting limit: %v\n", err)
+				continue
+			}
+			fmt.Println("Limit updated")
+		case 5:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+
+			summary, err := app.ssrService.Summary(flightNumber)
+			if err != nil {
+				fmt.Printf("Error building summary: %v\n", err)
+				continue
+			}
+			app.displaySSRSummary(summary)
+		case 6:
+			return
+		}
+	}
+}
+
+// seatsMenu configures seat attributes and prices and records seating wishes of passengers
+func (app *App) seatsMenu() {
+	for {
+		fmt.Println("\n--- Manage Seats ---")
+		choice := app.validation.GetInteger("1. Show seats of a flight - 2. Set seat attributes - 3. Set seat price - 4. Set seat preference - 5. Group reservations - 6. Set business cabin rows - 7. Save seat map (JSON/SVG) - 8. Back: ",
+			"Must be an integer between 1 and 8", 1, 8)
+
+		switch choice {
+		case 1:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			selectedFlight, err := app.flightService.GetFlight(flightNumber)
+			if err != nil {
+				fmt.Printf("Error finding flight: %v\n", err)
+				continue
+			}
+
+			app.displaySeatsMap(flightNumber, "")
+			for _, seat := range selectedFlight.SeatNumbers() {
+				state := "free"
+				if !selectedFlight.SeatList[seat] {
+					state = "occupied"
+				}
+				price := ""
+				if charge := selectedFlight.SeatPrice(seat); charge > 0 {
+					price = domain.FormatAmount(charge, flight.DefaultFareRules.Currency)
+				}
+				fmt.Printf("  %-4s %-9s %-14s %s\n", seat, state, price, domain.FormatSeatAttributes(selectedFlight.AttributesOf(seat)))
+			}
+		case 2:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			seat := app.validation.GetString("Enter seat number: ", "Seat number cannot be empty", false)
+			input := app.validation.GetString(fmt.Sprintf("Enter attributes separated by commas (%s; empty for none): ",
+				strings.Join(domain.ConfigurableSeatAttributes, ", ")), "", true)
+
+			attributes := []string{}
+			for _, field := range strings.Split(input, ",") {
+				if field = strings.TrimSpace(field); field != "" {
+					attributes = append(attributes, field)
+				}
+			}
+
+			if err := app.seatService.SetSeatAttributes(flightNumber, seat, attributes); err != nil {
+				fmt.Printf("Error setting seat attributes: %v\n", err)
+				continue
+			}
+			fmt.Printf("Seat %s updated\n", seat)
+		case 3:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			attribute := app.validation.GetString("Enter seat attribute (e.g. extra_legroom, exit_row, window): ",
+				"Attribute cannot be empty", false)
+			amount := app.validation.GetInteger("Enter price in cents: ", "Must be an integer between 0 and 100000", 0, 100000)
+
+			if err := app.seatService.SetSeatPrice(flightNumber, attribute, int64(amount)); err != nil {
+				fmt.Printf("Error setting seat price: %v\n", err)
+				continue
+			}
+			fmt.Println("Seat price updated")
+		case 4:
+			reservationID := app.validation.GetString("Please input reservation ID: ", "Reservation ID cannot be empty", false)
+			positions := []string{domain.SeatWindow, domain.SeatAisle, ""}
+			position := positions[app.validation.GetInteger("Preferred position (1. Window - 2. Aisle - 3. No preference): ",
+				"Must be an integer between 1 and 3", 1, 3)-1]
+			preference := domain.SeatPreference{
+				Position:     position,
+				ExtraLegroom: app.validation.CheckYesOrNo("Extra legroom? \nChoose 'Y' for YES || Choose 'N' for NO : "),
+				Bassinet:     app.validation.CheckYesOrNo("Travelling with an infant? \nChoose 'Y' for YES || Choose 'N' for NO : "),
+			}
+
+			if err := app.seatService.SetPreference(reservationID, preference); err != nil {
+				fmt.Printf("Error setting preference: %v\n", err)
+				continue
+			}
+			fmt.Println("Seat preference saved")
+		case 5:
+			input := app.validation.GetString("Enter reservation IDs separated by commas: ", "Reservation IDs cannot be empty", false)
+			reservationIDs := []string{}
+			for _, field := range strings.Split(input, ",") {
+				if field = strings.TrimSpace(field); field != "" {
+					reservationIDs = append(reservationIDs, field)
+				}
+			}
+
+			groupID, err := app.seatService.GroupReservations(reservationIDs)
+			if err != nil {
+				fmt.Printf("Error grouping reservations: %v\n", err)
+				continue
+			}
+			fmt.Printf("Reservations grouped as %s and will be seated together\n", groupID)
+		case 6:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			rows := app.validation.GetInteger("Enter the number of business cabin rows: ", "Must be an integer between 0 and 999", 0, 999)
+
+			if err := app.seatService.SetBusinessRows(flightNumber, rows); err != nil {
+				fmt.Printf("Error setting business rows: %v\n", err)
+				continue
+			}
+			fmt.Println("Cabin layout updated")
+		case 7:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			formats := []string{seatmap.FormatJSON, seatmap.FormatSVG}
+			format := formats[app.validation.GetInteger("Select format (1. JSON - 2. SVG): ", "Must be an integer between 1 and 2", 1, 2)-1]
+
+			seatMap, err := app.seatService.SeatMap(flightNumber, app.session, "")
+			if err != nil {
+				fmt.Printf("Error building seat map: %v\n", err)
+				continue
+			}
+
+			path := filepath.Join(app.documentDir, fmt.Sprintf("seatmap-%s.%s", flightNumber, format))
+			if err := app.saveDocument(path, func(w io.Writer) error {
+				return seatmap.Write(w, seatMap, format)
+			}); err != nil {
+				fmt.Printf("Error saving seat map: %v\n", err)
+				continue
+			}
+			fmt.Printf("Seat map saved to %s\n", path)
+		case 8:
 			return
 		}
-		
-		// Enter customer information
-		name := app.validation.GetString("Enter name: ", "Name cannot be empty", false)
-		address := app.validation.GetString("Enter address: ", "Address cannot be empty", false)
-		phoneNumber := app.validation.GetLong("Enter phone number: ", "Phone number must be a valid number", false)
-		idCardNumber := app.validation.GetLong("Enter identity card number: ", "ID card number must be a valid number", false)
-		
-		// Create reservation
-		reservation, err := app.reservationService.BookFlight(name, address, phoneNumber, idCardNumber, selectedFlight.FlightNumber)
-		if err != nil {
-			fmt.Printf("Error booking flight: %v\n", err)
+	}
+}
+
+// displaySSRSummary prints the special service requests of a flight for catering and ground handling
+func (app *App) displaySSRSummary(summary *flight.SSRSummary) {
+	fmt.Printf("Special service requests of flight %s\n", summary.FlightNumber)
+	if len(summary.Counts) == 0 {
+		fmt.Println("No special service requests")
+		return
+	}
+
+	fmt.Print("Totals:")
+	for _, code := range domain.SpecialServiceCodes() {
+		if count := summary.Counts[code]; count > 0 {
+			fmt.Printf(" %s %d", code, count)
+		}
+	}
+	fmt.Println()
+
+	sections := []struct {
+		title string
+		lines []flight.SSRLine
+	}{
+		{"Catering", summary.Catering},
+		{"Ground handling", summary.GroundHandling},
+	}
+	for _, section := range sections {
+		if len(section.lines) == 0 {
 			continue
 		}
-		
-		fmt.Printf("Reservation ID: %s added successfully.\nReservation ID is required for check-in progress, selecting a seat, and receiving a boarding pass\n\n",
-			reservation.ReservationID)
-		fmt.Println(reservation)
-		fmt.Println("When you go to the airport, please select the 'Flight check-in' option to choose your seat and receive your boarding pass.\n")
-		
-		if !app.validation.CheckYesOrNo("Do you want to create another reservation? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
-			break
+		fmt.Printf("%s:\n", section.title)
+		for _, line := range section.lines {
+			seat := line.Seat
+			if seat == "" {
+				seat = "-"
+			}
+			fmt.Printf("  %-4s %-8s %-20s %-8s %-4s %s\n", line.Code, line.ReservationID, line.Name, line.Class, seat, line.Text)
+		}
+	}
+}
+
+// faresMenu sets the fares and departure gates of flights
+func (app *App) faresMenu() {
+	for {
+		fmt.Println("\n--- Manage Fares and Gates ---")
+		choice := app.validation.GetInteger("1. Set fare - 2. Set gate - 3. Back: ",
+			"Must be an integer between 1 and 3", 1, 3)
+		if choice == 3 {
+			return
+		}
+
+		flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+			"Flight number should match the format Fxxxx", false)
+
+		switch choice {
+		case 1:
+			class := app.inputClass()
+			cents := app.validation.GetInteger("Enter the base fare in cents: ", "Must be an integer between 0 and 10000000", 0, 10000000)
+
+			if err := app.flightService.SetFare(flightNumber, class, int64(cents)); err != nil {
+				fmt.Printf("Error setting fare: %v\n", err)
+				continue
+			}
+			fmt.Printf("%s fare of flight %s set to %s\n", class, flightNumber,
+				domain.FormatAmount(int64(cents), flight.DefaultFareRules.Currency))
+		case 2:
+			gate := app.validation.GetString("Enter departure gate: ", "Gate cannot be empty", false)
+
+			if err := app.flightService.SetGate(flightNumber, strings.ToUpper(gate)); err != nil {
+				fmt.Printf("Error setting gate: %v\n", err)
+				continue
+			}
+			fmt.Printf("Flight %s departs from gate %s\n", flightNumber, strings.ToUpper(gate))
 		}
 	}
 }
@@ -248,7 +1404,7 @@ func (app *App) checkInMenu() {
 		}
 		
 		// Find the flight
-		flight, err := app.flightService.GetFlight(reservation.ReservationFlightNumber)
+		reservedFlight, err := app.flightService.GetFlight(reservation.ReservationFlightNumber)
 		if err != nil {
 			fmt.Printf("No such flight found for this reservation: %v\n", err)
 			return
@@ -259,15 +1415,62 @@ func (app *App) checkInMenu() {
 			return
 		}
 		
+		// Check eligibility before the passenger chooses a seat
+		if err := app.checkInRules.Check(reservation, reservedFlight, time.Now()); err != nil {
+			fmt.Printf("Check-in is not possible: %v\n", err)
+			return
+		}
+		
 		// Display available seats and let the user select one
 		fmt.Println("Please choose your seat on this journey:")
-		app.displaySeatsMap(flight)
+		app.displaySeatsMap(reservedFlight.FlightNumber, "")
+		
+		heldSeats, err := app.holdService.HeldSeats(reservedFlight.FlightNumber, app.session)
+		if err != nil {
+			fmt.Printf("Error checking held seats: %v\n", err)
+			return
+		}
 		
-		seatNumber := app.validation.GetString("Enter the seat number you want to choose: ", 
-			"Seat number cannot be empty", false)
+		seatNumber := ""
+		if !reservedFlight.HasFreeSeat(heldSeats) {
+			fmt.Println("This flight is oversold and no seat is free. Applying the denied-boarding rules.")
+		} else if app.validation.CheckYesOrNo("Do you want to choose your seat? Seats are assigned for free otherwise. \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+			seatNumber = app.validation.GetString("Enter the seat number you want to choose: ", 
+				"Seat number cannot be empty", false)
+			
+			// Hold the seat while the passenger confirms
+			hold, err := app.holdService.HoldSeat(app.session, reservedFlight.FlightNumber, seatNumber)
+			if err != nil {
+				fmt.Printf("Cannot choose this seat: %v\n", err)
+				continue
+			}
+			
+			price := ""
+			if charge, err := app.seatService.SeatCharge(reservationID, seatNumber); err == nil && charge > 0 {
+				price = fmt.Sprintf(" for %s", domain.FormatAmount(charge, flight.DefaultFareRules.Currency))
+			}
+			app.displaySeatsMap(reservedFlight.FlightNumber, seatNumber)
+			if attributes := reservedFlight.AttributesOf(seatNumber); len(attributes) > 0 {
+				fmt.Printf("Seat %s: %s\n", seatNumber, domain.FormatSeatAttributes(attributes))
+			}
+			if !app.validation.CheckYesOrNo(fmt.Sprintf("Seat %s is held for you until %s. Confirm this seat%s? \nChoose 'Y' for YES || Choose 'N' for NO : ",
+				seatNumber, hold.ExpiresAt.Format("15:04:05"), price)) {
+				app.holdService.Release(app.session, hold.ID)
+				continue
+			}
+		}
 		
 		// Perform check-in
-		err = app.reservationService.CheckIn(reservationID, seatNumber)
+		err = app.reservationService.CheckIn(reservationID, seatNumber, app.session)
+		if errors.Is(err, flight.ErrDeniedBoarding) {
+			fmt.Printf("Sorry, %v. Please see the check-in agent about compensation and rebooking.\n", err)
+			return
+		}
+		var rejection *domain.CheckInRejection
+		if errors.As(err, &rejection) {
+			fmt.Printf("Check-in is not possible (%s): %s\n", rejection.Reason, rejection.Message)
+			return
+		}
 		if err != nil {
 			fmt.Printf("Error checking in: %v\n", err)
 			continue
@@ -277,7 +1480,8 @@ func (app *App) checkInMenu() {
 		reservation, _ = app.reservationService.GetReservation(reservationID)
 		
 		// Display boarding pass
-		fmt.Println(reservation.BoardingPassToString(flight))
+		fmt.Println(reservation.BoardingPassToString(reservedFlight))
+		app.printBarcode(reservationID, boardingpass.SymbologyQR)
 		
 		if !app.validation.CheckYesOrNo("Do you want to get another boarding pass? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
 			break
@@ -307,7 +1511,7 @@ func (app *App) assignCrewMenu() {
 		
 		// Check if flight already has crew assigned
 		if len(flight.CrewMembers) > 0 {
-			fmt.Println("This flight already has a crew assigned.")
+			fmt.Println("This flight already has a crew assigned. Use 'Edit Crew of a Flight' to change it.")
 			return
 		}
 		
@@ -344,8 +1548,6 @@ func (app *App) assignCrewMenu() {
 func (app *App) inputCrew() []domain.Crew {
 	crewList := []domain.Crew{}
 	pilotCount := 0
-	attendantCount := 0
-	groundStaffCount := 0
 	
 	fmt.Println("+-------------------------------------Assign-Crew------------------------------------------+")
 	fmt.Println("|<> Please assign crew base on our instruction:                                            |")
@@ -376,14 +1578,12 @@ func (app *App) inputCrew() []domain.Crew {
 				fmt.Println("Maximum 2 pilots allowed.")
 				continue
 			}
-			position = "Pilot"
+			position = domain.PositionPilot
 			pilotCount++
 		case 2:
-			position = "Attendant"
-			attendantCount++
+			position = domain.PositionAttendant
 		case 3:
-			position = "Ground Staff"
-			groundStaffCount++
+			position = domain.PositionGroundStaff
 		default:
 			fmt.Println("Invalid position")
 			continue
@@ -393,14 +1593,351 @@ func (app *App) inputCrew() []domain.Crew {
 	}
 	
 	// Check if we have at least one of each position
-	if pilotCount < 1 || attendantCount < 1 || groundStaffCount < 1 {
-		fmt.Println("You must have at least one crew member for each position (Pilot, Attendant, Ground Staff).")
+	if err := domain.ValidateCrew(crewList); err != nil {
+		fmt.Printf("Invalid crew: %v\n", err)
 		return []domain.Crew{}
 	}
 	
 	return crewList
 }
 
+// editCrewMenu handles adding, removing and swapping crew members on a flight with a crew
+func (app *App) editCrewMenu() {
+	fmt.Println("\n--- Edit Crew of a Flight ---")
+
+	flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+		"Flight number should match the format Fxxxx", false)
+
+	if !app.validation.ValidateFlightNumber(flightNumber) {
+		fmt.Println("Flight number must be in the format Fxxxx (e.g., F1234)")
+		return
+	}
+
+	// The logged-in user is recorded in the crew change history
+	changedBy := app.user.Username
+
+	for {
+		flight, err := app.flightService.GetFlight(flightNumber)
+		if err != nil {
+			fmt.Printf("Flight number does not exist: %v\n", err)
+			return
+		}
+
+		fmt.Println("+-------------------+-------------------+")
+		fmt.Println("|        Name       |     Position      |")
+		fmt.Println("+-------------------+-------------------+")
+		for _, crew := range flight.CrewMembers {
+			fmt.Printf("|%-18s |%-18s |\n", crew.Name, crew.Position)
+			fmt.Println("+-------------------+-------------------+")
+		}
+
+		choice := app.validation.GetInteger("1. Add crew member - 2. Remove crew member - 3. Swap crew member - 4. Show change history - 5. Back: ",
+			"Must be an integer between 1 and 5", 1, 5)
+
+		switch choice {
+		case 1:
+			member := app.inputCrewMember("Enter registry ID or name of the new crew member: ")
+			err = app.flightService.AddCrewMember(flightNumber, member, changedBy)
+		case 2:
+			idOrName := app.validation.GetString("Enter registry ID or name of the crew member to remove: ",
+				"Name should not be blank", false)
+			err = app.flightService.RemoveCrewMember(flightNumber, idOrName, changedBy)
+		case 3:
+			idOrName := app.validation.GetString("Enter registry ID or name of the crew member to replace: ",
+				"Name should not be blank", false)
+			member := app.inputCrewMember("Enter registry ID or name of the replacement: ")
+			err = app.flightService.SwapCrewMember(flightNumber, idOrName, member, changedBy)
+		case 4:
+			app.displayCrewChanges(flightNumber)
+			continue
+		case 5:
+			return
+		}
+
+		if err != nil {
+			fmt.Printf("Error changing crew: %v\n", err)
+			continue
+		}
+		fmt.Println("Crew updated successfully")
+	}
+}
+
+// inputCrewMember reads a crew member, taking the details from the crew registry when an ID is given
+func (app *App) inputCrewMember(prompt string) domain.Crew {
+	idOrName := app.validation.GetString(prompt, "Name should not be blank", false)
+
+	member, err := app.crewService.GetCrewMember(idOrName)
+	if err == nil {
+		return member.AsCrew()
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		fmt.Printf("Error reading the crew registry, entering %s by name: %v\n", idOrName, err)
+	}
+
+	choice := app.validation.GetInteger("Input their position (1. Pilot - 2. Attendant - 3. Ground Staff): ",
+		"Must be an integer between 1 and 3", 1, 3)
+	positions := []string{domain.PositionPilot, domain.PositionAttendant, domain.PositionGroundStaff}
+
+	return domain.Crew{Name: idOrName, Position: positions[choice-1]}
+}
+
+// displayCrewChanges shows the crew change history of a flight
+func (app *App) displayCrewChanges(flightNumber string) {
+	changes, err := app.flightService.GetCrewChanges(flightNumber)
+	if err != nil {
+		fmt.Printf("Error retrieving crew changes: %v\n", err)
+		return
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No crew changes recorded for this flight")
+		return
+	}
+
+	fmt.Println("+------------------+--------+--------------------------------+--------------------------------+-------------------+")
+	fmt.Println("|    Changed at    | Action |            Removed             |             Added              |    Changed by     |")
+	fmt.Println("+------------------+--------+--------------------------------+--------------------------------+-------------------+")
+	for _, change := range changes {
+		removed, added := "", ""
+		if change.Removed != nil {
+			removed = fmt.Sprintf("%s (%s)", change.Removed.Name, change.Removed.Position)
+		}
+		if change.Added != nil {
+			added = fmt.Sprintf("%s (%s)", change.Added.Name, change.Added.Position)
+		}
+		fmt.Printf("| %-16s | %-6s | %-30s | %-30s | %-17s |\n", change.ChangedAt.Format("02/01/2006-15:04"),
+			change.Action, removed, added, change.ChangedBy)
+	}
+	fmt.Println("+------------------+--------+--------------------------------+--------------------------------+-------------------+")
+}
+
+// registerCrewMenu handles adding crew members to the crew registry
+func (app *App) registerCrewMenu() {
+	fmt.Println("\n--- Register Crew Member ---")
+
+	for {
+		name := app.validation.GetString("Enter name of crew member: ", "Name should not be blank", false)
+
+		choice := app.validation.GetInteger("Input their position (1. Pilot - 2. Attendant - 3. Ground Staff): ",
+			"Must be an integer between 1 and 3", 1, 3)
+		positions := []string{domain.PositionPilot, domain.PositionAttendant, domain.PositionGroundStaff}
+
+		homeBase := app.validation.GetString("Enter home base city: ", "Home base cannot be empty", false)
+
+		member, err := app.crewService.RegisterCrewMember(name, positions[choice-1], homeBase)
+		if err != nil {
+			fmt.Printf("Error registering crew member: %v\n", err)
+			continue
+		}
+
+		fmt.Printf("Crew member %s registered with ID %s\n", member.Name, member.ID)
+
+		if !app.validation.CheckYesOrNo("Do you want to register another crew member? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+			break
+		}
+	}
+}
+
+// generateRosterMenu proposes a crew roster for a date range and commits it on request
+func (app *App) generateRosterMenu() {
+	fmt.Println("\n--- Generate Crew Roster ---")
+
+	from := app.validation.GetDate("Enter first departure date of the roster (dd/mm/yyyy): ",
+		"Please follow our format and input realistic times, try again", "02/01/2006", false)
+	to := app.validation.GetDate("Enter last departure date of the roster (dd/mm/yyyy): ",
+		"Please follow our format and input realistic times, try again", "02/01/2006", false)
+
+	roster, err := app.rosterGenerator.Generate(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		fmt.Printf("Error generating roster: %v\n", err)
+		return
+	}
+
+	if len(roster.Assignments) == 0 {
+		fmt.Println("No flights without crew found in this period.")
+		return
+	}
+
+	// Display proposed assignments
+	fmt.Println("+--------------+-------------------+-------------------+------------------------------+")
+	fmt.Println("|Flight number |        Name       |     Position      |            Status            |")
+	fmt.Println("+--------------+-------------------+-------------------+------------------------------+")
+	for _, assignment := range roster.Assignments {
+		status := "Complete"
+		if !assignment.Complete() {
+			var missing []string
+			for position, count := range assignment.Missing {
+				missing = append(missing, fmt.Sprintf("%d %s", count, position))
+			}
+			status = "Missing " + strings.Join(missing, ", ")
+		}
+
+		fmt.Printf("| %-12s | %-17s | %-17s | %-28s |\n", assignment.FlightNumber, "", "", status)
+		for _, member := range assignment.Crew {
+			fmt.Printf("| %-12s | %-17s | %-17s | %-28s |\n", "", member.Name, member.Position, "")
+		}
+		fmt.Println("+--------------+-------------------+-------------------+------------------------------+")
+	}
+
+	// Display crew workload
+	fmt.Println("+--------+-------------------+--------+------------+--------------------+")
+	fmt.Println("|   ID   |        Name       | Duties | Duty time  |    Ends roster in  |")
+	fmt.Println("+--------+-------------------+--------+------------+--------------------+")
+	for _, summary := range roster.Summary {
+		endLocation := summary.EndLocation
+		if !summary.EndsAtHome {
+			endLocation += " (away)"
+		}
+		fmt.Printf("| %-6s | %-17s | %6d | %10s | %-18s |\n", summary.Member.ID, summary.Member.Name,
+			summary.Duties, summary.DutyTime, endLocation)
+	}
+	fmt.Println("+--------+-------------------+--------+------------+--------------------+")
+
+	if !app.validation.CheckYesOrNo("Do you want to commit the complete assignments? \nChoose 'Y' for YES || Choose 'N' for NO : ") {
+		return
+	}
+
+	committed, err := roster.Commit(app.flightService)
+	if err != nil {
+		fmt.Printf("Error committing roster: %v\n", err)
+	}
+	fmt.Printf("Crew assigned to %d flight(s)\n", committed)
+}
+
+// airplaneMenu handles airplanes, their maintenance and their assignment to flights
+func (app *App) airplaneMenu() {
+	for {
+		fmt.Println("\n--- Manage Airplanes ---")
+		choice := app.validation.GetInteger("1. Add airplane - 2. List airplanes - 3. Schedule maintenance - 4. Assign airplane to flight - 5. Maintenance report - 6. Back: ",
+			"Must be an integer between 1 and 6", 1, 6)
+
+		switch choice {
+		case 1:
+			id := app.validation.GetString("Enter airplane registration: ", "Registration cannot be empty", false)
+			model := app.validation.GetString("Enter airplane model: ", "Model cannot be empty", false)
+			capacity := app.validation.GetInteger("Enter seat capacity: ",
+				fmt.Sprintf("Seat capacity must be between %d and %d", domain.MinFlightCapacity, domain.MaxFlightCapacity),
+				domain.MinFlightCapacity, domain.MaxFlightCapacity)
+
+			if err := app.airplaneService.AddAirplane(id, model, capacity); err != nil {
+				fmt.Printf("Error adding airplane: %v\n", err)
+				continue
+			}
+			fmt.Printf("Airplane %s added successfully\n", id)
+		case 2:
+			app.displayAirplanes()
+		case 3:
+			app.scheduleMaintenanceMenu()
+		case 4:
+			flightNumber := app.validation.GetString("Enter flight number (Fxxxx and no space): ",
+				"Flight number should match the format Fxxxx", false)
+			id := app.validation.GetString("Enter airplane registration: ", "Registration cannot be empty", false)
+
+			if err := app.flightService.AssignAirplane(flightNumber, id); err != nil {
+				fmt.Printf("Error assigning airplane: %v\n", err)
+				continue
+			}
+			fmt.Printf("Airplane %s assigned to flight %s\n", id, flightNumber)
+		case 5:
+			app.displayMaintenanceReport()
+		case 6:
+			return
+		}
+	}
+}
+
+// displayAirplanes lists all airplanes with their maintenance events
+func (app *App) displayAirplanes() {
+	airplanes, err := app.airplaneService.GetAirplanes()
+	if err != nil {
+		fmt.Printf("Error retrieving airplanes: %v\n", err)
+		return
+	}
+
+	if len(airplanes) == 0 {
+		fmt.Println("No airplanes found.")
+		return
+	}
+
+	fmt.Println("+--------------+--------------------+----------+----------------------------------------------------------+")
+	fmt.Println("| Registration |       Model        | Capacity |                       Maintenance                        |")
+	fmt.Println("+--------------+--------------------+----------+----------------------------------------------------------+")
+	for _, airplane := range airplanes {
+		fmt.Printf("| %-12s | %-18s | %8d | %-56s |\n", airplane.ID, airplane.Model, airplane.Capacity, "")
+		for _, event := range airplane.Maintenance {
+			fmt.Printf("| %-12s | %-18s | %8s | %-7s %-16s - %-16s %-12s |\n", "", "", "", event.Type,
+				event.Start.Format("02/01/2006-15:04"), event.End.Format("02/01/2006-15:04"), event.Location)
+		}
+		fmt.Println("+--------------+--------------------+----------+----------------------------------------------------------+")
+	}
+}
+
+// scheduleMaintenanceMenu books a maintenance event and warns about the flights it affects
+func (app *App) scheduleMaintenanceMenu() {
+	id := app.validation.GetString("Enter airplane registration: ", "Registration cannot be empty", false)
+
+	choice := app.validation.GetInteger("Maintenance type (1. A-check - 2. C-check - 3. AOG): ",
+		"Must be an integer between 1 and 3", 1, 3)
+	types := []string{domain.MaintenanceACheck, domain.MaintenanceCCheck, domain.MaintenanceAOG}
+
+	start := app.validation.GetDate("Enter maintenance start (format dd/MM/yyyy-HH:mm): ",
+		"Please follow our format and input realistic times, try again", "02/01/2006-15:04", false)
+	end := app.validation.GetDate("Enter maintenance end (format dd/MM/yyyy-HH:mm): ",
+		"Please follow our format and input realistic times, try again", "02/01/2006-15:04", false)
+	location := app.validation.GetString("Enter maintenance location: ", "Location cannot be empty", false)
+
+	event, affected, err := app.airplaneService.ScheduleMaintenance(id, types[choice-1], start, end, location)
+	if err != nil {
+		fmt.Printf("Error scheduling maintenance: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Maintenance %s scheduled for airplane %s\n", event.ID, id)
+	for _, flight := range affected {
+		fmt.Printf("Warning: flight %s departing %s needs another airplane\n", flight.FlightNumber,
+			flight.DepartureTime.Format("02/01/2006-15:04"))
+	}
+}
+
+// displayMaintenanceReport shows the scheduled checks of every airplane, most urgent first
+func (app *App) displayMaintenanceReport() {
+	report, err := app.airplaneService.MaintenanceReport()
+	if err != nil {
+		fmt.Printf("Error building maintenance report: %v\n", err)
+		return
+	}
+
+	if len(report) == 0 {
+		fmt.Println("No airplanes found.")
+		return
+	}
+
+	fmt.Println("+--------------+---------+------------+-----------+-----------------+------------------+------------------+")
+	fmt.Println("| Registration |  Check  |  Last done | Hours/Cyc |    Remaining    |    Scheduled     |      Status      |")
+	fmt.Println("+--------------+---------+------------+-----------+-----------------+------------------+------------------+")
+	for _, status := range report {
+		lastDone := "Never"
+		if !status.LastDone.IsZero() {
+			lastDone = status.LastDone.Format("02/01/2006")
+		}
+		scheduled := "-"
+		if status.Scheduled != nil {
+			scheduled = status.Scheduled.Start.Format("02/01/2006-15:04")
+		}
+		state := "OK"
+		if status.DueSoon {
+			state = "DUE SOON"
+		}
+		if status.Overdue() {
+			state = "OVERDUE"
+		}
+		fmt.Printf("| %-12s | %-7s | %-10s | %5.0f/%-4d| %7.0fh/%-6d | %-16s | %-16s |\n", status.AirplaneID,
+			status.CheckType, lastDone, status.HoursSince, status.CyclesSince, status.HoursRemaining,
+			status.CyclesRemaining, scheduled, state)
+	}
+	fmt.Println("+--------------+---------+------------+-----------+-----------------+------------------+------------------+")
+}
+
 // displayAllFlightsMenu displays all flights sorted by departure time
 func (app *App) displayAllFlightsMenu() {
 	fmt.Println("\n--- All Flights ---")
@@ -473,6 +2010,9 @@ func (app *App) displayFlightReservationsMenu() {
 		if seatLocation == "" {
 			seatLocation = "   X"
 		}
+		if !reservation.IsConfirmed() {
+			seatLocation = strings.ToUpper(reservation.Status)
+		}
 		
 		fmt.Printf("| %-12s | %-18s | %-18d | %-18d | %-18s |%-36s|\n",
 			reservation.ReservationID, reservation.Name, reservation.PhoneNumber,
@@ -481,42 +2021,15 @@ func (app *App) displayFlightReservationsMenu() {
 	}
 }
 
-// displaySeatsMap displays the seating layout for a flight
-func (app *App) displaySeatsMap(flight *domain.Flight) {
-	maxSeatsPerRow := 4 // Maximum seats per row
-	seatIndex := 0
-	row := 1
-	
-	fmt.Println("=====================================================================")
-	
-	for seat, available := range flight.SeatList {
-		if seatIndex == 0 {
-			fmt.Printf("Row %-4d|", row)
-		}
-		
-		if available {
-			fmt.Printf(" %-6s |", seat)
-		} else {
-			fmt.Printf(" %s(x) |", seat)
-		}
-		
-		seatIndex++
-		
-		if seatIndex == 2 {
-			fmt.Print("                       |")
-		}
-		
-		if seatIndex >= maxSeatsPerRow {
-			fmt.Println()
-			seatIndex = 0
-			row++
-		}
+// displaySeatsMap displays the seating layout of a flight in row order, highlighting the selected seat
+func (app *App) displaySeatsMap(flightNumber, selected string) {
+	seatMap, err := app.seatService.SeatMap(flightNumber, app.session, selected)
+	if err != nil {
+		fmt.Printf("Error building seat map: %v\n", err)
+		return
 	}
 	
-	// Ensure we end with a newline
-	if seatIndex != 0 {
-		fmt.Println()
+	if err := seatmap.WriteTerminal(os.Stdout, seatMap, true); err != nil {
+		fmt.Printf("Error displaying seat map: %v\n", err)
 	}
-	
-	fmt.Println("=====================================================================")
 }
\ No newline at end of file
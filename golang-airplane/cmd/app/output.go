@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/csv"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang-airplane/internal/core/domain"
+)
+
+// Output formats of the subcommands
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// outputTimeLayout is the layout of times in text output, the same as the menu's
+const outputTimeLayout = "02/01/2006-15:04"
+
+// checkFormat checks an output format
+func checkFormat(format string) error {
+	switch format {
+	case formatText, formatJSON, formatCSV:
+		return nil
+	default:
+		return usagef("unknown format %q, expected text, json or csv", format)
+	}
+}
+
+// table is a list of records as columns and rows of text
+type table struct {
+	columns []string
+	rows    [][]string
+}
+
+// write writes a table as aligned text or as CSV, or the records themselves as JSON
+func (t table) write(out io.Writer, format string, records interface{}) error {
+	switch format {
+	case formatJSON:
+		encoder := stdjson.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case formatCSV:
+		writer := csv.NewWriter(out)
+		writer.Write(t.columns)
+		writer.WriteAll(t.rows)
+		return writer.Error()
+	default:
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(t.columns, "\t")))
+		for _, row := range t.rows {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		return writer.Flush()
+	}
+}
+
+// writeFlights writes a list of flights
+func writeFlights(out io.Writer, format string, flights []*domain.Flight) error {
+	if flights == nil {
+		flights = []*domain.Flight{}
+	}
+
+	t := table{columns: []string{"flight_number", "departure_city", "destination_city", "departure_time", "arrival_time",
+		"capacity", "available_seats", "status", "gate"}}
+	layout := outputTimeLayout
+	if format == formatCSV {
+		layout = time.RFC3339
+	}
+	for _, f := range flights {
+		t.rows = append(t.rows, []string{f.FlightNumber, f.DepartureCity, f.DestinationCity,
+			f.DepartureTime.Format(layout), f.ArrivalTime.Format(layout),
+			strconv.Itoa(f.FlightCapacity), strconv.Itoa(f.AvailableSeat), f.CurrentStatus(), f.Gate})
+	}
+	return t.write(out, format, flights)
+}
+
+// writeReservations writes a list of reservations
+func writeReservations(out io.Writer, format string, reservations []*domain.Reservation) error {
+	if reservations == nil {
+		reservations = []*domain.Reservation{}
+	}
+
+	t := table{columns: []string{"reservation_id", "flight_number", "name", "class", "status", "seat", "checked_in",
+		"phone_number", "identity_card_number", "address"}}
+	for _, r := range reservations {
+		t.rows = append(t.rows, []string{r.ReservationID, r.ReservationFlightNumber, r.Name, r.Class, r.Status,
+			r.SeatLocation, strconv.FormatBool(r.CheckedIn), strconv.FormatInt(r.PhoneNumber, 10),
+			strconv.FormatInt(r.IdentityCardNumber, 10), r.Address})
+	}
+	return t.write(out, format, reservations)
+}
+
+// writeCrew writes the crew of a flight
+func writeCrew(out io.Writer, format string, f *domain.Flight) error {
+	t := table{columns: []string{"flight_number", "id", "name", "position"}}
+	for _, member := range f.CrewMembers {
+		t.rows = append(t.rows, []string{f.FlightNumber, member.ID, member.Name, member.Position})
+	}
+	return t.write(out, format, f)
+}
+
+// userRecord is a user account as printed, without the password hash
+type userRecord struct {
+	Username  string    `json:"username"`
+	Roles     []string  `json:"roles"`
+	Disabled  bool      `json:"disabled"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// writeUsers writes user accounts
+func writeUsers(out io.Writer, format string, users []*domain.User) error {
+	records := []userRecord{}
+	t := table{columns: []string{"username", "roles", "disabled", "created_at"}}
+	for _, u := range users {
+		records = append(records, userRecord{Username: u.Username, Roles: u.Roles, Disabled: u.Disabled, CreatedAt: u.CreatedAt})
+		t.rows = append(t.rows, []string{u.Username, strings.Join(u.Roles, ","), strconv.FormatBool(u.Disabled),
+			u.CreatedAt.Format(time.RFC3339)})
+	}
+	return t.write(out, format, records)
+}
+
+// writeActivity writes the changes made by users
+func writeActivity(out io.Writer, format string, activities []*domain.Activity) error {
+	if activities == nil {
+		activities = []*domain.Activity{}
+	}
+
+	t := table{columns: []string{"at", "actor", "action", "target"}}
+	for _, a := range activities {
+		t.rows = append(t.rows, []string{a.At.Format(time.RFC3339), a.Actor, a.Action, a.Target})
+	}
+	return t.write(out, format, activities)
+}
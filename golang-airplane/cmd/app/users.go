@@ -0,0 +1,408 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang-airplane/internal/core/domain"
+)
+
+// Environment variables holding the credentials the subcommands log in with
+const (
+	usernameEnv = "AIRLINE_USERNAME"
+	passwordEnv = "AIRLINE_PASSWORD"
+)
+
+// maxLoginAttempts is how many times the menu asks for the password before exiting
+const maxLoginAttempts = 3
+
+// signIn makes the services act for a user, who may then only make the changes their roles allow and is recorded
+// as the author of every change of the session
+func (app *App) signIn(user *domain.User) {
+	app.user = user
+	app.auditLog.SetDefaultActor(user.Username)
+	app.flightService = app.authService.GuardFlights(app.flightService, user)
+	app.reservationService = app.authService.GuardReservations(app.reservationService, user)
+	app.airplaneService = app.authService.GuardAirplanes(app.airplaneService, user)
+}
+
+// logInFromEnv logs the subcommands in with the credentials in the environment
+func (app *App) logInFromEnv() error {
+	username := os.Getenv(usernameEnv)
+	if username == "" {
+		return fmt.Errorf("set %s and %s to log in: %w", usernameEnv, passwordEnv, domain.ErrUnauthorized)
+	}
+
+	user, err := app.authService.Authenticate(username, os.Getenv(passwordEnv))
+	if err != nil {
+		return err
+	}
+	app.signIn(user)
+	return nil
+}
+
+// logInMenu logs the interactive menu in, creating the administrator account on the first run; it reports
+// whether a user is logged in
+func (app *App) logInMenu() bool {
+	hasUsers, err := app.authService.HasUsers()
+	if err != nil {
+		fmt.Printf("Error loading users: %v\n", err)
+		return false
+	}
+
+	if !hasUsers {
+		fmt.Println("\nNo user accounts exist yet. Create the administrator account.")
+		for {
+			username := app.validation.GetString("Enter username: ", "Username cannot be empty", false)
+			password := app.inputNewPassword()
+			user, err := app.authService.CreateUser(nil, username, password, []string{domain.RoleAdmin})
+			if err != nil {
+				fmt.Printf("Error creating user: %v\n", err)
+				continue
+			}
+			fmt.Printf("Administrator %s created.\n", user.Username)
+			app.signIn(user)
+			return true
+		}
+	}
+
+	if os.Getenv(usernameEnv) != "" {
+		if err := app.logInFromEnv(); err != nil {
+			fmt.Printf("Error logging in as %s: %v\n", os.Getenv(usernameEnv), err)
+			return false
+		}
+		fmt.Printf("Logged in as %s.\n", app.user.Username)
+		return true
+	}
+
+	fmt.Println("\n--- Log In ---")
+	for attempt := 1; attempt <= maxLoginAttempts; attempt++ {
+		username := app.validation.GetString("Username: ", "Username cannot be empty", false)
+		password := app.validation.GetPassword("Password: ")
+		user, err := app.authService.Authenticate(username, password)
+		if err == nil {
+			fmt.Printf("Welcome, %s (%s).\n", user.Username, strings.Join(user.Roles, ", "))
+			app.signIn(user)
+			return true
+		}
+		if !errors.Is(err, domain.ErrUnauthorized) {
+			fmt.Printf("Error logging in: %v\n", err)
+			return false
+		}
+		fmt.Println("Invalid username or password.")
+	}
+	fmt.Println("Too many failed attempts. Goodbye!")
+	return false
+}
+
+// inputNewPassword asks for a new password twice until both match
+func (app *App) inputNewPassword() string {
+	for {
+		password := app.validation.GetPassword("Enter password: ")
+		if app.validation.GetPassword("Repeat password: ") == password {
+			return password
+		}
+		fmt.Println("The passwords do not match.")
+	}
+}
+
+// inputRoles asks for a comma-separated list of roles until all are known
+func (app *App) inputRoles() []string {
+	for {
+		input := app.validation.GetString(fmt.Sprintf("Enter roles, separated by commas (%s): ", strings.Join(domain.Roles, ", ")),
+			"Roles cannot be empty", false)
+		roles := splitList(input)
+		unknown := ""
+		for _, role := range roles {
+			if !domain.IsRole(role) {
+				unknown = role
+				break
+			}
+		}
+		if unknown == "" && len(roles) > 0 {
+			return roles
+		}
+		fmt.Printf("Unknown role %q.\n", unknown)
+	}
+}
+
+// splitList splits a comma-separated list, such as of roles
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// usersMenu handles passwords, user accounts, the activity log and the audit log
+func (app *App) usersMenu() {
+	for {
+		fmt.Printf("\n--- Users, Activity and Audit Log (logged in as %s) ---\n", app.user.Username)
+		choice := app.validation.GetInteger("1. Change my password - 2. List users - 3. Create user - 4. Set roles - 5. Disable user - 6. Enable user - 7. Show activity - 8. Audit log - 9. Back: ",
+			"Must be an integer between 1 and 9", 1, 9)
+
+		var err error
+		switch choice {
+		case 1:
+			err = app.authService.ChangePassword(app.user, app.user.Username, app.inputNewPassword())
+			if err == nil {
+				fmt.Println("Password changed.")
+			}
+		case 2:
+			err = app.displayUsers()
+		case 3:
+			username := app.validation.GetString("Enter username: ", "Username cannot be empty", false)
+			password := app.inputNewPassword()
+			var user *domain.User
+			user, err = app.authService.CreateUser(app.user, username, password, app.inputRoles())
+			if err == nil {
+				fmt.Printf("User %s created.\n", user.Username)
+			}
+		case 4:
+			username := app.validation.GetString("Enter username: ", "Username cannot be empty", false)
+			err = app.authService.SetRoles(app.user, username, app.inputRoles())
+			if err == nil {
+				fmt.Printf("Roles of %s updated.\n", username)
+			}
+		case 5, 6:
+			username := app.validation.GetString("Enter username: ", "Username cannot be empty", false)
+			err = app.authService.SetDisabled(app.user, username, choice == 5)
+			if err == nil {
+				fmt.Printf("User %s updated.\n", username)
+			}
+		case 7:
+			err = app.displayActivity()
+		case 8:
+			app.auditMenu()
+		case 9:
+			return
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	}
+}
+
+// displayUsers lists the user accounts
+func (app *App) displayUsers() error {
+	users, err := app.authService.ListUsers(app.user)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("+----------------------+--------------------------------------------------+----------+")
+	fmt.Println("|       Username       |                      Roles                       |  Status  |")
+	fmt.Println("+----------------------+--------------------------------------------------+----------+")
+	for _, user := range users {
+		status := "active"
+		if user.Disabled {
+			status = "disabled"
+		}
+		fmt.Printf("| %-20s | %-48s | %-8s |\n", user.Username, strings.Join(user.Roles, ", "), status)
+	}
+	fmt.Println("+----------------------+--------------------------------------------------+----------+")
+	return nil
+}
+
+// displayActivity lists the most recent changes made by users
+func (app *App) displayActivity() error {
+	activities, err := app.authService.ListActivity(app.user)
+	if err != nil {
+		return err
+	}
+
+	if len(activities) == 0 {
+		fmt.Println("No activity recorded.")
+		return nil
+	}
+	const shown = 50
+	if len(activities) > shown {
+		fmt.Printf("Showing the last %d of %d changes.\n", shown, len(activities))
+		activities = activities[len(activities)-shown:]
+	}
+	for _, activity := range activities {
+		fmt.Printf("%s  %-16s %-22s %s\n", activity.At.Format("02/01/2006-15:04:05"), activity.Actor, activity.Action, activity.Target)
+	}
+	return nil
+}
+
+// userInput holds the values of a new user account
+type userInput struct {
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Roles    []string `json:"roles"`
+}
+
+// listValue is a flag taking comma-separated values, such as roles, which may be repeated
+type listValue struct {
+	target *[]string
+}
+
+// String returns the values separated by commas
+func (v listValue) String() string {
+	if v.target == nil {
+		return ""
+	}
+	return strings.Join(*v.target, ",")
+}
+
+// Set adds values
+func (v listValue) Set(value string) error {
+	*v.target = append(*v.target, splitList(value)...)
+	return nil
+}
+
+// userAddCommand creates a user account; with no accounts yet it creates the first administrator without logging in
+func (app *App) userAddCommand(args []string, in io.Reader, out io.Writer) error {
+	var input userInput
+	var format string
+	fs := newFlagSet("user add", &format)
+	fs.StringVar(&input.Username, "username", "", "username")
+	fs.StringVar(&input.Password, "password", "", "password; prefer -input so it stays out of the process list")
+	fs.Var(listValue{&input.Roles}, "role", "roles, separated by commas or repeated: "+strings.Join(domain.Roles, ", "))
+	if err := parseFlags(fs, args, in, &input); err != nil {
+		return err
+	}
+	if input.Username == "" || input.Password == "" || len(input.Roles) == 0 {
+		return usagef("the username, password and at least one role are required")
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	user, err := app.authService.CreateUser(app.user, input.Username, input.Password, input.Roles)
+	if err != nil {
+		return err
+	}
+	return writeUsers(out, format, []*domain.User{user})
+}
+
+// userListCommand lists the user accounts
+func (app *App) userListCommand(args []string, in io.Reader, out io.Writer) error {
+	var format string
+	fs := newFlagSet("user list", &format)
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	users, err := app.authService.ListUsers(app.user)
+	if err != nil {
+		return err
+	}
+	return writeUsers(out, format, users)
+}
+
+// userRolesCommand replaces the roles of a user
+func (app *App) userRolesCommand(args []string, in io.Reader, out io.Writer) error {
+	var username string
+	var roles []string
+	fs := newFlagSet("user roles", nil)
+	fs.StringVar(&username, "username", "", "username")
+	fs.Var(listValue{&roles}, "role", "roles, separated by commas or repeated: "+strings.Join(domain.Roles, ", "))
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if username == "" || len(roles) == 0 {
+		return usagef("the username and at least one role are required")
+	}
+
+	if err := app.authService.SetRoles(app.user, username, roles); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Roles of %s set to %s\n", username, strings.Join(roles, ", "))
+	return nil
+}
+
+// userPasswordCommand changes the password of the logged-in user, or of another user for administrators
+func (app *App) userPasswordCommand(args []string, in io.Reader, out io.Writer) error {
+	var input userInput
+	fs := newFlagSet("user passwd", nil)
+	fs.StringVar(&input.Username, "username", "", "username; the logged-in user when left out")
+	fs.StringVar(&input.Password, "password", "", "new password; prefer -input so it stays out of the process list")
+	if err := parseFlags(fs, args, in, &input); err != nil {
+		return err
+	}
+	if input.Password == "" {
+		return usagef("the new password is required")
+	}
+	if input.Username == "" {
+		input.Username = app.user.Username
+	}
+
+	if err := app.authService.ChangePassword(app.user, input.Username, input.Password); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Password of %s changed\n", input.Username)
+	return nil
+}
+
+// userDisableCommand disables a user account
+func (app *App) userDisableCommand(args []string, in io.Reader, out io.Writer) error {
+	return app.setDisabledCommand("user disable", true, args, in, out)
+}
+
+// userEnableCommand re-enables a disabled user account
+func (app *App) userEnableCommand(args []string, in io.Reader, out io.Writer) error {
+	return app.setDisabledCommand("user enable", false, args, in, out)
+}
+
+// setDisabledCommand disables or re-enables a user account
+func (app *App) setDisabledCommand(name string, disabled bool, args []string, in io.Reader, out io.Writer) error {
+	var username string
+	fs := newFlagSet(name, nil)
+	fs.StringVar(&username, "username", "", "username")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if username == "" {
+		return usagef("the username is required")
+	}
+
+	if err := app.authService.SetDisabled(app.user, username, disabled); err != nil {
+		return err
+	}
+	state := "enabled"
+	if disabled {
+		state = "disabled"
+	}
+	fmt.Fprintf(out, "User %s %s\n", username, state)
+	return nil
+}
+
+// activityListCommand lists the changes made by users, optionally of one user or since a time
+func (app *App) activityListCommand(args []string, in io.Reader, out io.Writer) error {
+	var actor, format string
+	var since time.Time
+	fs := newFlagSet("activity list", &format)
+	fs.StringVar(&actor, "user", "", "only the changes of this user")
+	fs.Var(timeValue{&since, timeLayouts}, "since", "only the changes from this time, dd/mm/yyyy-HH:mm")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	activities, err := app.authService.ListActivity(app.user)
+	if err != nil {
+		return err
+	}
+
+	var matching []*domain.Activity
+	for _, activity := range activities {
+		if (actor == "" || activity.Actor == actor) && !activity.At.Before(since) {
+			matching = append(matching, activity)
+		}
+	}
+	return writeActivity(out, format, matching)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/core/domain"
+)
+
+// webhookInput is the JSON input of the webhook add command
+type webhookInput struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+// webhookAddCommand subscribes a partner URL to event types and prints the subscription with its secret
+func (app *App) webhookAddCommand(args []string, in io.Reader, out io.Writer) error {
+	var input webhookInput
+	var format string
+	fs := newFlagSet("webhook add", &format)
+	fs.StringVar(&input.URL, "url", "", "URL the events are posted to")
+	fs.Var(listValue{&input.EventTypes}, "event", "event types, separated by commas or repeated: "+strings.Join(domain.EventTypes, ", "))
+	fs.StringVar(&input.Secret, "secret", "", "key the payloads are signed with; one is generated when empty")
+	if err := parseFlags(fs, args, in, &input); err != nil {
+		return err
+	}
+	if input.URL == "" || len(input.EventTypes) == 0 {
+		return usagef("the URL and at least one event type are required")
+	}
+	for _, eventType := range input.EventTypes {
+		if _, err := domain.NewEvent(eventType); err != nil {
+			return usagef("the event types must be among %s", strings.Join(domain.EventTypes, ", "))
+		}
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "manage webhooks"); err != nil {
+		return err
+	}
+
+	subscription, err := app.webhookService.Subscribe(input.URL, input.EventTypes, input.Secret, app.user.Username)
+	if err != nil {
+		return err
+	}
+	return writeWebhooks(out, format, []*domain.WebhookSubscription{subscription}, true)
+}
+
+// webhookListCommand lists the webhook subscriptions, without their secrets
+func (app *App) webhookListCommand(args []string, in io.Reader, out io.Writer) error {
+	var format string
+	fs := newFlagSet("webhook list", &format)
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "manage webhooks"); err != nil {
+		return err
+	}
+
+	subscriptions, err := app.webhookService.Subscriptions()
+	if err != nil {
+		return err
+	}
+	return writeWebhooks(out, format, subscriptions, false)
+}
+
+// webhookRemoveCommand removes a webhook subscription
+func (app *App) webhookRemoveCommand(args []string, in io.Reader, out io.Writer) error {
+	var id string
+	fs := newFlagSet("webhook remove", nil)
+	fs.StringVar(&id, "id", "", "webhook ID")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if id == "" {
+		return usagef("-id is required")
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "manage webhooks"); err != nil {
+		return err
+	}
+
+	if err := app.webhookService.Unsubscribe(id); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Webhook %s removed\n", id)
+	return nil
+}
+
+// webhookLogCommand lists the delivery attempts of the webhooks
+func (app *App) webhookLogCommand(args []string, in io.Reader, out io.Writer) error {
+	var id, event, format string
+	fs := newFlagSet("webhook log", &format)
+	fs.StringVar(&id, "id", "", "only the attempts of this webhook")
+	fs.StringVar(&event, "event", "", "only the attempts of this event ID")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "read the webhook log"); err != nil {
+		return err
+	}
+
+	attempts, err := app.webhookService.Attempts(id, event)
+	if err != nil {
+		return err
+	}
+
+	t := table{columns: []string{"at", "webhook", "event_id", "event_type", "attempt", "status_code", "duration", "error"}}
+	for _, a := range attempts {
+		status := ""
+		if a.StatusCode != 0 {
+			status = strconv.Itoa(a.StatusCode)
+		}
+		t.rows = append(t.rows, []string{a.At.Format(time.RFC3339), a.SubscriptionID, a.EventID, a.EventType,
+			strconv.Itoa(a.Attempt), status, a.Duration.Round(time.Millisecond).String(), a.Error})
+	}
+	return t.write(out, format, attempts)
+}
+
+// webhookQueueCommand lists the deliveries waiting for a retry, or those in the dead-letter queue
+func (app *App) webhookQueueCommand(args []string, in io.Reader, out io.Writer) error {
+	var dead bool
+	var format string
+	fs := newFlagSet("webhook queue", &format)
+	fs.BoolVar(&dead, "dead", false, "list the dead-letter queue instead of the deliveries waiting for a retry")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "read the webhook queue"); err != nil {
+		return err
+	}
+
+	var deliveries []*domain.WebhookDelivery
+	var err error
+	if dead {
+		deliveries, err = app.webhookService.DeadLetters()
+	} else {
+		deliveries, err = app.webhookService.Pending()
+	}
+	if err != nil {
+		return err
+	}
+
+	t := table{columns: []string{"id", "event_type", "attempts", "next_attempt_at", "last_error"}}
+	for _, d := range deliveries {
+		next := ""
+		if d.Status == domain.WebhookPending {
+			next = d.NextAttemptAt.Format(time.RFC3339)
+		}
+		t.rows = append(t.rows, []string{d.ID, d.EventType, strconv.Itoa(d.Attempts), next, d.LastError})
+	}
+	return t.write(out, format, deliveries)
+}
+
+// webhookRedriveCommand sends a delivery of the dead-letter queue again
+func (app *App) webhookRedriveCommand(args []string, in io.Reader, out io.Writer) error {
+	var ids []string
+	fs := newFlagSet("webhook redrive", nil)
+	fs.Var(listValue{&ids}, "delivery", "delivery IDs, separated by commas or repeated")
+	if err := parseFlags(fs, args, in, nil); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return usagef("at least one -delivery is required")
+	}
+	if err := auth.Require(app.user, domain.RoleAdmin, "manage webhooks"); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := app.webhookService.Redrive(id); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Delivery %s queued again\n", id)
+	}
+	return nil
+}
+
+// webhookRecord is a webhook subscription as printed by the commands, with its secret only when it was just created
+type webhookRecord struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// writeWebhooks writes webhook subscriptions, with their secrets when asked
+func writeWebhooks(out io.Writer, format string, subscriptions []*domain.WebhookSubscription, withSecret bool) error {
+	records := []webhookRecord{}
+	columns := []string{"id", "url", "event_types", "created_by", "created_at"}
+	if withSecret {
+		columns = append(columns, "secret")
+	}
+	t := table{columns: columns}
+	for _, s := range subscriptions {
+		record := webhookRecord{ID: s.ID, URL: s.URL, EventTypes: s.EventTypes, CreatedBy: s.CreatedBy, CreatedAt: s.CreatedAt}
+		row := []string{s.ID, s.URL, strings.Join(s.EventTypes, ","), s.CreatedBy, s.CreatedAt.Format(time.RFC3339)}
+		if withSecret {
+			record.Secret = s.Secret
+			row = append(row, s.Secret)
+		}
+		records = append(records, record)
+		t.rows = append(t.rows, row)
+	}
+	return t.write(out, format, records)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"golang-airplane/internal/api/rest"
+	"golang-airplane/internal/components/airplane"
+	"golang-airplane/internal/components/audit"
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/components/boardingpass"
+	"golang-airplane/internal/components/documents"
+	"golang-airplane/internal/components/events"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/components/webhook"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/storage/json"
+	"golang-airplane/internal/web"
+)
+
+// shutdownTimeout is how long requests in flight may take to finish once the console stops
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	dataDir := flag.String("data", filepath.Join(".", "data"), "directory of the JSON data files")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+
+	// Setup storage
+	storage := json.NewStorage(*dataDir)
+	auditLog := audit.NewLog(json.NewAuditRepository(storage), audit.SystemActor)
+	flightStore := json.NewFlightRepository(storage)
+	reservationStore := json.NewReservationRepository(storage)
+	airplaneStore := json.NewAirplaneRepository(storage)
+	flightRepo := audit.Flights(flightStore, auditLog)
+	reservationRepo := audit.Reservations(reservationStore, auditLog)
+	crewChangeRepo := json.NewCrewChangeRepository(storage)
+	waitlistRepo := json.NewWaitlistRepository(storage)
+	notificationLog := json.NewNotificationLog(storage)
+	overbookingPolicyRepo := json.NewOverbookingPolicyRepository(storage)
+	seatHoldRepo := json.NewSeatHoldRepository(storage)
+	checkInPolicyRepo := json.NewCheckInPolicyRepository(storage)
+	brandRepo := json.NewBrandRepository(storage)
+	userRepo := json.NewUserRepository(storage)
+	activityRepo := json.NewActivityRepository(storage)
+
+	// Setup services
+	eventBus := events.NewBus(json.NewOutboxRepository(storage), storage, events.DefaultRetention)
+	webhookService := webhook.NewService(json.NewWebhookSubscriptionRepository(storage), json.NewWebhookDeliveryRepository(storage),
+		json.NewWebhookAttemptRepository(storage), nil, webhook.DefaultRetryPolicy)
+	holdService := flight.NewHoldService(flightRepo, seatHoldRepo, flight.DefaultHoldTTL)
+	checkInRules := flight.NewCheckInRules(checkInPolicyRepo)
+	seatService := flight.NewSeatService(flightRepo, reservationRepo, holdService)
+	waitlistService := flight.NewWaitlistService(flightRepo, reservationRepo, waitlistRepo, notificationLog, flight.DefaultWaitlistHold)
+	boardingPasses := boardingpass.NewService(flightRepo, reservationRepo, boardingpass.DefaultCarrier)
+	documentRenderer := documents.NewRenderer(flightRepo, reservationRepo, brandRepo, boardingPasses)
+	authService := auth.NewService(userRepo, activityRepo)
+
+	// Users act through services of their own, so the audit log names them as the ones making the changes; the
+	// changes made in the background or by the subscribers of events are recorded as the system's
+	servicesAs := func(actor string) ports.Services {
+		recorder := auditLog.As(actor)
+		flightRepo := audit.Flights(flightStore, recorder)
+		reservationRepo := audit.Reservations(reservationStore, recorder)
+		airplaneRepo := audit.Airplanes(airplaneStore, recorder)
+		overbooking := flight.NewOverbookingService(flightRepo, reservationRepo, overbookingPolicyRepo)
+		return ports.Services{
+			Flights:      flight.NewService(flightRepo, reservationRepo, crewChangeRepo, airplaneRepo, holdService, eventBus),
+			Reservations: flight.NewReservationService(flightRepo, reservationRepo, overbooking, holdService, checkInRules, eventBus),
+			Airplanes:    airplane.NewAirplaneService(airplaneRepo, flightRepo),
+		}
+	}
+
+	// Users log in with the accounts managed by the app, so one must exist
+	hasUsers, err := authService.HasUsers()
+	if err != nil {
+		logger.Fatalf("failed to load users: %v", err)
+	}
+	if !hasUsers {
+		logger.Fatalf("no user accounts exist; create the administrator with 'app user add'")
+	}
+
+	// Released seats go to the waitlist first
+	eventBus.Subscribe("waitlist", waitlistService.HandleSeatReleased, domain.EventSeatReleased)
+
+	// Partners are told of the events they subscribed to
+	eventBus.SubscribeAsync(webhook.SubscriberName, webhookService.HandleEvent)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Release expired seat holds in the background
+	holdService.StartReaper(ctx, time.Minute, func(err error) {
+		logger.Printf("error releasing expired seat holds: %v", err)
+	})
+
+	// Release the seats of expired waitlist offers in the background
+	waitlistService.StartReaper(ctx, time.Minute, func(err error) {
+		logger.Printf("error releasing expired waitlist offers: %v", err)
+	})
+
+	// Deliver events to the asynchronous subscribers in the background
+	eventBus.StartDelivery(ctx, events.DefaultRetryInterval, func(err error) {
+		logger.Printf("error delivering events: %v", err)
+	})
+	webhookService.StartDispatcher(ctx, webhook.DefaultCheckInterval, func(err error) {
+		logger.Printf("error sending webhooks: %v", err)
+	})
+
+	console, err := web.NewConsole(servicesAs, seatService, documentRenderer, authService, logger)
+	if err != nil {
+		logger.Fatalf("failed to create the console: %v", err)
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           rest.Recover(rest.LogRequests(console, logger), logger),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		logger.Printf("serving the console on %s", *addr)
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatalf("console failed: %v", err)
+		}
+	case <-ctx.Done():
+		logger.Printf("shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Printf("graceful shutdown failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"golang-airplane/internal/api/rest"
+	"golang-airplane/internal/api/rpc"
+	"golang-airplane/internal/components/airplane"
+	"golang-airplane/internal/components/audit"
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/components/events"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/components/webhook"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/storage/json"
+
+	"google.golang.org/grpc"
+)
+
+// shutdownTimeout is how long requests in flight may take to finish once the server stops
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	grpcAddr := flag.String("grpc-addr", ":9090", "address to serve the gRPC services on, or empty for none")
+	dataDir := flag.String("data", filepath.Join(".", "data"), "directory of the JSON data files")
+	printSpec := flag.Bool("openapi", false, "print the OpenAPI document of the API and exit")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+
+	// Setup storage
+	storage := json.NewStorage(*dataDir)
+	auditLog := audit.NewLog(json.NewAuditRepository(storage), audit.SystemActor)
+	flightStore := json.NewFlightRepository(storage)
+	reservationStore := json.NewReservationRepository(storage)
+	airplaneStore := json.NewAirplaneRepository(storage)
+	flightRepo := audit.Flights(flightStore, auditLog)
+	reservationRepo := audit.Reservations(reservationStore, auditLog)
+	crewChangeRepo := json.NewCrewChangeRepository(storage)
+	waitlistRepo := json.NewWaitlistRepository(storage)
+	notificationLog := json.NewNotificationLog(storage)
+	overbookingPolicyRepo := json.NewOverbookingPolicyRepository(storage)
+	seatHoldRepo := json.NewSeatHoldRepository(storage)
+	checkInPolicyRepo := json.NewCheckInPolicyRepository(storage)
+
+	// Setup services
+	eventBus := events.NewBus(json.NewOutboxRepository(storage), storage, events.DefaultRetention)
+	webhookService := webhook.NewService(json.NewWebhookSubscriptionRepository(storage), json.NewWebhookDeliveryRepository(storage),
+		json.NewWebhookAttemptRepository(storage), nil, webhook.DefaultRetryPolicy)
+	holdService := flight.NewHoldService(flightRepo, seatHoldRepo, flight.DefaultHoldTTL)
+	checkInRules := flight.NewCheckInRules(checkInPolicyRepo)
+	seatService := flight.NewSeatService(flightRepo, reservationRepo, holdService)
+	waitlistService := flight.NewWaitlistService(flightRepo, reservationRepo, waitlistRepo, notificationLog, flight.DefaultWaitlistHold)
+	authService := auth.NewService(json.NewUserRepository(storage), json.NewActivityRepository(storage))
+
+	// Users act through services of their own, so the audit log names them as the ones making the changes; the
+	// changes made in the background or by the subscribers of events are recorded as the system's
+	servicesAs := func(actor string) ports.Services {
+		recorder := auditLog.As(actor)
+		flightRepo := audit.Flights(flightStore, recorder)
+		reservationRepo := audit.Reservations(reservationStore, recorder)
+		airplaneRepo := audit.Airplanes(airplaneStore, recorder)
+		overbooking := flight.NewOverbookingService(flightRepo, reservationRepo, overbookingPolicyRepo)
+		return ports.Services{
+			Flights:      flight.NewService(flightRepo, reservationRepo, crewChangeRepo, airplaneRepo, holdService, eventBus),
+			Reservations: flight.NewReservationService(flightRepo, reservationRepo, overbooking, holdService, checkInRules, eventBus),
+			Airplanes:    airplane.NewAirplaneService(airplaneRepo, flightRepo),
+		}
+	}
+
+	// Released seats go to the waitlist first
+	eventBus.Subscribe("waitlist", waitlistService.HandleSeatReleased, domain.EventSeatReleased)
+
+	// Partners are told of the events they subscribed to
+	eventBus.SubscribeAsync(webhook.SubscriberName, webhookService.HandleEvent)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Release expired seat holds in the background
+	holdService.StartReaper(ctx, time.Minute, func(err error) {
+		logger.Printf("error releasing expired seat holds: %v", err)
+	})
+
+	// Release the seats of expired waitlist offers in the background
+	waitlistService.StartReaper(ctx, time.Minute, func(err error) {
+		logger.Printf("error releasing expired waitlist offers: %v", err)
+	})
+
+	// Deliver events to the asynchronous subscribers in the background
+	eventBus.StartDelivery(ctx, events.DefaultRetryInterval, func(err error) {
+		logger.Printf("error delivering events: %v", err)
+	})
+	webhookService.StartDispatcher(ctx, webhook.DefaultCheckInterval, func(err error) {
+		logger.Printf("error sending webhooks: %v", err)
+	})
+
+	handler := rest.NewHandler(servicesAs, authService)
+	if *printSpec {
+		encoder := stdjson.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rest.OpenAPI(handler.Routes())); err != nil {
+			logger.Fatalf("failed to write the OpenAPI document: %v", err)
+		}
+		return
+	}
+
+	// Callers authenticate with the accounts managed by the app, so one must exist
+	hasUsers, err := authService.HasUsers()
+	if err != nil {
+		logger.Fatalf("failed to load users: %v", err)
+	}
+	if !hasUsers {
+		logger.Fatalf("no user accounts exist; create the administrator with 'app user add'")
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           rest.Recover(rest.LogRequests(handler, logger), logger),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	errs := make(chan error, 2)
+	go func() {
+		logger.Printf("listening on %s", *addr)
+		errs <- server.ListenAndServe()
+	}()
+
+	var grpcServer *grpc.Server
+	if *grpcAddr != "" {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			logger.Fatalf("failed to listen on %s: %v", *grpcAddr, err)
+		}
+
+		// The gRPC services share the lock of the HTTP API, so both make their changes one at a time
+		services := rpc.NewServer(servicesAs, seatService, authService, handler.StorageLock(), eventBus,
+			rpc.DefaultRefreshInterval, logger)
+		grpcServer = grpc.NewServer(grpc.UnaryInterceptor(services.UnaryInterceptor()),
+			grpc.StreamInterceptor(services.StreamInterceptor()))
+		services.Register(grpcServer)
+
+		go func() {
+			logger.Printf("serving gRPC on %s", *grpcAddr)
+			errs <- grpcServer.Serve(listener)
+		}()
+	}
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, grpc.ErrServerStopped) {
+			logger.Fatalf("server failed: %v", err)
+		}
+	case <-ctx.Done():
+		logger.Printf("shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if grpcServer != nil {
+			stopGRPC(grpcServer, shutdownCtx)
+		}
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Printf("graceful shutdown failed: %v", err)
+		}
+	}
+}
+
+// stopGRPC stops the gRPC server once its calls finish, cancelling them when ctx ends first; watch
+// streams only end with the flight, so they are usually cancelled
+func stopGRPC(server *grpc.Server, ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
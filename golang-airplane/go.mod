@@ -1,3 +1,20 @@
 module golang-airplane
 
-go 1.18
\ No newline at end of file
+go 1.18
+
+require (
+	github.com/boombuler/barcode v1.1.0
+	github.com/jung-kurt/gofpdf v1.16.2
+	golang.org/x/crypto v0.8.0
+	golang.org/x/term v0.7.0
+	golang.org/x/text v0.9.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19
+	google.golang.org/grpc v1.57.2
+	google.golang.org/protobuf v1.31.0
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	golang.org/x/net v0.9.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+)
@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"context"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"net/http"
+)
+
+// authRealm is the realm clients are asked to send the credentials of a user account for
+const authRealm = `Basic realm="airline", charset="UTF-8"`
+
+type userKey struct{}
+
+// authenticate returns the request with the user of its basic auth credentials, if it has any
+func (h *Handler) authenticate(r *http.Request) (*http.Request, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return r, nil
+	}
+
+	user, err := h.access.Authenticate(username, password)
+	if err != nil {
+		return nil, err
+	}
+	return r.WithContext(context.WithValue(r.Context(), userKey{}, user)), nil
+}
+
+// userOf returns the authenticated user of a request, or nil for an anonymous request
+func userOf(r *http.Request) *domain.User {
+	user, _ := r.Context().Value(userKey{}).(*domain.User)
+	return user
+}
+
+// writeUnauthorized answers that the request needs the credentials of a user account
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", authRealm)
+	writeError(w, http.StatusUnauthorized, "unauthorized", message)
+}
+
+// flightsOf returns the flight service as the user of a request may use it
+func (h *Handler) flightsOf(r *http.Request) ports.FlightService {
+	user := userOf(r)
+	return h.access.GuardFlights(h.services(user.Username).Flights, user)
+}
+
+// reservationsOf returns the reservation service as the user of a request may use it
+func (h *Handler) reservationsOf(r *http.Request) ports.ReservationService {
+	user := userOf(r)
+	return h.access.GuardReservations(h.services(user.Username).Reservations, user)
+}
+
+// airplanesOf returns the airplane service as the user of a request may use it
+func (h *Handler) airplanesOf(r *http.Request) ports.AirplaneService {
+	user := userOf(r)
+	return h.access.GuardAirplanes(h.services(user.Username).Airplanes, user)
+}
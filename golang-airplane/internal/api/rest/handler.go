@@ -0,0 +1,549 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/utils"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SessionHeader carries the booking session token that ties seat holds to a client
+const SessionHeader = "X-Session-Token"
+
+// Handler serves the HTTP API
+type Handler struct {
+	services ports.ServiceFactory
+	access   ports.AccessControl
+	router   *router
+	mutex    sync.RWMutex // The JSON storage is not transactional, so changes are made one at a time
+}
+
+// NewHandler creates the HTTP API; callers authenticate as a user of access, which allows and records what they
+// change, and act through the services that services makes for them, so the audit trail names them
+func NewHandler(services ports.ServiceFactory, access ports.AccessControl) *Handler {
+	h := &Handler{
+		services: services,
+		access:   access,
+	}
+	h.router = &router{routes: h.Routes()}
+	return h
+}
+
+// Routes returns the endpoints of the API
+func (h *Handler) Routes() []Route {
+	paging := []QueryParam{
+		{Name: "page", Type: "integer", Description: "Page number, starting at 1"},
+		{Name: "per_page", Type: "integer", Description: "Items per page, at most 100"},
+	}
+
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/health", Summary: "Check that the service is up", Tag: "service",
+			Response: map[string]string{}, Status: http.StatusOK, Public: true, handler: h.health},
+		{Method: http.MethodGet, Pattern: "/openapi.json", Summary: "Get the OpenAPI document of the API", Tag: "service",
+			Response: map[string]interface{}{}, Status: http.StatusOK, Public: true, handler: h.openAPI},
+		{Method: http.MethodGet, Pattern: "/flights", Summary: "List flights, or search them by location and date", Tag: "flights",
+			Query: append([]QueryParam{
+				{Name: "location", Type: "string", Description: "Departure or destination city, given together with date"},
+				{Name: "date", Type: "string", Description: "Departure date as YYYY-MM-DD, given together with location"},
+			}, paging...),
+			Response: domain.Flight{}, List: true, Status: http.StatusOK, handler: h.listFlights},
+		{Method: http.MethodPost, Pattern: "/flights", Summary: "Create a flight", Tag: "flights",
+			Request: CreateFlightRequest{}, Response: domain.Flight{}, Status: http.StatusCreated, handler: h.createFlight},
+		{Method: http.MethodGet, Pattern: "/flights/{flightNumber}", Summary: "Get a flight", Tag: "flights",
+			Response: domain.Flight{}, Status: http.StatusOK, handler: h.getFlight},
+		{Method: http.MethodGet, Pattern: "/flights/{flightNumber}/crew", Summary: "List the crew of a flight", Tag: "crew",
+			Response: domain.Crew{}, List: true, Query: paging, Status: http.StatusOK, handler: h.listCrew},
+		{Method: http.MethodPut, Pattern: "/flights/{flightNumber}/crew", Summary: "Assign the crew of a flight", Tag: "crew",
+			Request: AssignCrewRequest{}, Response: domain.Flight{}, Status: http.StatusOK, handler: h.assignCrew},
+		{Method: http.MethodPost, Pattern: "/flights/{flightNumber}/crew", Summary: "Add a crew member to a flight", Tag: "crew",
+			Request: CrewMemberRequest{}, Response: domain.Flight{}, Status: http.StatusOK, handler: h.addCrewMember},
+		{Method: http.MethodDelete, Pattern: "/flights/{flightNumber}/crew/{member}", Summary: "Remove a crew member, by registry ID or name, from a flight", Tag: "crew",
+			Response: domain.Flight{}, Status: http.StatusOK, handler: h.removeCrewMember},
+		{Method: http.MethodGet, Pattern: "/flights/{flightNumber}/crew-changes", Summary: "List the crew change audit trail of a flight", Tag: "crew",
+			Response: domain.CrewChange{}, List: true, Query: paging, Status: http.StatusOK, handler: h.listCrewChanges},
+		{Method: http.MethodGet, Pattern: "/flights/{flightNumber}/reservations", Summary: "List the reservations of a flight", Tag: "reservations",
+			Response: domain.Reservation{}, List: true, Query: paging, Status: http.StatusOK, handler: h.listReservations},
+		{Method: http.MethodPost, Pattern: "/reservations", Summary: "Book a flight", Tag: "reservations",
+			Request: BookFlightRequest{}, Response: domain.Reservation{}, Status: http.StatusCreated, handler: h.bookFlight},
+		{Method: http.MethodGet, Pattern: "/reservations/{reservationID}", Summary: "Get a reservation", Tag: "reservations",
+			Response: domain.Reservation{}, Status: http.StatusOK, handler: h.getReservation},
+		{Method: http.MethodPost, Pattern: "/reservations/{reservationID}/check-in", Summary: "Check in, choosing a seat or having one assigned", Tag: "reservations",
+			Request: CheckInRequest{}, Response: domain.Reservation{}, Status: http.StatusOK, handler: h.checkIn},
+		{Method: http.MethodPost, Pattern: "/reservations/{reservationID}/cancel", Summary: "Cancel a reservation", Tag: "reservations",
+			Response: domain.Reservation{}, Status: http.StatusOK, handler: h.cancelReservation},
+		{Method: http.MethodGet, Pattern: "/airplanes", Summary: "List airplanes", Tag: "airplanes",
+			Response: domain.Airplane{}, List: true, Query: paging, Status: http.StatusOK, handler: h.listAirplanes},
+		{Method: http.MethodPost, Pattern: "/airplanes", Summary: "Register an airplane", Tag: "airplanes",
+			Request: CreateAirplaneRequest{}, Response: domain.Airplane{}, Status: http.StatusCreated, handler: h.createAirplane},
+		{Method: http.MethodGet, Pattern: "/airplanes/{airplaneID}", Summary: "Get an airplane", Tag: "airplanes",
+			Response: domain.Airplane{}, Status: http.StatusOK, handler: h.getAirplane},
+	}
+}
+
+// ServeHTTP serves a request, letting reads run together and changes one at a time
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The password is checked before taking the lock, so checking it holds no other request up
+	r, err := h.authenticate(r)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		h.mutex.RLock()
+		defer h.mutex.RUnlock()
+	} else {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+	}
+	h.router.ServeHTTP(w, r)
+}
+
+// StorageLock returns the lock that serialises changes, for other APIs served by the same process
+func (h *Handler) StorageLock() *sync.RWMutex {
+	return &h.mutex
+}
+
+// health answers that the service is up
+func (h *Handler) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// openAPI returns the OpenAPI document of the API
+func (h *Handler) openAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, OpenAPI(h.Routes()))
+}
+
+// listFlights lists all flights, newest departure first, or the flights of a location on a date
+func (h *Handler) listFlights(w http.ResponseWriter, r *http.Request) {
+	page, perPage, err := pagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_query", err.Error())
+		return
+	}
+
+	location, date := r.URL.Query().Get("location"), r.URL.Query().Get("date")
+
+	var flights []*domain.Flight
+	switch {
+	case location == "" && date == "":
+		flights, err = h.flightsOf(r).ListAllFlights()
+	case location == "" || date == "":
+		writeError(w, http.StatusBadRequest, "invalid_query", "location and date must be given together")
+		return
+	default:
+		day, parseErr := time.Parse("2006-01-02", date)
+		if parseErr != nil {
+			writeError(w, http.StatusBadRequest, "invalid_query", "date must have the form YYYY-MM-DD")
+			return
+		}
+		flights, err = h.flightsOf(r).SearchFlights(location, day)
+	}
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	if flights == nil {
+		flights = []*domain.Flight{}
+	}
+	writeJSON(w, http.StatusOK, paginate(len(flights), page, perPage, func(start, end int) interface{} {
+		return flights[start:end]
+	}))
+}
+
+// CreateFlightRequest is the body of a request creating a flight
+type CreateFlightRequest struct {
+	FlightNumber    string    `json:"flight_number"`
+	DepartureCity   string    `json:"departure_city"`
+	DestinationCity string    `json:"destination_city"`
+	DepartureTime   time.Time `json:"departure_time"`
+	ArrivalTime     time.Time `json:"arrival_time"`
+	Capacity        int       `json:"capacity"`
+}
+
+// Validate checks the fields of the request
+func (req *CreateFlightRequest) Validate() error {
+	if !utils.IsFlightNumber(req.FlightNumber) {
+		return errors.New("flight_number must match the format Fxxxx")
+	}
+	if strings.TrimSpace(req.DepartureCity) == "" || strings.TrimSpace(req.DestinationCity) == "" {
+		return errors.New("departure_city and destination_city are required")
+	}
+	if strings.EqualFold(strings.TrimSpace(req.DepartureCity), strings.TrimSpace(req.DestinationCity)) {
+		return errors.New("departure_city and destination_city must differ")
+	}
+	if err := utils.CheckDates(req.DepartureTime, req.ArrivalTime, time.Now()); err != nil {
+		return err
+	}
+	if req.Capacity < domain.MinFlightCapacity || req.Capacity > domain.MaxFlightCapacity {
+		return fmt.Errorf("capacity must be between %d and %d", domain.MinFlightCapacity, domain.MaxFlightCapacity)
+	}
+	return nil
+}
+
+// createFlight creates a flight
+func (h *Handler) createFlight(w http.ResponseWriter, r *http.Request) {
+	var req CreateFlightRequest
+	if !decodeValid(w, r, &req) {
+		return
+	}
+
+	flight, err := h.flightsOf(r).AddFlight(req.FlightNumber, strings.TrimSpace(req.DepartureCity), strings.TrimSpace(req.DestinationCity),
+		req.DepartureTime, req.ArrivalTime, req.Capacity)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/flights/"+flight.FlightNumber)
+	writeJSON(w, http.StatusCreated, flight)
+}
+
+// getFlight returns a flight
+func (h *Handler) getFlight(w http.ResponseWriter, r *http.Request) {
+	flight, err := h.flightsOf(r).GetFlight(param(r, "flightNumber"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, flight)
+}
+
+// AssignCrewRequest is the body of a request assigning the crew of a flight
+type AssignCrewRequest struct {
+	Crew []domain.Crew `json:"crew"`
+}
+
+// Validate checks the fields of the request
+func (req *AssignCrewRequest) Validate() error {
+	if len(req.Crew) == 0 {
+		return errors.New("crew is required")
+	}
+	for i, member := range req.Crew {
+		if strings.TrimSpace(member.Name) == "" || strings.TrimSpace(member.Position) == "" {
+			return fmt.Errorf("crew[%d] needs a name and a position", i)
+		}
+	}
+	return nil
+}
+
+// assignCrew assigns the crew of a flight
+func (h *Handler) assignCrew(w http.ResponseWriter, r *http.Request) {
+	var req AssignCrewRequest
+	if !decodeValid(w, r, &req) {
+		return
+	}
+
+	flightNumber := param(r, "flightNumber")
+	if err := h.flightsOf(r).AssignCrew(flightNumber, req.Crew); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	flight, err := h.flightsOf(r).GetFlight(flightNumber)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, flight)
+}
+
+// listCrew lists the crew of a flight
+func (h *Handler) listCrew(w http.ResponseWriter, r *http.Request) {
+	page, perPage, err := pagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_query", err.Error())
+		return
+	}
+
+	flight, err := h.flightsOf(r).GetFlight(param(r, "flightNumber"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	crew := flight.CrewMembers
+	if crew == nil {
+		crew = []domain.Crew{}
+	}
+	writeJSON(w, http.StatusOK, paginate(len(crew), page, perPage, func(start, end int) interface{} {
+		return crew[start:end]
+	}))
+}
+
+// CrewMemberRequest is the body of a request adding a crew member to a flight
+type CrewMemberRequest struct {
+	Member domain.Crew `json:"member"`
+}
+
+// Validate checks the fields of the request
+func (req *CrewMemberRequest) Validate() error {
+	if strings.TrimSpace(req.Member.Name) == "" || strings.TrimSpace(req.Member.Position) == "" {
+		return errors.New("member needs a name and a position")
+	}
+	return nil
+}
+
+// addCrewMember adds a crew member to a flight
+func (h *Handler) addCrewMember(w http.ResponseWriter, r *http.Request) {
+	var req CrewMemberRequest
+	if !decodeValid(w, r, &req) {
+		return
+	}
+
+	flightNumber := param(r, "flightNumber")
+	if err := h.flightsOf(r).AddCrewMember(flightNumber, req.Member, userOf(r).Username); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.getFlight(w, r)
+}
+
+// removeCrewMember removes a crew member from a flight
+func (h *Handler) removeCrewMember(w http.ResponseWriter, r *http.Request) {
+	if err := h.flightsOf(r).RemoveCrewMember(param(r, "flightNumber"), param(r, "member"), userOf(r).Username); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.getFlight(w, r)
+}
+
+// listCrewChanges lists the crew change audit trail of a flight
+func (h *Handler) listCrewChanges(w http.ResponseWriter, r *http.Request) {
+	page, perPage, err := pagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_query", err.Error())
+		return
+	}
+
+	flightNumber := param(r, "flightNumber")
+	if _, err := h.flightsOf(r).GetFlight(flightNumber); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	changes, err := h.flightsOf(r).GetCrewChanges(flightNumber)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	if changes == nil {
+		changes = []*domain.CrewChange{}
+	}
+	writeJSON(w, http.StatusOK, paginate(len(changes), page, perPage, func(start, end int) interface{} {
+		return changes[start:end]
+	}))
+}
+
+// listReservations lists the reservations of a flight
+func (h *Handler) listReservations(w http.ResponseWriter, r *http.Request) {
+	page, perPage, err := pagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_query", err.Error())
+		return
+	}
+
+	reservations, err := h.reservationsOf(r).GetReservationsForFlight(param(r, "flightNumber"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	if reservations == nil {
+		reservations = []*domain.Reservation{}
+	}
+	writeJSON(w, http.StatusOK, paginate(len(reservations), page, perPage, func(start, end int) interface{} {
+		return reservations[start:end]
+	}))
+}
+
+// BookFlightRequest is the body of a request booking a flight
+type BookFlightRequest struct {
+	Name               string `json:"name"`
+	Address            string `json:"address"`
+	PhoneNumber        int64  `json:"phone_number"`
+	IdentityCardNumber int64  `json:"identity_card_number"`
+	FlightNumber       string `json:"flight_number"`
+	Class              string `json:"class,omitempty"` // Economy when empty
+}
+
+// Validate checks the fields of the request
+func (req *BookFlightRequest) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.TrimSpace(req.Address) == "" {
+		return errors.New("address is required")
+	}
+	if req.PhoneNumber <= 0 || req.IdentityCardNumber <= 0 {
+		return errors.New("phone_number and identity_card_number must be positive")
+	}
+	if !utils.IsFlightNumber(req.FlightNumber) {
+		return errors.New("flight_number must match the format Fxxxx")
+	}
+	return nil
+}
+
+// bookFlight books a flight
+func (h *Handler) bookFlight(w http.ResponseWriter, r *http.Request) {
+	var req BookFlightRequest
+	if !decodeValid(w, r, &req) {
+		return
+	}
+
+	reservation, err := h.reservationsOf(r).BookFlight(strings.TrimSpace(req.Name), strings.TrimSpace(req.Address),
+		req.PhoneNumber, req.IdentityCardNumber, req.FlightNumber, req.Class, r.Header.Get(SessionHeader))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/reservations/"+reservation.ReservationID)
+	writeJSON(w, http.StatusCreated, reservation)
+}
+
+// getReservation returns a reservation
+func (h *Handler) getReservation(w http.ResponseWriter, r *http.Request) {
+	reservation, err := h.reservationsOf(r).GetReservation(param(r, "reservationID"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, reservation)
+}
+
+// CheckInRequest is the body of a check-in request; the body may be left out to have a seat assigned
+type CheckInRequest struct {
+	Seat string `json:"seat,omitempty"`
+}
+
+// Validate checks the fields of the request
+func (req *CheckInRequest) Validate() error {
+	return nil
+}
+
+// checkIn checks a reservation in
+func (h *Handler) checkIn(w http.ResponseWriter, r *http.Request) {
+	var req CheckInRequest
+	if r.ContentLength != 0 && !decodeValid(w, r, &req) {
+		return
+	}
+
+	reservationID := param(r, "reservationID")
+	if err := h.reservationsOf(r).CheckIn(reservationID, strings.ToUpper(strings.TrimSpace(req.Seat)), r.Header.Get(SessionHeader)); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.getReservation(w, r)
+}
+
+// cancelReservation cancels a reservation
+func (h *Handler) cancelReservation(w http.ResponseWriter, r *http.Request) {
+	if err := h.reservationsOf(r).CancelReservation(param(r, "reservationID")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.getReservation(w, r)
+}
+
+// listAirplanes lists the airplanes
+func (h *Handler) listAirplanes(w http.ResponseWriter, r *http.Request) {
+	page, perPage, err := pagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_query", err.Error())
+		return
+	}
+
+	airplanes, err := h.airplanesOf(r).GetAirplanes()
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	if airplanes == nil {
+		airplanes = []domain.Airplane{}
+	}
+	writeJSON(w, http.StatusOK, paginate(len(airplanes), page, perPage, func(start, end int) interface{} {
+		return airplanes[start:end]
+	}))
+}
+
+// CreateAirplaneRequest is the body of a request registering an airplane
+type CreateAirplaneRequest struct {
+	ID       string `json:"id"`
+	Model    string `json:"model"`
+	Capacity int    `json:"capacity"`
+}
+
+// Validate checks the fields of the request
+func (req *CreateAirplaneRequest) Validate() error {
+	if strings.TrimSpace(req.ID) == "" || strings.TrimSpace(req.Model) == "" {
+		return errors.New("id and model are required")
+	}
+	if req.Capacity < domain.MinFlightCapacity || req.Capacity > domain.MaxFlightCapacity {
+		return fmt.Errorf("capacity must be between %d and %d", domain.MinFlightCapacity, domain.MaxFlightCapacity)
+	}
+	return nil
+}
+
+// createAirplane registers an airplane
+func (h *Handler) createAirplane(w http.ResponseWriter, r *http.Request) {
+	var req CreateAirplaneRequest
+	if !decodeValid(w, r, &req) {
+		return
+	}
+
+	id := strings.TrimSpace(req.ID)
+	if err := h.airplanesOf(r).AddAirplane(id, strings.TrimSpace(req.Model), req.Capacity); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	airplane, err := h.airplanesOf(r).GetAirplaneByID(id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/airplanes/"+airplane.ID)
+	writeJSON(w, http.StatusCreated, airplane)
+}
+
+// getAirplane returns an airplane
+func (h *Handler) getAirplane(w http.ResponseWriter, r *http.Request) {
+	airplane, err := h.airplanesOf(r).GetAirplaneByID(param(r, "airplaneID"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, airplane)
+}
+
+// validator is implemented by request bodies
+type validator interface {
+	Validate() error
+}
+
+// decodeValid decodes and validates a request body, writing a 400 response when it is invalid
+func decodeValid(w http.ResponseWriter, r *http.Request, req validator) bool {
+	if err := decode(w, r, req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+		return false
+	}
+	if err := req.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+		return false
+	}
+	return true
+}
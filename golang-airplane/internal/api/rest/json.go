@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/utils"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// ErrorBody is the JSON body of every error response
+type ErrorBody struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes why a request failed
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Page is the JSON body of a paginated list
+type Page struct {
+	Items   interface{} `json:"items"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Total   int         `json:"total"`
+}
+
+// writeJSON writes a value as a JSON response with a status code
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes an error response
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, ErrorBody{Error: ErrorDetail{Code: code, Message: message}})
+}
+
+// writeServiceError writes the response for an error returned by a service, choosing the status
+// code from the kind of error. Errors of no known kind, such as storage failures, are not shown
+// to the client.
+func writeServiceError(w http.ResponseWriter, err error) {
+	var rejection *domain.CheckInRejection
+	var broken *domain.Rejection
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		writeError(w, http.StatusNotFound, "not_found", err.Error())
+	case errors.Is(err, domain.ErrAlreadyExists):
+		writeError(w, http.StatusConflict, "already_exists", err.Error())
+	case errors.Is(err, domain.ErrUnauthorized):
+		writeUnauthorized(w, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		writeError(w, http.StatusForbidden, "forbidden", err.Error())
+	case errors.As(err, &rejection):
+		writeError(w, http.StatusConflict, string(rejection.Reason), rejection.Message)
+	case errors.Is(err, flight.ErrNoSeatsAvailable):
+		writeError(w, http.StatusConflict, "no_seats_available", err.Error())
+	case errors.Is(err, flight.ErrDeniedBoarding):
+		writeError(w, http.StatusConflict, "denied_boarding", err.Error())
+	case errors.As(err, &broken):
+		writeError(w, http.StatusUnprocessableEntity, "rejected", err.Error())
+	default:
+		if recorder, ok := w.(*statusRecorder); ok {
+			recorder.err = err
+		}
+		writeError(w, http.StatusInternalServerError, "internal", "internal server error")
+	}
+}
+
+// decode reads the JSON body of a request into a value, refusing unknown fields and trailing data
+func decode(w http.ResponseWriter, r *http.Request, value interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(value); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("request body is empty")
+		}
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	if decoder.More() {
+		return errors.New("request body must contain a single JSON object")
+	}
+	return nil
+}
+
+// pagination reads the page and per_page query parameters
+func pagination(r *http.Request) (int, int, error) {
+	page, perPage := 1, utils.DefaultPerPage
+
+	if value := r.URL.Query().Get("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return 0, 0, errors.New("page must be a positive integer")
+		}
+		page = n
+	}
+	if value := r.URL.Query().Get("per_page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > utils.MaxPerPage {
+			return 0, 0, fmt.Errorf("per_page must be an integer between 1 and %d", utils.MaxPerPage)
+		}
+		perPage = n
+	}
+
+	return page, perPage, nil
+}
+
+// paginate returns one page of a list of total items, given the items of the page
+func paginate(total, page, perPage int, slice func(start, end int) interface{}) Page {
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	return Page{Items: slice(start, end), Page: page, PerPage: perPage, Total: total}
+}
@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// statusRecorder remembers the status code written by a handler, and the internal error it hid from the client
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	err    error
+}
+
+// WriteHeader records the status code and writes it
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// LogRequests logs the method, path, status and duration of every request
+func LogRequests(next http.Handler, logger *log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		logger.Printf("%s %s %d %s", r.Method, r.URL.Path, recorder.status, time.Since(start).Round(time.Microsecond))
+		if recorder.err != nil {
+			logger.Printf("error serving %s %s: %v", r.Method, r.URL.Path, recorder.err)
+		}
+	})
+}
+
+// Recover answers 500 instead of dropping the connection when a handler panics
+func Recover(next http.Handler, logger *log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				logger.Printf("panic serving %s %s: %v", r.Method, r.URL.Path, err)
+				writeError(w, http.StatusInternalServerError, "internal", "internal server error")
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
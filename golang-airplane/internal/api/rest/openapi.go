@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIVersion is the version of the API published in its OpenAPI document
+const APIVersion = "1.0.0"
+
+// OpenAPI generates the OpenAPI 3 document of a set of routes from their patterns and the Go types
+// of their request and response bodies
+func OpenAPI(routes []Route) map[string]interface{} {
+	g := &schemaGenerator{schemas: map[string]interface{}{}}
+	errorSchema := g.schema(reflect.TypeOf(ErrorBody{}))
+
+	paths := map[string]interface{}{}
+	for _, route := range routes {
+		operation := map[string]interface{}{
+			"operationId": operationID(route),
+			"summary":     route.Summary,
+		}
+		if route.Tag != "" {
+			operation["tags"] = []string{route.Tag}
+		}
+		if route.Public {
+			operation["security"] = []interface{}{}
+		}
+
+		parameters := []interface{}{}
+		for _, part := range strings.Split(route.Pattern, "/") {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				parameters = append(parameters, map[string]interface{}{
+					"name":     part[1 : len(part)-1],
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]interface{}{"type": "string"},
+				})
+			}
+		}
+		for _, query := range route.Query {
+			parameters = append(parameters, map[string]interface{}{
+				"name":        query.Name,
+				"in":          "query",
+				"description": query.Description,
+				"schema":      map[string]interface{}{"type": query.Type},
+			})
+		}
+		if route.Method == http.MethodPost || route.Method == http.MethodPut {
+			parameters = append(parameters, map[string]interface{}{
+				"name":        SessionHeader,
+				"in":          "header",
+				"description": "Booking session token tying seat holds to the client",
+				"schema":      map[string]interface{}{"type": "string"},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if route.Request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				// A check-in without a body has a seat assigned
+				"required": reflect.TypeOf(route.Request) != reflect.TypeOf(CheckInRequest{}),
+				"content":  jsonContent(g.schema(reflect.TypeOf(route.Request))),
+			}
+		}
+
+		responses := map[string]interface{}{
+			"default": map[string]interface{}{
+				"description": "Error",
+				"content":     jsonContent(errorSchema),
+			},
+		}
+		if route.Response != nil {
+			schema := g.schema(reflect.TypeOf(route.Response))
+			if route.List {
+				schema = pageSchema(schema)
+			}
+			responses[strconv.Itoa(route.Status)] = map[string]interface{}{
+				"description": http.StatusText(route.Status),
+				"content":     jsonContent(schema),
+			}
+		}
+		operation["responses"] = responses
+
+		path, _ := paths[route.Pattern].(map[string]interface{})
+		if path == nil {
+			path = map[string]interface{}{}
+			paths[route.Pattern] = path
+		}
+		path[strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Airline Management API",
+			"version":     APIVersion,
+			"description": "Flights, reservations, crew and airplanes of the airline management system",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": g.schemas,
+			"securitySchemes": map[string]interface{}{
+				"basicAuth": map[string]interface{}{
+					"type":        "http",
+					"scheme":      "basic",
+					"description": "Username and password of a user account",
+				},
+			},
+		},
+		"security": []interface{}{map[string]interface{}{"basicAuth": []string{}}},
+	}
+}
+
+// operationID returns the operation ID of a route, which is the name of its handler method
+func operationID(route Route) string {
+	name := runtime.FuncForPC(reflect.ValueOf(route.handler).Pointer()).Name()
+	name = name[strings.LastIndex(name, ".")+1:]
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// jsonContent returns the content of a JSON body with a schema
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+// pageSchema returns the schema of a page of items with a schema
+func pageSchema(items map[string]interface{}) map[string]interface{} {
+	integer := map[string]interface{}{"type": "integer"}
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"items", "page", "per_page", "total"},
+		"properties": map[string]interface{}{
+			"items":    map[string]interface{}{"type": "array", "items": items},
+			"page":     integer,
+			"per_page": integer,
+			"total":    integer,
+		},
+	}
+}
+
+// schemaGenerator builds JSON schemas of Go types, collecting named structs as components
+type schemaGenerator struct {
+	schemas map[string]interface{}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schema returns the schema of a Go type as it is encoded by encoding/json
+func (g *schemaGenerator) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, exists := g.schemas[t.Name()]; !exists {
+			// Registered before the fields so recursive types end
+			g.schemas[t.Name()] = map[string]interface{}{}
+			g.schemas[t.Name()] = g.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	// Interfaces hold any value
+	return map[string]interface{}{}
+}
+
+// structSchema returns the object schema of a struct type from its JSON field tags
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name, options := field.Name, ""
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			parts := strings.SplitN(tag, ",", 2)
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			if len(parts) > 1 {
+				options = parts[1]
+			}
+		}
+
+		properties[name] = g.schema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
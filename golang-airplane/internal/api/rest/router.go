@@ -0,0 +1,98 @@
+// Package rest exposes the flight, reservation and airplane services as a JSON HTTP API
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Route is one endpoint of the API, described well enough to generate its OpenAPI operation
+type Route struct {
+	Method   string
+	Pattern  string // Path with {name} placeholders, such as /flights/{flightNumber}
+	Summary  string
+	Tag      string
+	Query    []QueryParam
+	Request  interface{} // Zero value of the request body, or nil without a body
+	Response interface{} // Zero value of the response body, or of a list item when List is set
+	List     bool        // The response is a page of Response items
+	Status   int         // Status code of a successful response
+	Public   bool        // Served without authentication
+	handler  http.HandlerFunc
+}
+
+// QueryParam is a query parameter of a route
+type QueryParam struct {
+	Name        string
+	Type        string // OpenAPI type, such as string or integer
+	Description string
+}
+
+// router dispatches requests to the route matching their method and path
+type router struct {
+	routes []Route
+}
+
+type paramsKey struct{}
+
+// ServeHTTP calls the handler of the matching route, answering 404 for unknown paths and
+// 405 for known paths requested with another method
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	allowed := []string{}
+	for _, route := range rt.routes {
+		params, ok := match(route.Pattern, r.URL.Path)
+		if !ok {
+			continue
+		}
+		if route.Method != r.Method {
+			allowed = append(allowed, route.Method)
+			continue
+		}
+
+		if !route.Public && userOf(r) == nil {
+			writeUnauthorized(w, "authentication required")
+			return
+		}
+
+		route.handler(w, r.WithContext(context.WithValue(r.Context(), paramsKey{}, params)))
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "method "+r.Method+" is not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "not_found", "no endpoint at "+r.URL.Path)
+}
+
+// match reports whether a path matches a route pattern and returns the values of its placeholders
+func match(pattern, path string) (map[string]string, bool) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternParts) != len(pathParts) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if pathParts[i] == "" {
+				return nil, false
+			}
+			params[part[1:len(part)-1]] = pathParts[i]
+			continue
+		}
+		if part != pathParts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// param returns the value of a path placeholder of the request
+func param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return params[name]
+}
@@ -0,0 +1,924 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: airline/v1/airline.proto
+
+// Airline management services for internal integrations.
+
+package airlinepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	FlightService_CreateFlight_FullMethodName       = "/airline.v1.FlightService/CreateFlight"
+	FlightService_GetFlight_FullMethodName          = "/airline.v1.FlightService/GetFlight"
+	FlightService_ListFlights_FullMethodName        = "/airline.v1.FlightService/ListFlights"
+	FlightService_SearchFlights_FullMethodName      = "/airline.v1.FlightService/SearchFlights"
+	FlightService_UpdateFlightStatus_FullMethodName = "/airline.v1.FlightService/UpdateFlightStatus"
+	FlightService_WatchFlightStatus_FullMethodName  = "/airline.v1.FlightService/WatchFlightStatus"
+)
+
+// FlightServiceClient is the client API for FlightService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FlightServiceClient interface {
+	CreateFlight(ctx context.Context, in *CreateFlightRequest, opts ...grpc.CallOption) (*Flight, error)
+	GetFlight(ctx context.Context, in *GetFlightRequest, opts ...grpc.CallOption) (*Flight, error)
+	ListFlights(ctx context.Context, in *ListFlightsRequest, opts ...grpc.CallOption) (*ListFlightsResponse, error)
+	SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (*ListFlightsResponse, error)
+	UpdateFlightStatus(ctx context.Context, in *UpdateFlightStatusRequest, opts ...grpc.CallOption) (*Flight, error)
+	// WatchFlightStatus sends the status of a flight and then every change of its status, gate or times
+	// until the flight departs or is cancelled.
+	WatchFlightStatus(ctx context.Context, in *WatchFlightStatusRequest, opts ...grpc.CallOption) (FlightService_WatchFlightStatusClient, error)
+}
+
+type flightServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFlightServiceClient(cc grpc.ClientConnInterface) FlightServiceClient {
+	return &flightServiceClient{cc}
+}
+
+func (c *flightServiceClient) CreateFlight(ctx context.Context, in *CreateFlightRequest, opts ...grpc.CallOption) (*Flight, error) {
+	out := new(Flight)
+	err := c.cc.Invoke(ctx, FlightService_CreateFlight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) GetFlight(ctx context.Context, in *GetFlightRequest, opts ...grpc.CallOption) (*Flight, error) {
+	out := new(Flight)
+	err := c.cc.Invoke(ctx, FlightService_GetFlight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) ListFlights(ctx context.Context, in *ListFlightsRequest, opts ...grpc.CallOption) (*ListFlightsResponse, error) {
+	out := new(ListFlightsResponse)
+	err := c.cc.Invoke(ctx, FlightService_ListFlights_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (*ListFlightsResponse, error) {
+	out := new(ListFlightsResponse)
+	err := c.cc.Invoke(ctx, FlightService_SearchFlights_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) UpdateFlightStatus(ctx context.Context, in *UpdateFlightStatusRequest, opts ...grpc.CallOption) (*Flight, error) {
+	out := new(Flight)
+	err := c.cc.Invoke(ctx, FlightService_UpdateFlightStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) WatchFlightStatus(ctx context.Context, in *WatchFlightStatusRequest, opts ...grpc.CallOption) (FlightService_WatchFlightStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FlightService_ServiceDesc.Streams[0], FlightService_WatchFlightStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &flightServiceWatchFlightStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FlightService_WatchFlightStatusClient interface {
+	Recv() (*FlightStatusEvent, error)
+	grpc.ClientStream
+}
+
+type flightServiceWatchFlightStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *flightServiceWatchFlightStatusClient) Recv() (*FlightStatusEvent, error) {
+	m := new(FlightStatusEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FlightServiceServer is the server API for FlightService service.
+// All implementations must embed UnimplementedFlightServiceServer
+// for forward compatibility
+type FlightServiceServer interface {
+	CreateFlight(context.Context, *CreateFlightRequest) (*Flight, error)
+	GetFlight(context.Context, *GetFlightRequest) (*Flight, error)
+	ListFlights(context.Context, *ListFlightsRequest) (*ListFlightsResponse, error)
+	SearchFlights(context.Context, *SearchFlightsRequest) (*ListFlightsResponse, error)
+	UpdateFlightStatus(context.Context, *UpdateFlightStatusRequest) (*Flight, error)
+	// WatchFlightStatus sends the status of a flight and then every change of its status, gate or times
+	// until the flight departs or is cancelled.
+	WatchFlightStatus(*WatchFlightStatusRequest, FlightService_WatchFlightStatusServer) error
+	mustEmbedUnimplementedFlightServiceServer()
+}
+
+// UnimplementedFlightServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFlightServiceServer struct {
+}
+
+func (UnimplementedFlightServiceServer) CreateFlight(context.Context, *CreateFlightRequest) (*Flight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFlight not implemented")
+}
+func (UnimplementedFlightServiceServer) GetFlight(context.Context, *GetFlightRequest) (*Flight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlight not implemented")
+}
+func (UnimplementedFlightServiceServer) ListFlights(context.Context, *ListFlightsRequest) (*ListFlightsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFlights not implemented")
+}
+func (UnimplementedFlightServiceServer) SearchFlights(context.Context, *SearchFlightsRequest) (*ListFlightsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchFlights not implemented")
+}
+func (UnimplementedFlightServiceServer) UpdateFlightStatus(context.Context, *UpdateFlightStatusRequest) (*Flight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFlightStatus not implemented")
+}
+func (UnimplementedFlightServiceServer) WatchFlightStatus(*WatchFlightStatusRequest, FlightService_WatchFlightStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFlightStatus not implemented")
+}
+func (UnimplementedFlightServiceServer) mustEmbedUnimplementedFlightServiceServer() {}
+
+// UnsafeFlightServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FlightServiceServer will
+// result in compilation errors.
+type UnsafeFlightServiceServer interface {
+	mustEmbedUnimplementedFlightServiceServer()
+}
+
+func RegisterFlightServiceServer(s grpc.ServiceRegistrar, srv FlightServiceServer) {
+	s.RegisterService(&FlightService_ServiceDesc, srv)
+}
+
+func _FlightService_CreateFlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).CreateFlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_CreateFlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).CreateFlight(ctx, req.(*CreateFlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_GetFlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).GetFlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_GetFlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).GetFlight(ctx, req.(*GetFlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_ListFlights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFlightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).ListFlights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_ListFlights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).ListFlights(ctx, req.(*ListFlightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_SearchFlights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFlightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).SearchFlights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_SearchFlights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).SearchFlights(ctx, req.(*SearchFlightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_UpdateFlightStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFlightStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).UpdateFlightStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_UpdateFlightStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).UpdateFlightStatus(ctx, req.(*UpdateFlightStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_WatchFlightStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFlightStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FlightServiceServer).WatchFlightStatus(m, &flightServiceWatchFlightStatusServer{stream})
+}
+
+type FlightService_WatchFlightStatusServer interface {
+	Send(*FlightStatusEvent) error
+	grpc.ServerStream
+}
+
+type flightServiceWatchFlightStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *flightServiceWatchFlightStatusServer) Send(m *FlightStatusEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// FlightService_ServiceDesc is the grpc.ServiceDesc for FlightService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FlightService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "airline.v1.FlightService",
+	HandlerType: (*FlightServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFlight",
+			Handler:    _FlightService_CreateFlight_Handler,
+		},
+		{
+			MethodName: "GetFlight",
+			Handler:    _FlightService_GetFlight_Handler,
+		},
+		{
+			MethodName: "ListFlights",
+			Handler:    _FlightService_ListFlights_Handler,
+		},
+		{
+			MethodName: "SearchFlights",
+			Handler:    _FlightService_SearchFlights_Handler,
+		},
+		{
+			MethodName: "UpdateFlightStatus",
+			Handler:    _FlightService_UpdateFlightStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFlightStatus",
+			Handler:       _FlightService_WatchFlightStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "airline/v1/airline.proto",
+}
+
+const (
+	ReservationService_BookFlight_FullMethodName        = "/airline.v1.ReservationService/BookFlight"
+	ReservationService_GetReservation_FullMethodName    = "/airline.v1.ReservationService/GetReservation"
+	ReservationService_ListReservations_FullMethodName  = "/airline.v1.ReservationService/ListReservations"
+	ReservationService_CheckIn_FullMethodName           = "/airline.v1.ReservationService/CheckIn"
+	ReservationService_CancelReservation_FullMethodName = "/airline.v1.ReservationService/CancelReservation"
+)
+
+// ReservationServiceClient is the client API for ReservationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReservationServiceClient interface {
+	BookFlight(ctx context.Context, in *BookFlightRequest, opts ...grpc.CallOption) (*Reservation, error)
+	GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error)
+	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*Reservation, error)
+	CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+}
+
+type reservationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReservationServiceClient(cc grpc.ClientConnInterface) ReservationServiceClient {
+	return &reservationServiceClient{cc}
+}
+
+func (c *reservationServiceClient) BookFlight(ctx context.Context, in *BookFlightRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, ReservationService_BookFlight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, ReservationService_GetReservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error) {
+	out := new(ListReservationsResponse)
+	err := c.cc.Invoke(ctx, ReservationService_ListReservations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, ReservationService_CheckIn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, ReservationService_CancelReservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReservationServiceServer is the server API for ReservationService service.
+// All implementations must embed UnimplementedReservationServiceServer
+// for forward compatibility
+type ReservationServiceServer interface {
+	BookFlight(context.Context, *BookFlightRequest) (*Reservation, error)
+	GetReservation(context.Context, *GetReservationRequest) (*Reservation, error)
+	ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+	CheckIn(context.Context, *CheckInRequest) (*Reservation, error)
+	CancelReservation(context.Context, *CancelReservationRequest) (*Reservation, error)
+	mustEmbedUnimplementedReservationServiceServer()
+}
+
+// UnimplementedReservationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReservationServiceServer struct {
+}
+
+func (UnimplementedReservationServiceServer) BookFlight(context.Context, *BookFlightRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BookFlight not implemented")
+}
+func (UnimplementedReservationServiceServer) GetReservation(context.Context, *GetReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReservation not implemented")
+}
+func (UnimplementedReservationServiceServer) ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
+}
+func (UnimplementedReservationServiceServer) CheckIn(context.Context, *CheckInRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+}
+func (UnimplementedReservationServiceServer) CancelReservation(context.Context, *CancelReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (UnimplementedReservationServiceServer) mustEmbedUnimplementedReservationServiceServer() {}
+
+// UnsafeReservationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReservationServiceServer will
+// result in compilation errors.
+type UnsafeReservationServiceServer interface {
+	mustEmbedUnimplementedReservationServiceServer()
+}
+
+func RegisterReservationServiceServer(s grpc.ServiceRegistrar, srv ReservationServiceServer) {
+	s.RegisterService(&ReservationService_ServiceDesc, srv)
+}
+
+func _ReservationService_BookFlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookFlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).BookFlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_BookFlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).BookFlight(ctx, req.(*BookFlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_GetReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).GetReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_GetReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).GetReservation(ctx, req.(*GetReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).ListReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_ListReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).ListReservations(ctx, req.(*ListReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_CheckIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).CheckIn(ctx, req.(*CheckInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).CancelReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_CancelReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).CancelReservation(ctx, req.(*CancelReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReservationService_ServiceDesc is the grpc.ServiceDesc for ReservationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReservationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "airline.v1.ReservationService",
+	HandlerType: (*ReservationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BookFlight",
+			Handler:    _ReservationService_BookFlight_Handler,
+		},
+		{
+			MethodName: "GetReservation",
+			Handler:    _ReservationService_GetReservation_Handler,
+		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _ReservationService_ListReservations_Handler,
+		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _ReservationService_CheckIn_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _ReservationService_CancelReservation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "airline/v1/airline.proto",
+}
+
+const (
+	SeatService_GetSeatMap_FullMethodName   = "/airline.v1.SeatService/GetSeatMap"
+	SeatService_WatchSeatMap_FullMethodName = "/airline.v1.SeatService/WatchSeatMap"
+)
+
+// SeatServiceClient is the client API for SeatService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SeatServiceClient interface {
+	GetSeatMap(ctx context.Context, in *GetSeatMapRequest, opts ...grpc.CallOption) (*SeatMap, error)
+	// WatchSeatMap sends the seat map of a flight and then the whole map again whenever a seat changes state.
+	WatchSeatMap(ctx context.Context, in *GetSeatMapRequest, opts ...grpc.CallOption) (SeatService_WatchSeatMapClient, error)
+}
+
+type seatServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSeatServiceClient(cc grpc.ClientConnInterface) SeatServiceClient {
+	return &seatServiceClient{cc}
+}
+
+func (c *seatServiceClient) GetSeatMap(ctx context.Context, in *GetSeatMapRequest, opts ...grpc.CallOption) (*SeatMap, error) {
+	out := new(SeatMap)
+	err := c.cc.Invoke(ctx, SeatService_GetSeatMap_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seatServiceClient) WatchSeatMap(ctx context.Context, in *GetSeatMapRequest, opts ...grpc.CallOption) (SeatService_WatchSeatMapClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SeatService_ServiceDesc.Streams[0], SeatService_WatchSeatMap_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seatServiceWatchSeatMapClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SeatService_WatchSeatMapClient interface {
+	Recv() (*SeatMap, error)
+	grpc.ClientStream
+}
+
+type seatServiceWatchSeatMapClient struct {
+	grpc.ClientStream
+}
+
+func (x *seatServiceWatchSeatMapClient) Recv() (*SeatMap, error) {
+	m := new(SeatMap)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SeatServiceServer is the server API for SeatService service.
+// All implementations must embed UnimplementedSeatServiceServer
+// for forward compatibility
+type SeatServiceServer interface {
+	GetSeatMap(context.Context, *GetSeatMapRequest) (*SeatMap, error)
+	// WatchSeatMap sends the seat map of a flight and then the whole map again whenever a seat changes state.
+	WatchSeatMap(*GetSeatMapRequest, SeatService_WatchSeatMapServer) error
+	mustEmbedUnimplementedSeatServiceServer()
+}
+
+// UnimplementedSeatServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSeatServiceServer struct {
+}
+
+func (UnimplementedSeatServiceServer) GetSeatMap(context.Context, *GetSeatMapRequest) (*SeatMap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeatMap not implemented")
+}
+func (UnimplementedSeatServiceServer) WatchSeatMap(*GetSeatMapRequest, SeatService_WatchSeatMapServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSeatMap not implemented")
+}
+func (UnimplementedSeatServiceServer) mustEmbedUnimplementedSeatServiceServer() {}
+
+// UnsafeSeatServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SeatServiceServer will
+// result in compilation errors.
+type UnsafeSeatServiceServer interface {
+	mustEmbedUnimplementedSeatServiceServer()
+}
+
+func RegisterSeatServiceServer(s grpc.ServiceRegistrar, srv SeatServiceServer) {
+	s.RegisterService(&SeatService_ServiceDesc, srv)
+}
+
+func _SeatService_GetSeatMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeatMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeatServiceServer).GetSeatMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeatService_GetSeatMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeatServiceServer).GetSeatMap(ctx, req.(*GetSeatMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeatService_WatchSeatMap_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetSeatMapRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SeatServiceServer).WatchSeatMap(m, &seatServiceWatchSeatMapServer{stream})
+}
+
+type SeatService_WatchSeatMapServer interface {
+	Send(*SeatMap) error
+	grpc.ServerStream
+}
+
+type seatServiceWatchSeatMapServer struct {
+	grpc.ServerStream
+}
+
+func (x *seatServiceWatchSeatMapServer) Send(m *SeatMap) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// SeatService_ServiceDesc is the grpc.ServiceDesc for SeatService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SeatService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "airline.v1.SeatService",
+	HandlerType: (*SeatServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSeatMap",
+			Handler:    _SeatService_GetSeatMap_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSeatMap",
+			Handler:       _SeatService_WatchSeatMap_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "airline/v1/airline.proto",
+}
+
+const (
+	CrewService_AssignCrew_FullMethodName       = "/airline.v1.CrewService/AssignCrew"
+	CrewService_AddCrewMember_FullMethodName    = "/airline.v1.CrewService/AddCrewMember"
+	CrewService_RemoveCrewMember_FullMethodName = "/airline.v1.CrewService/RemoveCrewMember"
+	CrewService_ListCrewChanges_FullMethodName  = "/airline.v1.CrewService/ListCrewChanges"
+)
+
+// CrewServiceClient is the client API for CrewService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CrewServiceClient interface {
+	AssignCrew(ctx context.Context, in *AssignCrewRequest, opts ...grpc.CallOption) (*Flight, error)
+	AddCrewMember(ctx context.Context, in *AddCrewMemberRequest, opts ...grpc.CallOption) (*Flight, error)
+	RemoveCrewMember(ctx context.Context, in *RemoveCrewMemberRequest, opts ...grpc.CallOption) (*Flight, error)
+	ListCrewChanges(ctx context.Context, in *ListCrewChangesRequest, opts ...grpc.CallOption) (*ListCrewChangesResponse, error)
+}
+
+type crewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCrewServiceClient(cc grpc.ClientConnInterface) CrewServiceClient {
+	return &crewServiceClient{cc}
+}
+
+func (c *crewServiceClient) AssignCrew(ctx context.Context, in *AssignCrewRequest, opts ...grpc.CallOption) (*Flight, error) {
+	out := new(Flight)
+	err := c.cc.Invoke(ctx, CrewService_AssignCrew_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crewServiceClient) AddCrewMember(ctx context.Context, in *AddCrewMemberRequest, opts ...grpc.CallOption) (*Flight, error) {
+	out := new(Flight)
+	err := c.cc.Invoke(ctx, CrewService_AddCrewMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crewServiceClient) RemoveCrewMember(ctx context.Context, in *RemoveCrewMemberRequest, opts ...grpc.CallOption) (*Flight, error) {
+	out := new(Flight)
+	err := c.cc.Invoke(ctx, CrewService_RemoveCrewMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crewServiceClient) ListCrewChanges(ctx context.Context, in *ListCrewChangesRequest, opts ...grpc.CallOption) (*ListCrewChangesResponse, error) {
+	out := new(ListCrewChangesResponse)
+	err := c.cc.Invoke(ctx, CrewService_ListCrewChanges_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CrewServiceServer is the server API for CrewService service.
+// All implementations must embed UnimplementedCrewServiceServer
+// for forward compatibility
+type CrewServiceServer interface {
+	AssignCrew(context.Context, *AssignCrewRequest) (*Flight, error)
+	AddCrewMember(context.Context, *AddCrewMemberRequest) (*Flight, error)
+	RemoveCrewMember(context.Context, *RemoveCrewMemberRequest) (*Flight, error)
+	ListCrewChanges(context.Context, *ListCrewChangesRequest) (*ListCrewChangesResponse, error)
+	mustEmbedUnimplementedCrewServiceServer()
+}
+
+// UnimplementedCrewServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCrewServiceServer struct {
+}
+
+func (UnimplementedCrewServiceServer) AssignCrew(context.Context, *AssignCrewRequest) (*Flight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignCrew not implemented")
+}
+func (UnimplementedCrewServiceServer) AddCrewMember(context.Context, *AddCrewMemberRequest) (*Flight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCrewMember not implemented")
+}
+func (UnimplementedCrewServiceServer) RemoveCrewMember(context.Context, *RemoveCrewMemberRequest) (*Flight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCrewMember not implemented")
+}
+func (UnimplementedCrewServiceServer) ListCrewChanges(context.Context, *ListCrewChangesRequest) (*ListCrewChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCrewChanges not implemented")
+}
+func (UnimplementedCrewServiceServer) mustEmbedUnimplementedCrewServiceServer() {}
+
+// UnsafeCrewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CrewServiceServer will
+// result in compilation errors.
+type UnsafeCrewServiceServer interface {
+	mustEmbedUnimplementedCrewServiceServer()
+}
+
+func RegisterCrewServiceServer(s grpc.ServiceRegistrar, srv CrewServiceServer) {
+	s.RegisterService(&CrewService_ServiceDesc, srv)
+}
+
+func _CrewService_AssignCrew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignCrewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrewServiceServer).AssignCrew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrewService_AssignCrew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrewServiceServer).AssignCrew(ctx, req.(*AssignCrewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrewService_AddCrewMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCrewMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrewServiceServer).AddCrewMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrewService_AddCrewMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrewServiceServer).AddCrewMember(ctx, req.(*AddCrewMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrewService_RemoveCrewMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCrewMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrewServiceServer).RemoveCrewMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrewService_RemoveCrewMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrewServiceServer).RemoveCrewMember(ctx, req.(*RemoveCrewMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrewService_ListCrewChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCrewChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrewServiceServer).ListCrewChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrewService_ListCrewChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrewServiceServer).ListCrewChanges(ctx, req.(*ListCrewChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CrewService_ServiceDesc is the grpc.ServiceDesc for CrewService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CrewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "airline.v1.CrewService",
+	HandlerType: (*CrewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AssignCrew",
+			Handler:    _CrewService_AssignCrew_Handler,
+		},
+		{
+			MethodName: "AddCrewMember",
+			Handler:    _CrewService_AddCrewMember_Handler,
+		},
+		{
+			MethodName: "RemoveCrewMember",
+			Handler:    _CrewService_RemoveCrewMember_Handler,
+		},
+		{
+			MethodName: "ListCrewChanges",
+			Handler:    _CrewService_ListCrewChanges_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "airline/v1/airline.proto",
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationKey is the metadata key carrying the basic auth credentials of a call, as in HTTP
+const authorizationKey = "authorization"
+
+type userKey struct{}
+
+// authenticate returns the context of a call with the user of the basic auth credentials in its metadata
+func (s *Server) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	username, password, ok := parseBasicAuth(values[0])
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization must hold basic auth credentials")
+	}
+
+	user, err := s.access.Authenticate(username, password)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return context.WithValue(ctx, userKey{}, user), nil
+}
+
+// parseBasicAuth returns the username and password of a basic auth "Basic base64(username:password)" value
+func parseBasicAuth(value string) (string, string, bool) {
+	const prefix = "Basic "
+	if len(value) < len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value[len(prefix):])
+	if err != nil {
+		return "", "", false
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	return username, password, ok
+}
+
+// authenticatedStream is a stream whose context carries the authenticated user
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream with the authenticated user
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// userOf returns the authenticated user of a call
+func userOf(ctx context.Context) *domain.User {
+	user, _ := ctx.Value(userKey{}).(*domain.User)
+	return user
+}
+
+// flightsOf returns the flight service as the user of a call may use it
+func (s *Server) flightsOf(ctx context.Context) ports.FlightService {
+	user := userOf(ctx)
+	return s.access.GuardFlights(s.services(user.Username).Flights, user)
+}
+
+// reservationsOf returns the reservation service as the user of a call may use it
+func (s *Server) reservationsOf(ctx context.Context) ports.ReservationService {
+	user := userOf(ctx)
+	return s.access.GuardReservations(s.services(user.Username).Reservations, user)
+}
@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"golang-airplane/internal/api/rpc/airlinepb"
+	"golang-airplane/internal/core/domain"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// flightMessage converts a flight
+func flightMessage(f *domain.Flight) *airlinepb.Flight {
+	message := &airlinepb.Flight{
+		FlightNumber:    f.FlightNumber,
+		DepartureCity:   f.DepartureCity,
+		DestinationCity: f.DestinationCity,
+		DepartureTime:   timestamppb.New(f.DepartureTime),
+		ArrivalTime:     timestamppb.New(f.ArrivalTime),
+		Capacity:        int32(f.FlightCapacity),
+		AvailableSeats:  int32(f.AvailableSeat),
+		Status:          f.CurrentStatus(),
+		Gate:            f.Gate,
+		AirplaneId:      f.AirplaneID,
+	}
+	for _, member := range f.CrewMembers {
+		message.Crew = append(message.Crew, crewMessage(member))
+	}
+	return message
+}
+
+// flightMessages converts a list of flights
+func flightMessages(flights []*domain.Flight) []*airlinepb.Flight {
+	messages := make([]*airlinepb.Flight, 0, len(flights))
+	for _, f := range flights {
+		messages = append(messages, flightMessage(f))
+	}
+	return messages
+}
+
+// statusEvent converts the status of a flight into an event observed now
+func statusEvent(f *domain.Flight) *airlinepb.FlightStatusEvent {
+	return &airlinepb.FlightStatusEvent{
+		FlightNumber:  f.FlightNumber,
+		Status:        f.CurrentStatus(),
+		Gate:          f.Gate,
+		DepartureTime: timestamppb.New(f.DepartureTime),
+		ArrivalTime:   timestamppb.New(f.ArrivalTime),
+		ObservedAt:    timestamppb.Now(),
+	}
+}
+
+// crewMessage converts a crew entry
+func crewMessage(member domain.Crew) *airlinepb.CrewMember {
+	return &airlinepb.CrewMember{Id: member.ID, Name: member.Name, Position: member.Position}
+}
+
+// crewEntry converts a crew member message back into a crew entry
+func crewEntry(member *airlinepb.CrewMember) domain.Crew {
+	return domain.Crew{ID: member.GetId(), Name: member.GetName(), Position: member.GetPosition()}
+}
+
+// crewChangeMessage converts an entry of the crew change audit trail
+func crewChangeMessage(change *domain.CrewChange) *airlinepb.CrewChange {
+	message := &airlinepb.CrewChange{
+		FlightNumber: change.FlightNumber,
+		Action:       change.Action,
+		ChangedBy:    change.ChangedBy,
+		ChangedAt:    timestamppb.New(change.ChangedAt),
+	}
+	if change.Removed != nil {
+		message.Removed = crewMessage(*change.Removed)
+	}
+	if change.Added != nil {
+		message.Added = crewMessage(*change.Added)
+	}
+	return message
+}
+
+// reservationMessage converts a reservation
+func reservationMessage(r *domain.Reservation) *airlinepb.Reservation {
+	return &airlinepb.Reservation{
+		ReservationId:      r.ReservationID,
+		Name:               r.Name,
+		Address:            r.Address,
+		PhoneNumber:        r.PhoneNumber,
+		IdentityCardNumber: r.IdentityCardNumber,
+		FlightNumber:       r.ReservationFlightNumber,
+		Class:              r.Class,
+		Status:             r.Status,
+		Seat:               r.SeatLocation,
+		CheckedIn:          r.CheckedIn,
+		ReservationTime:    timestamppb.New(r.ReservationTime),
+	}
+}
+
+// seatMapMessage converts a seat map
+func seatMapMessage(m *domain.SeatMap) *airlinepb.SeatMap {
+	message := &airlinepb.SeatMap{
+		FlightNumber: m.FlightNumber,
+		Columns:      m.Columns,
+	}
+	for _, index := range m.AisleAfter {
+		message.AisleAfter = append(message.AisleAfter, int32(index))
+	}
+
+	for _, row := range m.Rows {
+		rowMessage := &airlinepb.SeatRow{
+			Number:  int32(row.Number),
+			Cabin:   row.Cabin,
+			ExitRow: row.ExitRow,
+		}
+		for _, seat := range row.Seats {
+			rowMessage.Seats = append(rowMessage.Seats, &airlinepb.Seat{
+				Number:     seat.Number,
+				Column:     seat.Column,
+				State:      seat.State,
+				Attributes: seat.Attributes,
+				Price:      seat.Price,
+			})
+		}
+		message.Rows = append(message.Rows, rowMessage)
+	}
+	return message
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"golang-airplane/internal/api/rpc/airlinepb"
+	"golang-airplane/internal/core/domain"
+	"strings"
+)
+
+// crewServer serves the CrewService
+type crewServer struct {
+	airlinepb.UnimplementedCrewServiceServer
+	*Server
+}
+
+// AssignCrew assigns the crew of a flight
+func (s *crewServer) AssignCrew(ctx context.Context, req *airlinepb.AssignCrewRequest) (*airlinepb.Flight, error) {
+	if len(req.GetCrew()) == 0 {
+		return nil, invalid("crew is required")
+	}
+
+	crew := make([]domain.Crew, 0, len(req.GetCrew()))
+	for i, member := range req.GetCrew() {
+		if !complete(member) {
+			return nil, invalid(fmt.Sprintf("crew[%d] needs a name and a position", i))
+		}
+		crew = append(crew, crewEntry(member))
+	}
+
+	if err := s.flightsOf(ctx).AssignCrew(req.GetFlightNumber(), crew); err != nil {
+		return nil, serviceError(err)
+	}
+	return s.flight(ctx, req.GetFlightNumber())
+}
+
+// AddCrewMember adds a crew member to a flight, recording the caller as the one who changed the crew; changed_by is
+// ignored
+func (s *crewServer) AddCrewMember(ctx context.Context, req *airlinepb.AddCrewMemberRequest) (*airlinepb.Flight, error) {
+	if !complete(req.GetMember()) {
+		return nil, invalid("member needs a name and a position")
+	}
+
+	if err := s.flightsOf(ctx).AddCrewMember(req.GetFlightNumber(), crewEntry(req.GetMember()), userOf(ctx).Username); err != nil {
+		return nil, serviceError(err)
+	}
+	return s.flight(ctx, req.GetFlightNumber())
+}
+
+// RemoveCrewMember removes a crew member, by registry ID or name, from a flight, recording the caller as the one who
+// changed the crew; changed_by is ignored
+func (s *crewServer) RemoveCrewMember(ctx context.Context, req *airlinepb.RemoveCrewMemberRequest) (*airlinepb.Flight, error) {
+	if strings.TrimSpace(req.GetMember()) == "" {
+		return nil, invalid("member is required")
+	}
+
+	if err := s.flightsOf(ctx).RemoveCrewMember(req.GetFlightNumber(), req.GetMember(), userOf(ctx).Username); err != nil {
+		return nil, serviceError(err)
+	}
+	return s.flight(ctx, req.GetFlightNumber())
+}
+
+// ListCrewChanges lists the crew change audit trail of a flight
+func (s *crewServer) ListCrewChanges(ctx context.Context, req *airlinepb.ListCrewChangesRequest) (*airlinepb.ListCrewChangesResponse, error) {
+	if _, err := s.flightsOf(ctx).GetFlight(req.GetFlightNumber()); err != nil {
+		return nil, serviceError(err)
+	}
+
+	changes, err := s.flightsOf(ctx).GetCrewChanges(req.GetFlightNumber())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	response := &airlinepb.ListCrewChangesResponse{}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, crewChangeMessage(change))
+	}
+	return response, nil
+}
+
+// flight returns a flight after a change to its crew
+func (s *crewServer) flight(ctx context.Context, flightNumber string) (*airlinepb.Flight, error) {
+	f, err := s.flightsOf(ctx).GetFlight(flightNumber)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return flightMessage(f), nil
+}
+
+// complete reports whether a crew member has a name and a position
+func complete(member *airlinepb.CrewMember) bool {
+	return strings.TrimSpace(member.GetName()) != "" && strings.TrimSpace(member.GetPosition()) != ""
+}
@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"golang-airplane/internal/api/rpc/airlinepb"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/utils"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// flightServer serves the FlightService
+type flightServer struct {
+	airlinepb.UnimplementedFlightServiceServer
+	*Server
+}
+
+// CreateFlight creates a flight
+func (s *flightServer) CreateFlight(ctx context.Context, req *airlinepb.CreateFlightRequest) (*airlinepb.Flight, error) {
+	departureCity, destinationCity := strings.TrimSpace(req.GetDepartureCity()), strings.TrimSpace(req.GetDestinationCity())
+	switch {
+	case !utils.IsFlightNumber(req.GetFlightNumber()):
+		return nil, invalid("flight_number must match the format Fxxxx")
+	case departureCity == "" || destinationCity == "":
+		return nil, invalid("departure_city and destination_city are required")
+	case strings.EqualFold(departureCity, destinationCity):
+		return nil, invalid("departure_city and destination_city must differ")
+	case req.GetDepartureTime() == nil || req.GetArrivalTime() == nil:
+		return nil, invalid("departure_time and arrival_time are required")
+	case req.GetCapacity() < domain.MinFlightCapacity || req.GetCapacity() > domain.MaxFlightCapacity:
+		return nil, invalid(fmt.Sprintf("capacity must be between %d and %d", domain.MinFlightCapacity, domain.MaxFlightCapacity))
+	}
+
+	departureTime, arrivalTime := req.GetDepartureTime().AsTime(), req.GetArrivalTime().AsTime()
+	if err := utils.CheckDates(departureTime, arrivalTime, time.Now()); err != nil {
+		return nil, invalid(err.Error())
+	}
+
+	f, err := s.flightsOf(ctx).AddFlight(req.GetFlightNumber(), departureCity, destinationCity, departureTime, arrivalTime, int(req.GetCapacity()))
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return flightMessage(f), nil
+}
+
+// GetFlight returns a flight
+func (s *flightServer) GetFlight(ctx context.Context, req *airlinepb.GetFlightRequest) (*airlinepb.Flight, error) {
+	f, err := s.flightsOf(ctx).GetFlight(req.GetFlightNumber())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return flightMessage(f), nil
+}
+
+// ListFlights lists all flights, newest departure first
+func (s *flightServer) ListFlights(ctx context.Context, req *airlinepb.ListFlightsRequest) (*airlinepb.ListFlightsResponse, error) {
+	page, perPage, err := pagination(req.GetPage(), req.GetPerPage())
+	if err != nil {
+		return nil, err
+	}
+
+	flights, err := s.flightsOf(ctx).ListAllFlights()
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	start, end := pageBounds(len(flights), page, perPage)
+	return &airlinepb.ListFlightsResponse{Flights: flightMessages(flights[start:end]), Total: int32(len(flights))}, nil
+}
+
+// SearchFlights lists the flights of a location on a date
+func (s *flightServer) SearchFlights(ctx context.Context, req *airlinepb.SearchFlightsRequest) (*airlinepb.ListFlightsResponse, error) {
+	page, perPage, err := pagination(req.GetPage(), req.GetPerPage())
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.GetLocation()) == "" || req.GetDate() == nil {
+		return nil, invalid("location and date are required")
+	}
+
+	flights, err := s.flightsOf(ctx).SearchFlights(strings.TrimSpace(req.GetLocation()), req.GetDate().AsTime())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	start, end := pageBounds(len(flights), page, perPage)
+	return &airlinepb.ListFlightsResponse{Flights: flightMessages(flights[start:end]), Total: int32(len(flights))}, nil
+}
+
+// UpdateFlightStatus changes the operational status of a flight
+func (s *flightServer) UpdateFlightStatus(ctx context.Context, req *airlinepb.UpdateFlightStatusRequest) (*airlinepb.Flight, error) {
+	if err := s.flightsOf(ctx).UpdateStatus(req.GetFlightNumber(), req.GetStatus()); err != nil {
+		return nil, serviceError(err)
+	}
+	return s.GetFlight(ctx, &airlinepb.GetFlightRequest{FlightNumber: req.GetFlightNumber()})
+}
+
+// WatchFlightStatus streams the status of a flight, then every change of its status, gate or times
+// until the flight departs or is cancelled
+func (s *flightServer) WatchFlightStatus(req *airlinepb.WatchFlightStatusRequest, stream airlinepb.FlightService_WatchFlightStatusServer) error {
+	var last *airlinepb.FlightStatusEvent
+
+	return s.watch(stream.Context(), req.GetFlightNumber(), func() (bool, error) {
+		f, err := s.flightsOf(stream.Context()).GetFlight(req.GetFlightNumber())
+		if err != nil {
+			return false, serviceError(err)
+		}
+
+		event := statusEvent(f)
+		if last == nil || !sameStatus(last, event) {
+			if err := stream.Send(event); err != nil {
+				return false, err
+			}
+			last = event
+		}
+		return finished(f), nil
+	})
+}
+
+// sameStatus reports whether two events show the same status, gate and times
+func sameStatus(a, b *airlinepb.FlightStatusEvent) bool {
+	return a.GetStatus() == b.GetStatus() && a.GetGate() == b.GetGate() &&
+		proto.Equal(a.GetDepartureTime(), b.GetDepartureTime()) && proto.Equal(a.GetArrivalTime(), b.GetArrivalTime())
+}
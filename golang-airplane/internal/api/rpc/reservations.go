@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"golang-airplane/internal/api/rpc/airlinepb"
+	"golang-airplane/internal/utils"
+	"strings"
+)
+
+// reservationServer serves the ReservationService
+type reservationServer struct {
+	airlinepb.UnimplementedReservationServiceServer
+	*Server
+}
+
+// BookFlight books a flight
+func (s *reservationServer) BookFlight(ctx context.Context, req *airlinepb.BookFlightRequest) (*airlinepb.Reservation, error) {
+	name, address := strings.TrimSpace(req.GetName()), strings.TrimSpace(req.GetAddress())
+
+	switch {
+	case name == "":
+		return nil, invalid("name is required")
+	case address == "":
+		return nil, invalid("address is required")
+	case req.GetPhoneNumber() <= 0 || req.GetIdentityCardNumber() <= 0:
+		return nil, invalid("phone_number and identity_card_number must be positive")
+	case !utils.IsFlightNumber(req.GetFlightNumber()):
+		return nil, invalid("flight_number must match the format Fxxxx")
+	}
+
+	reservation, err := s.reservationsOf(ctx).BookFlight(name, address, req.GetPhoneNumber(), req.GetIdentityCardNumber(),
+		req.GetFlightNumber(), req.GetClass(), req.GetSessionToken())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return reservationMessage(reservation), nil
+}
+
+// GetReservation returns a reservation
+func (s *reservationServer) GetReservation(ctx context.Context, req *airlinepb.GetReservationRequest) (*airlinepb.Reservation, error) {
+	reservation, err := s.reservationsOf(ctx).GetReservation(req.GetReservationId())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return reservationMessage(reservation), nil
+}
+
+// ListReservations lists the reservations of a flight
+func (s *reservationServer) ListReservations(ctx context.Context, req *airlinepb.ListReservationsRequest) (*airlinepb.ListReservationsResponse, error) {
+	page, perPage, err := pagination(req.GetPage(), req.GetPerPage())
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.reservationsOf(ctx).GetReservationsForFlight(req.GetFlightNumber())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	start, end := pageBounds(len(reservations), page, perPage)
+	response := &airlinepb.ListReservationsResponse{Total: int32(len(reservations))}
+	for _, reservation := range reservations[start:end] {
+		response.Reservations = append(response.Reservations, reservationMessage(reservation))
+	}
+	return response, nil
+}
+
+// CheckIn checks a reservation in, on the chosen seat or on one assigned when none is given
+func (s *reservationServer) CheckIn(ctx context.Context, req *airlinepb.CheckInRequest) (*airlinepb.Reservation, error) {
+	seat := strings.ToUpper(strings.TrimSpace(req.GetSeat()))
+	if err := s.reservationsOf(ctx).CheckIn(req.GetReservationId(), seat, req.GetSessionToken()); err != nil {
+		return nil, serviceError(err)
+	}
+	return s.GetReservation(ctx, &airlinepb.GetReservationRequest{ReservationId: req.GetReservationId()})
+}
+
+// CancelReservation cancels a reservation
+func (s *reservationServer) CancelReservation(ctx context.Context, req *airlinepb.CancelReservationRequest) (*airlinepb.Reservation, error) {
+	if err := s.reservationsOf(ctx).CancelReservation(req.GetReservationId()); err != nil {
+		return nil, serviceError(err)
+	}
+	return s.GetReservation(ctx, &airlinepb.GetReservationRequest{ReservationId: req.GetReservationId()})
+}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+	"golang-airplane/internal/api/rpc/airlinepb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// seatServer serves the SeatService
+type seatServer struct {
+	airlinepb.UnimplementedSeatServiceServer
+	*Server
+}
+
+// GetSeatMap returns the seat map of a flight as seen by a booking session
+func (s *seatServer) GetSeatMap(ctx context.Context, req *airlinepb.GetSeatMapRequest) (*airlinepb.SeatMap, error) {
+	seatMap, err := s.seats.SeatMap(req.GetFlightNumber(), req.GetSessionToken(), "")
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return seatMapMessage(seatMap), nil
+}
+
+// WatchSeatMap streams the seat map of a flight, then the whole map again whenever a seat changes
+// state, until the flight departs or is cancelled
+func (s *seatServer) WatchSeatMap(req *airlinepb.GetSeatMapRequest, stream airlinepb.SeatService_WatchSeatMapServer) error {
+	var last *airlinepb.SeatMap
+
+	return s.watch(stream.Context(), req.GetFlightNumber(), func() (bool, error) {
+		f, err := s.flightsOf(stream.Context()).GetFlight(req.GetFlightNumber())
+		if err != nil {
+			return false, serviceError(err)
+		}
+		seatMap, err := s.seats.SeatMap(req.GetFlightNumber(), req.GetSessionToken(), "")
+		if err != nil {
+			return false, serviceError(err)
+		}
+
+		message := seatMapMessage(seatMap)
+		if last == nil || !proto.Equal(last, message) {
+			if err := stream.Send(message); err != nil {
+				return false, err
+			}
+			last = message
+		}
+		return finished(f), nil
+	})
+}
@@ -0,0 +1,277 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang-airplane/internal/api/rpc/airlinepb"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/utils"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRefreshInterval is how often the streaming RPCs read again what they follow when no event woke them, for the
+// changes that publish no event such as seat holds and gate changes
+const DefaultRefreshInterval = 30 * time.Second
+
+// errorDomain names the services in the details of their errors
+const errorDomain = "airline.v1"
+
+// Server serves the gRPC services over the flight, reservation and seat services
+type Server struct {
+	services        ports.ServiceFactory
+	seats           *flight.SeatService
+	access          ports.AccessControl
+	mutex           *sync.RWMutex // The JSON storage is not transactional, so changes are made one at a time
+	events          ports.EventWatcher
+	refreshInterval time.Duration
+	logger          *log.Logger
+}
+
+// NewServer creates the gRPC services; callers authenticate as a user of access, which allows and records what they
+// change, and act through the services that services makes for them. mutex serialises changes and may be shared
+// with other APIs of the same process, or nil for a lock of its own. The streaming calls follow the events
+// published on events, which may be nil to only refresh every refreshInterval. Internal errors hidden from the
+// callers are logged to logger, if any.
+func NewServer(services ports.ServiceFactory, seats *flight.SeatService, access ports.AccessControl, mutex *sync.RWMutex,
+	events ports.EventWatcher, refreshInterval time.Duration, logger *log.Logger) *Server {
+	if mutex == nil {
+		mutex = &sync.RWMutex{}
+	}
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultRefreshInterval
+	}
+	return &Server{
+		services:        services,
+		seats:           seats,
+		access:          access,
+		mutex:           mutex,
+		events:          events,
+		refreshInterval: refreshInterval,
+		logger:          logger,
+	}
+}
+
+// Register registers the flight, reservation, seat and crew services with a gRPC server
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	airlinepb.RegisterFlightServiceServer(registrar, &flightServer{Server: s})
+	airlinepb.RegisterReservationServiceServer(registrar, &reservationServer{Server: s})
+	airlinepb.RegisterSeatServiceServer(registrar, &seatServer{Server: s})
+	airlinepb.RegisterCrewServiceServer(registrar, &crewServer{Server: s})
+}
+
+// UnaryInterceptor authenticates the calls, then lets reading calls run together and changing calls one at a time;
+// the streaming calls only read and take the lock themselves while they look for changes
+func (s *Server) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// The password is checked before taking the lock, so checking it holds no other call up
+		ctx, err := s.authenticate(ctx)
+		if err != nil {
+			s.logInternal(info.FullMethod, err)
+			return nil, err
+		}
+
+		if isRead(info.FullMethod) {
+			s.mutex.RLock()
+			defer s.mutex.RUnlock()
+		} else {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+		}
+		resp, err := handler(ctx, req)
+		s.logInternal(info.FullMethod, err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor authenticates the streaming calls and logs their internal errors
+func (s *Server) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authenticate(stream.Context())
+		if err == nil {
+			err = handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+		}
+		s.logInternal(info.FullMethod, err)
+		return err
+	}
+}
+
+// logInternal logs the error behind an internal error status
+func (s *Server) logInternal(fullMethod string, err error) {
+	var internal *internalError
+	if s.logger != nil && errors.As(err, &internal) {
+		s.logger.Printf("error serving %s: %v", fullMethod, internal.err)
+	}
+}
+
+// isRead reports whether a method, given by its full name, only reads
+func isRead(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Search"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceError returns the gRPC status for an error returned by a service, choosing the code from
+// the kind of error. Errors of no known kind, such as storage failures, are not shown to the caller.
+func serviceError(err error) error {
+	var rejection *domain.CheckInRejection
+	var broken *domain.Rejection
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrUnauthorized):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.As(err, &rejection):
+		return withReason(codes.FailedPrecondition, string(rejection.Reason), rejection.Message)
+	case errors.Is(err, flight.ErrNoSeatsAvailable):
+		return withReason(codes.ResourceExhausted, "no_seats_available", err.Error())
+	case errors.Is(err, flight.ErrDeniedBoarding):
+		return withReason(codes.FailedPrecondition, "denied_boarding", err.Error())
+	case errors.As(err, &broken):
+		return withReason(codes.FailedPrecondition, "rejected", err.Error())
+	default:
+		return &internalError{err: err}
+	}
+}
+
+// internalError is the internal error status of a service error, keeping the error for the log
+type internalError struct {
+	err error
+}
+
+// Error returns the message shown to the caller
+func (e *internalError) Error() string {
+	return "internal error"
+}
+
+// GRPCStatus returns the internal error status
+func (e *internalError) GRPCStatus() *status.Status {
+	return status.New(codes.Internal, e.Error())
+}
+
+// withReason returns a status carrying the machine-readable reason of an error, the same codes the
+// HTTP API reports
+func withReason(code codes.Code, reason, message string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// invalid returns the status of a request with an invalid argument
+func invalid(message string) error {
+	return status.Error(codes.InvalidArgument, message)
+}
+
+// pagination checks the page and per_page fields of a request, where zero means the default
+func pagination(page, perPage int32) (int, int, error) {
+	if page < 0 {
+		return 0, 0, invalid("page must not be negative")
+	}
+	if perPage < 0 || perPage > utils.MaxPerPage {
+		return 0, 0, invalid(fmt.Sprintf("per_page must be between 0 and %d", utils.MaxPerPage))
+	}
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = utils.DefaultPerPage
+	}
+	return int(page), int(perPage), nil
+}
+
+// pageBounds returns the bounds of one page of a list of total items
+func pageBounds(total, page, perPage int) (int, int) {
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// watch calls poll right away, then whenever an event of the flight is published and at every refresh interval,
+// holding the read lock, until poll reports it is done or the stream ends
+func (s *Server) watch(ctx context.Context, flightNumber string, poll func() (bool, error)) error {
+	// Follow the events before the first poll, so no change falls in between
+	var events <-chan *domain.EventMessage
+	if s.events != nil {
+		var stop func()
+		events, stop = s.events.Watch()
+		defer stop()
+	}
+
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		s.mutex.RLock()
+		done, err := poll()
+		s.mutex.RUnlock()
+		if err != nil || done {
+			return err
+		}
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case message := <-events:
+				if eventFlight(message) == flightNumber {
+					break wait
+				}
+			case <-ticker.C:
+				break wait
+			}
+		}
+	}
+}
+
+// eventFlight returns the flight number of the flight an event is about
+func eventFlight(message *domain.EventMessage) string {
+	switch event := message.Event.(type) {
+	case *domain.FlightCreated:
+		return event.FlightNumber
+	case *domain.FlightStatusChanged:
+		return event.FlightNumber
+	case *domain.ReservationBooked:
+		return event.FlightNumber
+	case *domain.CheckedIn:
+		return event.FlightNumber
+	case *domain.CrewAssigned:
+		return event.FlightNumber
+	case *domain.SeatReleased:
+		return event.FlightNumber
+	}
+	return ""
+}
+
+// finished reports whether a flight reached a status that no longer changes
+func finished(f *domain.Flight) bool {
+	current := f.CurrentStatus()
+	return current == domain.FlightDeparted || current == domain.FlightCancelled
+}
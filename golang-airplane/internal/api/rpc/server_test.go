@@ -0,0 +1,347 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"golang-airplane/internal/api/rpc"
+	"golang-airplane/internal/api/rpc/airlinepb"
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/components/events"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/storage/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testPassword is the password of every test user account
+const testPassword = "test password"
+
+// testServices are the services behind a test server
+type testServices struct {
+	flights      ports.FlightService
+	reservations ports.ReservationService
+	seats        *flight.SeatService
+	access       *auth.Service
+	events       *events.Bus
+}
+
+// newTestServices builds the services over empty JSON storage, publishing to an event bus, with an account named
+// after each role
+func newTestServices(t *testing.T) *testServices {
+	t.Helper()
+
+	storage := json.NewStorage(t.TempDir())
+	flightRepo := json.NewFlightRepository(storage)
+	reservationRepo := json.NewReservationRepository(storage)
+	airplaneRepo := json.NewAirplaneRepository(storage)
+	holds := flight.NewHoldService(flightRepo, json.NewSeatHoldRepository(storage), flight.DefaultHoldTTL)
+	bus := events.NewBus(json.NewOutboxRepository(storage), storage, events.DefaultRetention)
+
+	access := auth.NewService(json.NewUserRepository(storage), json.NewActivityRepository(storage))
+	admin, err := access.CreateUser(nil, domain.RoleAdmin, testPassword, []string{domain.RoleAdmin})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	for _, role := range []string{domain.RoleSalesAgent, domain.RoleCrewPlanner} {
+		if _, err := access.CreateUser(admin, role, testPassword, []string{role}); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+
+	overbooking := flight.NewOverbookingService(flightRepo, reservationRepo, json.NewOverbookingPolicyRepository(storage))
+	checkInRules := flight.NewCheckInRules(json.NewCheckInPolicyRepository(storage))
+	return &testServices{
+		flights:      flight.NewService(flightRepo, reservationRepo, json.NewCrewChangeRepository(storage), airplaneRepo, holds, bus),
+		reservations: flight.NewReservationService(flightRepo, reservationRepo, overbooking, holds, checkInRules, bus),
+		seats:        flight.NewSeatService(flightRepo, reservationRepo, holds),
+		access:       access,
+		events:       bus,
+	}
+}
+
+// basicAuth sends the basic auth credentials of a user account with every call
+type basicAuth struct {
+	username, password string
+}
+
+// GetRequestMetadata returns the authorization metadata of the credentials
+func (b basicAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	credentials := base64.StdEncoding.EncodeToString([]byte(b.username + ":" + b.password))
+	return map[string]string{"authorization": "Basic " + credentials}, nil
+}
+
+// RequireTransportSecurity lets the credentials go over the in-memory connection
+func (b basicAuth) RequireTransportSecurity() bool {
+	return false
+}
+
+// testServer serves the gRPC services in memory. The streams only refresh once an hour, so the changes they
+// see come from the event bus.
+func testServer(t *testing.T, services *testServices) *bufconn.Listener {
+	t.Helper()
+
+	servicesAs := func(actor string) ports.Services {
+		return ports.Services{Flights: services.flights, Reservations: services.reservations}
+	}
+	server := rpc.NewServer(servicesAs, services.seats, services.access, nil, services.events, time.Hour, nil)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(server.UnaryInterceptor()),
+		grpc.StreamInterceptor(server.StreamInterceptor()))
+	server.Register(grpcServer)
+
+	listener := bufconn.Listen(1 << 20)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	return listener
+}
+
+// dialAs returns a connection to a test server calling it as a user, or anonymously for an empty username
+func dialAs(t *testing.T, listener *bufconn.Listener, username string) *grpc.ClientConn {
+	t.Helper()
+
+	options := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if username != "" {
+		options = append(options, grpc.WithPerRPCCredentials(basicAuth{username: username, password: testPassword}))
+	}
+	conn, err := grpc.Dial("bufnet", options...)
+	if err != nil {
+		t.Fatalf("failed to dial the test server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// dial serves the gRPC services in memory and returns a connection to them as an administrator
+func dial(t *testing.T, services *testServices) *grpc.ClientConn {
+	t.Helper()
+	return dialAs(t, testServer(t, services), domain.RoleAdmin)
+}
+
+// createFlight creates a flight departing tomorrow
+func createFlight(t *testing.T, flights airlinepb.FlightServiceClient, flightNumber string) *airlinepb.Flight {
+	t.Helper()
+
+	departure := time.Now().Add(24 * time.Hour)
+	created, err := flights.CreateFlight(context.Background(), &airlinepb.CreateFlightRequest{
+		FlightNumber:    flightNumber,
+		DepartureCity:   "Hanoi",
+		DestinationCity: "Saigon",
+		DepartureTime:   timestamppb.New(departure),
+		ArrivalTime:     timestamppb.New(departure.Add(2 * time.Hour)),
+		Capacity:        40,
+	})
+	if err != nil {
+		t.Fatalf("CreateFlight: %v", err)
+	}
+	return created
+}
+
+// wantCode fails the test unless err has the given status code
+func wantCode(t *testing.T, call string, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Errorf("%s returned %v, want %s", call, err, code)
+	}
+}
+
+func TestFlights(t *testing.T) {
+	flights := airlinepb.NewFlightServiceClient(dial(t, newTestServices(t)))
+	ctx := context.Background()
+
+	created := createFlight(t, flights, "F1001")
+	if created.GetFlightNumber() != "F1001" || created.GetCapacity() != 40 || created.GetAvailableSeats() != 40 {
+		t.Errorf("CreateFlight returned %v", created)
+	}
+
+	got, err := flights.GetFlight(ctx, &airlinepb.GetFlightRequest{FlightNumber: "F1001"})
+	if err != nil {
+		t.Fatalf("GetFlight: %v", err)
+	}
+	if got.GetDepartureCity() != "Hanoi" || !got.GetDepartureTime().AsTime().Equal(created.GetDepartureTime().AsTime()) {
+		t.Errorf("GetFlight returned %v, want the created flight", got)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	flights := airlinepb.NewFlightServiceClient(dial(t, newTestServices(t)))
+	ctx := context.Background()
+	for _, flightNumber := range []string{"F1001", "F1002", "F1003"} {
+		createFlight(t, flights, flightNumber)
+	}
+
+	page, err := flights.ListFlights(ctx, &airlinepb.ListFlightsRequest{Page: 2, PerPage: 2})
+	if err != nil {
+		t.Fatalf("ListFlights: %v", err)
+	}
+	if page.GetTotal() != 3 || len(page.GetFlights()) != 1 {
+		t.Errorf("ListFlights returned %d of %d flights on page 2, want 1 of 3", len(page.GetFlights()), page.GetTotal())
+	}
+
+	all, err := flights.ListFlights(ctx, &airlinepb.ListFlightsRequest{})
+	if err != nil {
+		t.Fatalf("ListFlights: %v", err)
+	}
+	if len(all.GetFlights()) != 3 {
+		t.Errorf("ListFlights returned %d flights on the default page, want 3", len(all.GetFlights()))
+	}
+
+	_, err = flights.ListFlights(ctx, &airlinepb.ListFlightsRequest{PerPage: 101})
+	wantCode(t, "ListFlights of 101 per page", err, codes.InvalidArgument)
+}
+
+func TestErrorCodes(t *testing.T) {
+	flights := airlinepb.NewFlightServiceClient(dial(t, newTestServices(t)))
+	ctx := context.Background()
+	createFlight(t, flights, "F1001")
+
+	_, err := flights.GetFlight(ctx, &airlinepb.GetFlightRequest{FlightNumber: "F9999"})
+	wantCode(t, "GetFlight of an unknown flight", err, codes.NotFound)
+
+	departure := time.Now().Add(24 * time.Hour)
+	_, err = flights.CreateFlight(ctx, &airlinepb.CreateFlightRequest{
+		FlightNumber:    "F1001",
+		DepartureCity:   "Hanoi",
+		DestinationCity: "Hue",
+		DepartureTime:   timestamppb.New(departure),
+		ArrivalTime:     timestamppb.New(departure.Add(2 * time.Hour)),
+		Capacity:        40,
+	})
+	wantCode(t, "CreateFlight of an existing flight", err, codes.AlreadyExists)
+
+	_, err = flights.CreateFlight(ctx, &airlinepb.CreateFlightRequest{FlightNumber: "bad"})
+	wantCode(t, "CreateFlight of an invalid flight", err, codes.InvalidArgument)
+
+	_, err = flights.UpdateFlightStatus(ctx, &airlinepb.UpdateFlightStatusRequest{FlightNumber: "F1001", Status: "Lost"})
+	wantCode(t, "UpdateFlightStatus to an unknown status", err, codes.FailedPrecondition)
+}
+
+// brokenFlights is a flight service whose storage fails
+type brokenFlights struct {
+	ports.FlightService
+}
+
+// GetFlight fails as storage would
+func (brokenFlights) GetFlight(flightNumber string) (*domain.Flight, error) {
+	return nil, errors.New("failed to read flights.json: input/output error")
+}
+
+func TestInternalErrors(t *testing.T) {
+	services := newTestServices(t)
+	services.flights = brokenFlights{services.flights}
+	flights := airlinepb.NewFlightServiceClient(dial(t, services))
+
+	_, err := flights.GetFlight(context.Background(), &airlinepb.GetFlightRequest{FlightNumber: "F1001"})
+	wantCode(t, "GetFlight over failing storage", err, codes.Internal)
+	if message := status.Convert(err).Message(); message != "internal error" {
+		t.Errorf("GetFlight over failing storage showed %q to the caller", message)
+	}
+}
+
+func TestWatchFlightStatus(t *testing.T) {
+	flights := airlinepb.NewFlightServiceClient(dial(t, newTestServices(t)))
+	createFlight(t, flights, "F1001")
+	createFlight(t, flights, "F1002")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := flights.WatchFlightStatus(ctx, &airlinepb.WatchFlightStatusRequest{FlightNumber: "F1001"})
+	if err != nil {
+		t.Fatalf("WatchFlightStatus: %v", err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("receiving the current status: %v", err)
+	}
+	if first.GetStatus() != domain.FlightScheduled {
+		t.Errorf("first status is %q, want %q", first.GetStatus(), domain.FlightScheduled)
+	}
+
+	// A change of another flight must not be streamed
+	for _, update := range []*airlinepb.UpdateFlightStatusRequest{
+		{FlightNumber: "F1002", Status: domain.FlightDelayed},
+		{FlightNumber: "F1001", Status: domain.FlightCancelled},
+	} {
+		if _, err := flights.UpdateFlightStatus(context.Background(), update); err != nil {
+			t.Fatalf("UpdateFlightStatus: %v", err)
+		}
+	}
+
+	next, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("receiving the status change: %v", err)
+	}
+	if next.GetFlightNumber() != "F1001" || next.GetStatus() != domain.FlightCancelled {
+		t.Errorf("streamed %v, want F1001 cancelled", next)
+	}
+
+	// A cancelled flight no longer changes, so the stream ends
+	if _, err := stream.Recv(); err == nil {
+		t.Errorf("the stream went on after the flight was cancelled")
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	listener := testServer(t, newTestServices(t))
+	ctx := context.Background()
+
+	anonymous := airlinepb.NewFlightServiceClient(dialAs(t, listener, ""))
+	_, err := anonymous.ListFlights(ctx, &airlinepb.ListFlightsRequest{})
+	wantCode(t, "ListFlights without credentials", err, codes.Unauthenticated)
+
+	stream, err := anonymous.WatchFlightStatus(ctx, &airlinepb.WatchFlightStatusRequest{FlightNumber: "F1001"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	wantCode(t, "WatchFlightStatus without credentials", err, codes.Unauthenticated)
+
+	agent := airlinepb.NewFlightServiceClient(dialAs(t, listener, domain.RoleSalesAgent))
+	departure := time.Now().Add(24 * time.Hour)
+	_, err = agent.CreateFlight(ctx, &airlinepb.CreateFlightRequest{
+		FlightNumber:    "F1001",
+		DepartureCity:   "Hanoi",
+		DestinationCity: "Saigon",
+		DepartureTime:   timestamppb.New(departure),
+		ArrivalTime:     timestamppb.New(departure.Add(2 * time.Hour)),
+		Capacity:        40,
+	})
+	wantCode(t, "CreateFlight as a sales agent", err, codes.PermissionDenied)
+
+	createFlight(t, airlinepb.NewFlightServiceClient(dialAs(t, listener, domain.RoleAdmin)), "F1001")
+	crew := airlinepb.NewCrewServiceClient(dialAs(t, listener, domain.RoleCrewPlanner))
+	_, err = crew.AssignCrew(ctx, &airlinepb.AssignCrewRequest{FlightNumber: "F1001", Crew: []*airlinepb.CrewMember{
+		{Name: "Tran Minh", Position: domain.PositionPilot},
+		{Name: "Le Hoa", Position: domain.PositionAttendant},
+		{Name: "Pham Nam", Position: domain.PositionGroundStaff},
+	}})
+	if err != nil {
+		t.Fatalf("AssignCrew as a crew planner: %v", err)
+	}
+	_, err = crew.AddCrewMember(ctx, &airlinepb.AddCrewMemberRequest{FlightNumber: "F1001",
+		Member: &airlinepb.CrewMember{Name: "Vo Lan", Position: domain.PositionAttendant}, ChangedBy: "someone else"})
+	if err != nil {
+		t.Fatalf("AddCrewMember as a crew planner: %v", err)
+	}
+
+	changes, err := crew.ListCrewChanges(ctx, &airlinepb.ListCrewChangesRequest{FlightNumber: "F1001"})
+	if err != nil {
+		t.Fatalf("ListCrewChanges: %v", err)
+	}
+	if len(changes.GetChanges()) != 1 || changes.GetChanges()[0].GetChangedBy() != domain.RoleCrewPlanner {
+		t.Errorf("ListCrewChanges returned %v, want the change recorded as made by the crew planner", changes.GetChanges())
+	}
+}
@@ -1,7 +1,7 @@
 package airplane
 
 import (
-	"errors"
+	"fmt"
 	"golang-airplane/internal/core/domain"
 	"golang-airplane/internal/core/ports"
 )
@@ -51,7 +51,7 @@ func (r *AirplaneRepositoryImpl) FindByID(id string) (domain.Airplane, error) {
 	
 	airplane, exists := airplanesMap[id]
 	if !exists {
-		return domain.Airplane{}, errors.New("airplane not found")
+		return domain.Airplane{}, fmt.Errorf("airplane %w", domain.ErrNotFound)
 	}
 	
 	return *airplane, nil
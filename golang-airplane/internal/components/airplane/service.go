@@ -1,25 +1,32 @@
 package airplane
 
 import (
-	"errors"
+	"fmt"
 	"golang-airplane/internal/core/domain"
 	"golang-airplane/internal/core/ports"
+	"sort"
+	"time"
 )
 
 // AirplaneService implements the service for airplane operations
 type AirplaneService struct {
-	repo ports.AirplaneRepository
+	repo       ports.AirplaneRepository
+	flightRepo ports.FlightRepository
 }
 
 // NewAirplaneService creates a new airplane service
-func NewAirplaneService(repo ports.AirplaneRepository) *AirplaneService {
-	return &AirplaneService{repo: repo}
+func NewAirplaneService(repo ports.AirplaneRepository, flightRepo ports.FlightRepository) *AirplaneService {
+	return &AirplaneService{repo: repo, flightRepo: flightRepo}
 }
 
 // AddAirplane creates and stores a new airplane
 func (s *AirplaneService) AddAirplane(id string, model string, capacity int) error {
 	if model == "" || capacity <= 0 {
-		return errors.New("invalid airplane data: model cannot be empty and capacity must be positive")
+		return domain.Rejectf("invalid airplane data: model cannot be empty and capacity must be positive")
+	}
+	
+	if _, err := s.repo.FindByID(id); err == nil {
+		return fmt.Errorf("airplane with ID %s %w", id, domain.ErrAlreadyExists)
 	}
 	
 	airplane := domain.Airplane{
@@ -39,4 +46,145 @@ func (s *AirplaneService) GetAirplanes() ([]domain.Airplane, error) {
 // GetAirplaneByID retrieves an airplane by its ID
 func (s *AirplaneService) GetAirplaneByID(id string) (domain.Airplane, error) {
 	return s.repo.FindByID(id)
-}
\ No newline at end of file
+}
+
+// ScheduleMaintenance books a maintenance event for an airplane and returns the flights it affects
+func (s *AirplaneService) ScheduleMaintenance(airplaneID, eventType string, start, end time.Time, location string) (*domain.MaintenanceEvent, []*domain.Flight, error) {
+	switch eventType {
+	case domain.MaintenanceACheck, domain.MaintenanceCCheck, domain.MaintenanceAOG:
+	default:
+		return nil, nil, domain.Rejectf("unknown maintenance type %q", eventType)
+	}
+
+	if !end.After(start) {
+		return nil, nil, domain.Rejectf("maintenance end must be after its start")
+	}
+
+	if location == "" {
+		return nil, nil, domain.Rejectf("maintenance location cannot be empty")
+	}
+
+	airplane, err := s.repo.FindByID(airplaneID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	event := domain.MaintenanceEvent{
+		ID:       fmt.Sprintf("%s-M%03d", airplane.ID, len(airplane.Maintenance)+1),
+		Type:     eventType,
+		Start:    start,
+		End:      end,
+		Location: location,
+	}
+	airplane.Maintenance = append(airplane.Maintenance, event)
+
+	err = s.repo.Save(airplane)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to save airplane: %w", err)
+	}
+
+	// Find the flights that now need another airplane
+	flights, err := s.flightRepo.FindAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var affected []*domain.Flight
+	for _, flight := range flights {
+		if flight.AirplaneID == airplaneID && event.Overlaps(flight.DepartureTime, flight.ArrivalTime) {
+			affected = append(affected, flight)
+		}
+	}
+
+	return &event, affected, nil
+}
+
+// MaintenanceReport lists the checks of every airplane with the hours and cycles flown since they were last done
+func (s *AirplaneService) MaintenanceReport() ([]domain.CheckStatus, error) {
+	airplanes, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	flights, err := s.flightRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var report []domain.CheckStatus
+
+	for _, airplane := range airplanes {
+		for level, interval := range domain.DefaultMaintenanceProgram {
+			status := domain.CheckStatus{
+				AirplaneID: airplane.ID,
+				Model:      airplane.Model,
+				CheckType:  interval.Type,
+			}
+
+			// A heavier check also resets the lighter ones
+			for i := range airplane.Maintenance {
+				event := &airplane.Maintenance[i]
+				eventLevel := checkLevel(event.Type)
+				if eventLevel < level {
+					continue
+				}
+
+				if !event.End.After(now) {
+					if event.End.After(status.LastDone) {
+						status.LastDone = event.End
+					}
+				} else if event.Type == interval.Type && (status.Scheduled == nil || event.Start.Before(status.Scheduled.Start)) {
+					status.Scheduled = event
+				}
+			}
+
+			// Accumulate the completed flights since the check was last done
+			for _, flight := range flights {
+				if flight.AirplaneID != airplane.ID || flight.ArrivalTime.After(now) || flight.DepartureTime.Before(status.LastDone) {
+					continue
+				}
+				status.HoursSince += flight.ArrivalTime.Sub(flight.DepartureTime).Hours()
+				status.CyclesSince++
+			}
+
+			status.HoursRemaining = interval.FlightHours - status.HoursSince
+			status.CyclesRemaining = interval.Cycles - status.CyclesSince
+			status.DueSoon = remainingShare(status) < 0.1
+			report = append(report, status)
+		}
+	}
+
+	// Most urgent checks first
+	sort.Slice(report, func(i, j int) bool {
+		return remainingShare(report[i]) < remainingShare(report[j])
+	})
+
+	return report, nil
+}
+
+// checkLevel returns the position of a check type in the maintenance program, or -1 for unscheduled events
+func checkLevel(eventType string) int {
+	for level, interval := range domain.DefaultMaintenanceProgram {
+		if interval.Type == eventType {
+			return level
+		}
+	}
+	return -1
+}
+
+// remainingShare returns the fraction of the check interval left before the nearest limit
+func remainingShare(status domain.CheckStatus) float64 {
+	for _, interval := range domain.DefaultMaintenanceProgram {
+		if interval.Type != status.CheckType {
+			continue
+		}
+		hours := status.HoursRemaining / interval.FlightHours
+		cycles := float64(status.CyclesRemaining) / float64(interval.Cycles)
+		if cycles < hours {
+			return cycles
+		}
+		return hours
+	}
+	return 1
+}
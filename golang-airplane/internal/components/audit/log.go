@@ -0,0 +1,279 @@
+// Package audit keeps an append-only, hash-chained log of every change to flights, reservations, airplanes and
+// the crew registry, recorded by decorators around their repositories
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SystemActor is the default actor of the binaries, for the changes not made by a logged-in user
+const SystemActor = "system"
+
+// ChainError reports the first entry of the log that breaks the hash chain
+type ChainError struct {
+	Sequence int // Position of the entry, from 1
+	Reason   string
+}
+
+// Error returns the entry and the reason
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit log entry %d: %s", e.Sequence, e.Reason)
+}
+
+// Recorder records changes in the audit log as made by someone
+type Recorder interface {
+	// Record appends a change of an entity; before is nil for a created entity, and updates changing nothing are
+	// not recorded
+	Record(entityType, entityID, operation string, before, after interface{}) error
+}
+
+// Log records changes in the audit log. The end of the chain is kept in memory once read, so the log must only be
+// written by one process at a time, as the rest of the JSON storage.
+type Log struct {
+	repo         ports.AuditRepository
+	mutex        sync.Mutex // Guards the fields below and serialises appends, so each entry chains to the last
+	defaultActor string     // Actor of the changes recorded through the log itself rather than As
+	loaded       bool       // Whether sequence and head hold the end of the chain
+	sequence     int        // Sequence number of the last entry
+	head         string     // Hash of the last entry
+}
+
+// NewLog creates an audit log; changes recorded through it are made by defaultActor, and those recorded through
+// As by the actor given
+func NewLog(repo ports.AuditRepository, defaultActor string) *Log {
+	return &Log{
+		repo:         repo,
+		defaultActor: defaultActor,
+	}
+}
+
+// SetDefaultActor sets who the changes recorded through the log itself are made by, for processes acting for a single
+// user such as a command-line session
+func (l *Log) SetDefaultActor(actor string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.defaultActor = actor
+}
+
+// As returns a recorder of the changes made by an actor, for the repositories of the services acting for them
+func (l *Log) As(actor string) Recorder {
+	return &actorRecorder{log: l, actor: actor}
+}
+
+// actorRecorder records changes in a log as made by one actor
+type actorRecorder struct {
+	log   *Log
+	actor string
+}
+
+// Record appends a change of an entity made by the actor
+func (r *actorRecorder) Record(entityType, entityID, operation string, before, after interface{}) error {
+	return r.log.record(r.actor, entityType, entityID, operation, before, after)
+}
+
+// Record appends a change of an entity made by the default actor; before is nil for a created entity, and updates
+// changing nothing are not recorded
+func (l *Log) Record(entityType, entityID, operation string, before, after interface{}) error {
+	return l.record("", entityType, entityID, operation, before, after)
+}
+
+// record appends a change of an entity made by an actor, or by the default actor when empty
+func (l *Log) record(actor, entityType, entityID, operation string, before, after interface{}) error {
+	changes, err := diff(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to compare %s %s: %w", entityType, entityID, err)
+	}
+	if operation == domain.AuditUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if actor == "" {
+		actor = l.defaultActor
+	}
+	for retried := false; ; retried = true {
+		if !l.loaded {
+			entries, err := l.repo.FindAll()
+			if err != nil {
+				return err
+			}
+			l.sequence, l.head = 0, ""
+			if len(entries) > 0 {
+				l.sequence, l.head = len(entries), entries[len(entries)-1].Hash
+			}
+			l.loaded = true
+		}
+
+		entry := &domain.AuditEntry{
+			Sequence:   l.sequence + 1,
+			At:         time.Now().UTC(),
+			Actor:      actor,
+			EntityType: entityType,
+			EntityID:   entityID,
+			Operation:  operation,
+			Changes:    changes,
+			PrevHash:   l.head,
+		}
+		entry.Hash, err = hash(entry)
+		if err != nil {
+			return err
+		}
+
+		err = l.repo.Append(entry)
+		if err == nil {
+			l.sequence, l.head = entry.Sequence, entry.Hash
+			return nil
+		}
+		// The entry may or may not have been written, so read the end of the chain again next time; an end that moved,
+		// such as an entry dropped with a failed storage step, is read again now
+		l.loaded = false
+		if retried || !errors.Is(err, domain.ErrConflict) {
+			return err
+		}
+	}
+}
+
+// Query returns the entries selected by a filter, oldest first
+func (l *Log) Query(filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	entries, err := l.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []*domain.AuditEntry
+	for _, entry := range entries {
+		if filter.Matches(entry) {
+			matching = append(matching, entry)
+		}
+	}
+	return matching, nil
+}
+
+// Verify checks the hash chain of the whole log and returns the number of entries and the hash of the last one,
+// which can be kept elsewhere to notice the log being truncated or rewritten; a broken chain is a *ChainError
+func (l *Log) Verify() (int, string, error) {
+	entries, err := l.repo.FindAll()
+	if err != nil {
+		return 0, "", err
+	}
+
+	prevHash := ""
+	for i, entry := range entries {
+		switch {
+		case entry.Sequence != i+1:
+			return i, prevHash, &ChainError{Sequence: i + 1, Reason: fmt.Sprintf("has sequence number %d", entry.Sequence)}
+		case entry.PrevHash != prevHash:
+			return i, prevHash, &ChainError{Sequence: i + 1, Reason: "does not follow the previous entry"}
+		}
+
+		expected, err := hash(entry)
+		if err != nil {
+			return i, prevHash, err
+		}
+		if entry.Hash != expected {
+			return i, prevHash, &ChainError{Sequence: i + 1, Reason: "does not match its hash"}
+		}
+		prevHash = entry.Hash
+	}
+	return len(entries), prevHash, nil
+}
+
+// hash returns the SHA-256 of an entry without its hash
+func hash(entry *domain.AuditEntry) (string, error) {
+	unhashed := *entry
+	unhashed.Hash = ""
+	data, err := json.Marshal(unhashed)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash audit entry: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// diff returns the fields that differ between two versions of an entity, by path, in order
+func diff(before, after interface{}) ([]domain.FieldChange, error) {
+	beforeFields, err := flatten(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := flatten(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for field := range beforeFields {
+		fields = append(fields, field)
+	}
+	for field := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	var changes []domain.FieldChange
+	for _, field := range fields {
+		beforeValue, afterValue := beforeFields[field], afterFields[field]
+		if !bytes.Equal(beforeValue, afterValue) {
+			changes = append(changes, domain.FieldChange{Field: field, Before: beforeValue, After: afterValue})
+		}
+	}
+	return changes, nil
+}
+
+// flatten returns the JSON values of an entity by field path, descending into objects; nil has no fields
+func flatten(entity interface{}) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if entity == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // Keeps large numbers such as phone numbers exact
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return fields, flattenValue("", value, fields)
+}
+
+// flattenValue adds a value at a path, or the fields of an object below it
+func flattenValue(path string, value interface{}, fields map[string]json.RawMessage) error {
+	if object, ok := value.(map[string]interface{}); ok {
+		for key, field := range object {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			if err := flattenValue(fieldPath, field, fields); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fields[path] = data
+	return nil
+}
@@ -0,0 +1,101 @@
+package audit_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang-airplane/internal/components/audit"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/storage/json"
+)
+
+// record creates an airplane named after the actor and a number through a recorder
+func record(t *testing.T, recorder audit.Recorder, actor string, i int) {
+	t.Helper()
+	airplane := domain.Airplane{ID: fmt.Sprintf("%s-%d", actor, i), Model: "A320", Capacity: 180}
+	if err := recorder.Record(domain.AuditAirplane, airplane.ID, domain.AuditCreate, nil, airplane); err != nil {
+		t.Errorf("Record: %v", err)
+	}
+}
+
+func TestConcurrentActors(t *testing.T) {
+	log := audit.NewLog(json.NewAuditRepository(json.NewStorage(t.TempDir())), audit.SystemActor)
+
+	const perActor = 10
+	recorders := map[string]audit.Recorder{
+		"ann":             log.As("ann"),
+		"bob":             log.As("bob"),
+		audit.SystemActor: log,
+	}
+
+	var wg sync.WaitGroup
+	for actor, recorder := range recorders {
+		wg.Add(1)
+		go func(actor string, recorder audit.Recorder) {
+			defer wg.Done()
+			for i := 0; i < perActor; i++ {
+				record(t, recorder, actor, i)
+			}
+		}(actor, recorder)
+	}
+	wg.Wait()
+
+	for actor := range recorders {
+		entries, err := log.Query(domain.AuditFilter{Actor: actor})
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+		if len(entries) != perActor {
+			t.Fatalf("%s made %d changes, want %d", actor, len(entries), perActor)
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.EntityID, actor+"-") {
+				t.Errorf("change of %s recorded as made by %s", entry.EntityID, actor)
+			}
+		}
+	}
+
+	count, _, err := log.Verify()
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if want := perActor * len(recorders); count != want {
+		t.Errorf("Verify counted %d entries, want %d", count, want)
+	}
+}
+
+func TestChainContinuesAcrossLogs(t *testing.T) {
+	repo := json.NewAuditRepository(json.NewStorage(t.TempDir()))
+	for i := 0; i < 3; i++ {
+		// Each log reads the end of the chain the previous one left
+		log := audit.NewLog(repo, audit.SystemActor)
+		record(t, log, audit.SystemActor, 2*i)
+		record(t, log.As("ann"), "ann", 2*i+1)
+	}
+
+	count, _, err := audit.NewLog(repo, audit.SystemActor).Verify()
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if count != 6 {
+		t.Errorf("Verify counted %d entries, want 6", count)
+	}
+}
+
+func TestUnchangedUpdatesAreSkipped(t *testing.T) {
+	log := audit.NewLog(json.NewAuditRepository(json.NewStorage(t.TempDir())), audit.SystemActor)
+
+	airplane := domain.Airplane{ID: "A1", Model: "A320", Capacity: 180}
+	if err := log.Record(domain.AuditAirplane, airplane.ID, domain.AuditUpdate, airplane, airplane); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	entries, err := log.Query(domain.AuditFilter{})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("recorded %d entries for an update changing nothing", len(entries))
+	}
+}
@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// previous returns the stored version of an entity before a save, and whether there was one
+func previous(find func() (interface{}, error)) (interface{}, bool, error) {
+	before, err := find()
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return before, true, nil
+}
+
+// save stores an entity and records the change, as an update when a previous version exists
+func save(log Recorder, entityType, entityID string, entity interface{}, find func() (interface{}, error), store func() error) error {
+	before, exists, err := previous(find)
+	if err != nil {
+		return err
+	}
+
+	if err := store(); err != nil {
+		return err
+	}
+
+	operation := domain.AuditCreate
+	if exists {
+		operation = domain.AuditUpdate
+	}
+	if err := log.Record(entityType, entityID, operation, before, entity); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// flightRepository records the changes made through a flight repository
+type flightRepository struct {
+	ports.FlightRepository
+	log Recorder
+}
+
+// Flights returns a flight repository recording its changes through log, a *Log or the recorder of an actor
+func Flights(inner ports.FlightRepository, log Recorder) ports.FlightRepository {
+	return &flightRepository{FlightRepository: inner, log: log}
+}
+
+// Save stores a flight and records it as created or updated
+func (r *flightRepository) Save(flight *domain.Flight) error {
+	find := func() (interface{}, error) { return r.FlightRepository.FindByID(flight.FlightNumber) }
+	return save(r.log, domain.AuditFlight, flight.FlightNumber, flight, find, func() error {
+		return r.FlightRepository.Save(flight)
+	})
+}
+
+// Update updates a flight and records the change
+func (r *flightRepository) Update(flight *domain.Flight) error {
+	find := func() (interface{}, error) { return r.FlightRepository.FindByID(flight.FlightNumber) }
+	return save(r.log, domain.AuditFlight, flight.FlightNumber, flight, find, func() error {
+		return r.FlightRepository.Update(flight)
+	})
+}
+
+// reservationRepository records the changes made through a reservation repository
+type reservationRepository struct {
+	ports.ReservationRepository
+	log Recorder
+}
+
+// Reservations returns a reservation repository recording its changes through log, a *Log or the recorder of an actor
+func Reservations(inner ports.ReservationRepository, log Recorder) ports.ReservationRepository {
+	return &reservationRepository{ReservationRepository: inner, log: log}
+}
+
+// Save stores a reservation and records it as created or updated
+func (r *reservationRepository) Save(reservation *domain.Reservation) error {
+	find := func() (interface{}, error) { return r.ReservationRepository.FindByID(reservation.ReservationID) }
+	return save(r.log, domain.AuditReservation, reservation.ReservationID, reservation, find, func() error {
+		return r.ReservationRepository.Save(reservation)
+	})
+}
+
+// Update updates a reservation and records the change
+func (r *reservationRepository) Update(reservation *domain.Reservation) error {
+	find := func() (interface{}, error) { return r.ReservationRepository.FindByID(reservation.ReservationID) }
+	return save(r.log, domain.AuditReservation, reservation.ReservationID, reservation, find, func() error {
+		return r.ReservationRepository.Update(reservation)
+	})
+}
+
+// airplaneRepository records the changes made through an airplane repository
+type airplaneRepository struct {
+	ports.AirplaneRepository
+	log Recorder
+}
+
+// Airplanes returns an airplane repository recording its changes through log, a *Log or the recorder of an actor
+func Airplanes(inner ports.AirplaneRepository, log Recorder) ports.AirplaneRepository {
+	return &airplaneRepository{AirplaneRepository: inner, log: log}
+}
+
+// Save stores an airplane and records it as created or updated
+func (r *airplaneRepository) Save(airplane domain.Airplane) error {
+	find := func() (interface{}, error) { return r.AirplaneRepository.FindByID(airplane.ID) }
+	return save(r.log, domain.AuditAirplane, airplane.ID, airplane, find, func() error {
+		return r.AirplaneRepository.Save(airplane)
+	})
+}
+
+// crewRepository records the changes made through a crew registry repository
+type crewRepository struct {
+	ports.CrewRepository
+	log Recorder
+}
+
+// Crew returns a crew registry repository recording its changes through log, a *Log or the recorder of an actor
+func Crew(inner ports.CrewRepository, log Recorder) ports.CrewRepository {
+	return &crewRepository{CrewRepository: inner, log: log}
+}
+
+// Save stores a crew member and records them as created or updated
+func (r *crewRepository) Save(member *domain.CrewMember) error {
+	find := func() (interface{}, error) { return r.CrewRepository.FindByID(member.ID) }
+	return save(r.log, domain.AuditCrewMember, member.ID, member, find, func() error {
+		return r.CrewRepository.Save(member)
+	})
+}
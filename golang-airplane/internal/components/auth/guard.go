@@ -0,0 +1,308 @@
+package auth
+
+import (
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"time"
+)
+
+// guard checks the roles of the user acting through it and records the changes they make
+type guard struct {
+	user     *domain.User
+	activity *Service
+}
+
+// allow returns ErrForbidden unless the acting user has a role
+func (g guard) allow(role, action string) error {
+	return Require(g.user, role, action)
+}
+
+// change makes a change and records it as made by the acting user once it succeeded; target returns what was
+// changed
+func (g guard) change(action string, target func() string, change func() error) error {
+	if err := change(); err != nil {
+		return err
+	}
+	return g.activity.record(g.user.Username, action, target())
+}
+
+// known returns a target known before the change
+func known(target string) func() string {
+	return func() string { return target }
+}
+
+// flightGuard is a FlightService acting for a user: schedulers plan flights, crew planners edit crews and every
+// user may read
+type flightGuard struct {
+	guard
+	inner ports.FlightService
+}
+
+// GuardFlights returns the flight service as seen by a user
+func (s *Service) GuardFlights(inner ports.FlightService, user *domain.User) ports.FlightService {
+	return &flightGuard{guard: guard{user: user, activity: s}, inner: inner}
+}
+
+// AddFlight adds a new flight
+func (g *flightGuard) AddFlight(flightNumber, departureCity, destinationCity string, departureTime, arrivalTime time.Time, availableSeat int) (*domain.Flight, error) {
+	if err := g.allow(domain.RoleScheduler, "add flights"); err != nil {
+		return nil, err
+	}
+	var flight *domain.Flight
+	err := g.change("add flight", known(flightNumber), func() error {
+		var err error
+		flight, err = g.inner.AddFlight(flightNumber, departureCity, destinationCity, departureTime, arrivalTime, availableSeat)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return flight, nil
+}
+
+// GetFlight retrieves a flight by its flight number
+func (g *flightGuard) GetFlight(flightNumber string) (*domain.Flight, error) {
+	return g.inner.GetFlight(flightNumber)
+}
+
+// SearchFlights searches for flights by location and date
+func (g *flightGuard) SearchFlights(location string, date time.Time) ([]*domain.Flight, error) {
+	return g.inner.SearchFlights(location, date)
+}
+
+// AssignCrew assigns crew members to a flight
+func (g *flightGuard) AssignCrew(flightNumber string, crewMembers []domain.Crew) error {
+	if err := g.allow(domain.RoleCrewPlanner, "assign crews"); err != nil {
+		return err
+	}
+	return g.change("assign crew", known(flightNumber), func() error {
+		return g.inner.AssignCrew(flightNumber, crewMembers)
+	})
+}
+
+// AddCrewMember adds a crew member to a flight, recording the acting user as the one who changed the crew
+func (g *flightGuard) AddCrewMember(flightNumber string, member domain.Crew, changedBy string) error {
+	if err := g.allow(domain.RoleCrewPlanner, "edit crews"); err != nil {
+		return err
+	}
+	return g.change("add crew member", known(flightNumber), func() error {
+		return g.inner.AddCrewMember(flightNumber, member, g.user.Username)
+	})
+}
+
+// RemoveCrewMember removes a crew member from a flight, recording the acting user as the one who changed the crew
+func (g *flightGuard) RemoveCrewMember(flightNumber, idOrName, changedBy string) error {
+	if err := g.allow(domain.RoleCrewPlanner, "edit crews"); err != nil {
+		return err
+	}
+	return g.change("remove crew member", known(flightNumber), func() error {
+		return g.inner.RemoveCrewMember(flightNumber, idOrName, g.user.Username)
+	})
+}
+
+// SwapCrewMember replaces a crew member of a flight, recording the acting user as the one who changed the crew
+func (g *flightGuard) SwapCrewMember(flightNumber, idOrName string, replacement domain.Crew, changedBy string) error {
+	if err := g.allow(domain.RoleCrewPlanner, "edit crews"); err != nil {
+		return err
+	}
+	return g.change("swap crew member", known(flightNumber), func() error {
+		return g.inner.SwapCrewMember(flightNumber, idOrName, replacement, g.user.Username)
+	})
+}
+
+// GetCrewChanges retrieves the crew change audit trail of a flight
+func (g *flightGuard) GetCrewChanges(flightNumber string) ([]*domain.CrewChange, error) {
+	return g.inner.GetCrewChanges(flightNumber)
+}
+
+// AssignAirplane assigns an airplane to a flight
+func (g *flightGuard) AssignAirplane(flightNumber, airplaneID string) error {
+	if err := g.allow(domain.RoleScheduler, "assign airplanes"); err != nil {
+		return err
+	}
+	return g.change("assign airplane", known(flightNumber), func() error {
+		return g.inner.AssignAirplane(flightNumber, airplaneID)
+	})
+}
+
+// SetFare sets the base fare of a class on a flight
+func (g *flightGuard) SetFare(flightNumber, class string, amount int64) error {
+	if err := g.allow(domain.RoleScheduler, "set fares"); err != nil {
+		return err
+	}
+	return g.change("set fare", known(flightNumber), func() error {
+		return g.inner.SetFare(flightNumber, class, amount)
+	})
+}
+
+// SetGate sets the departure gate of a flight
+func (g *flightGuard) SetGate(flightNumber, gate string) error {
+	if err := g.allow(domain.RoleScheduler, "set gates"); err != nil {
+		return err
+	}
+	return g.change("set gate", known(flightNumber), func() error {
+		return g.inner.SetGate(flightNumber, gate)
+	})
+}
+
+// UpdateStatus changes the operational status of a flight
+func (g *flightGuard) UpdateStatus(flightNumber, status string) error {
+	if err := g.allow(domain.RoleScheduler, "change flight statuses"); err != nil {
+		return err
+	}
+	return g.change("update status", known(flightNumber), func() error {
+		return g.inner.UpdateStatus(flightNumber, status)
+	})
+}
+
+// ChangeCapacity changes the number of seats of a flight
+func (g *flightGuard) ChangeCapacity(flightNumber string, capacity int) error {
+	if err := g.allow(domain.RoleScheduler, "change capacities"); err != nil {
+		return err
+	}
+	return g.change("change capacity", known(flightNumber), func() error {
+		return g.inner.ChangeCapacity(flightNumber, capacity)
+	})
+}
+
+// ListAllFlights retrieves all flights
+func (g *flightGuard) ListAllFlights() ([]*domain.Flight, error) {
+	return g.inner.ListAllFlights()
+}
+
+// reservationGuard is a ReservationService acting for a user: sales agents book and cancel, check-in agents check
+// passengers in and every user may read
+type reservationGuard struct {
+	guard
+	inner ports.ReservationService
+}
+
+// GuardReservations returns the reservation service as seen by a user
+func (s *Service) GuardReservations(inner ports.ReservationService, user *domain.User) ports.ReservationService {
+	return &reservationGuard{guard: guard{user: user, activity: s}, inner: inner}
+}
+
+// BookFlight creates a new reservation for a flight
+func (g *reservationGuard) BookFlight(name, address string, phoneNumber, identityCardNumber int64, flightNumber, class, sessionToken string) (*domain.Reservation, error) {
+	if err := g.allow(domain.RoleSalesAgent, "book flights"); err != nil {
+		return nil, err
+	}
+	var reservation *domain.Reservation
+	target := func() string { return reservation.ReservationID }
+	err := g.change("book flight", target, func() error {
+		var err error
+		reservation, err = g.inner.BookFlight(name, address, phoneNumber, identityCardNumber, flightNumber, class, sessionToken)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// HoldInventory holds seats of a flight for a booking session; holds are not recorded as they expire by themselves
+func (g *reservationGuard) HoldInventory(sessionToken, flightNumber string, quantity int) (*domain.SeatHold, error) {
+	if err := g.allow(domain.RoleSalesAgent, "hold seats"); err != nil {
+		return nil, err
+	}
+	return g.inner.HoldInventory(sessionToken, flightNumber, quantity)
+}
+
+// BookableSeats returns how many more reservations a flight accepts
+func (g *reservationGuard) BookableSeats(flightNumber string) (int, error) {
+	return g.inner.BookableSeats(flightNumber)
+}
+
+// GetReservation retrieves a reservation by its ID
+func (g *reservationGuard) GetReservation(reservationID string) (*domain.Reservation, error) {
+	return g.inner.GetReservation(reservationID)
+}
+
+// CheckIn performs the check-in process for a reservation and assigns a seat
+func (g *reservationGuard) CheckIn(reservationID, seatNumber, sessionToken string) error {
+	if err := g.allow(domain.RoleCheckInAgent, "check passengers in"); err != nil {
+		return err
+	}
+	return g.change("check in", known(reservationID), func() error {
+		return g.inner.CheckIn(reservationID, seatNumber, sessionToken)
+	})
+}
+
+// GetReservationsForFlight retrieves all reservations for a specific flight
+func (g *reservationGuard) GetReservationsForFlight(flightNumber string) ([]*domain.Reservation, error) {
+	return g.inner.GetReservationsForFlight(flightNumber)
+}
+
+// AddTravelDocument records a travel document presented by the passenger of a reservation
+func (g *reservationGuard) AddTravelDocument(reservationID string, document domain.TravelDocument) error {
+	if err := g.allow(domain.RoleCheckInAgent, "record travel documents"); err != nil {
+		return err
+	}
+	return g.change("add travel document", known(reservationID), func() error {
+		return g.inner.AddTravelDocument(reservationID, document)
+	})
+}
+
+// CancelReservation cancels a reservation and releases its seat
+func (g *reservationGuard) CancelReservation(reservationID string) error {
+	if err := g.allow(domain.RoleSalesAgent, "cancel reservations"); err != nil {
+		return err
+	}
+	return g.change("cancel reservation", known(reservationID), func() error {
+		return g.inner.CancelReservation(reservationID)
+	})
+}
+
+// airplaneGuard is an AirplaneService acting for a user: schedulers manage the fleet and every user may read
+type airplaneGuard struct {
+	guard
+	inner ports.AirplaneService
+}
+
+// GuardAirplanes returns the airplane service as seen by a user
+func (s *Service) GuardAirplanes(inner ports.AirplaneService, user *domain.User) ports.AirplaneService {
+	return &airplaneGuard{guard: guard{user: user, activity: s}, inner: inner}
+}
+
+// AddAirplane creates and stores a new airplane
+func (g *airplaneGuard) AddAirplane(id string, model string, capacity int) error {
+	if err := g.allow(domain.RoleScheduler, "add airplanes"); err != nil {
+		return err
+	}
+	return g.change("add airplane", known(id), func() error {
+		return g.inner.AddAirplane(id, model, capacity)
+	})
+}
+
+// GetAirplanes retrieves all airplanes
+func (g *airplaneGuard) GetAirplanes() ([]domain.Airplane, error) {
+	return g.inner.GetAirplanes()
+}
+
+// GetAirplaneByID retrieves an airplane by its ID
+func (g *airplaneGuard) GetAirplaneByID(id string) (domain.Airplane, error) {
+	return g.inner.GetAirplaneByID(id)
+}
+
+// ScheduleMaintenance books a maintenance event for an airplane and returns the flights it affects
+func (g *airplaneGuard) ScheduleMaintenance(airplaneID, eventType string, start, end time.Time, location string) (*domain.MaintenanceEvent, []*domain.Flight, error) {
+	if err := g.allow(domain.RoleScheduler, "schedule maintenance"); err != nil {
+		return nil, nil, err
+	}
+	var event *domain.MaintenanceEvent
+	var affected []*domain.Flight
+	err := g.change("schedule maintenance", known(airplaneID), func() error {
+		var err error
+		event, affected, err = g.inner.ScheduleMaintenance(airplaneID, eventType, start, end, location)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return event, affected, nil
+}
+
+// MaintenanceReport lists the checks of every airplane
+func (g *airplaneGuard) MaintenanceReport() ([]domain.CheckStatus, error) {
+	return g.inner.MaintenanceReport()
+}
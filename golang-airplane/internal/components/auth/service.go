@@ -0,0 +1,386 @@
+// Package auth manages the user accounts of the staff and checks what each of them may change
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength is the length passwords must have at least
+const MinPasswordLength = 8
+
+// verifiedTTL is how long a checked password is remembered, so API callers sending their credentials with every
+// request do not each wait for bcrypt
+const verifiedTTL = 5 * time.Minute
+
+// usernamePattern is the form of usernames: lowercase letters, digits, dots, dashes and underscores
+var usernamePattern = regexp.MustCompile(`^[a-z0-9._-]{3,32}$`)
+
+// dummyHash is compared against when a username is unknown, so unknown users take as long to refuse
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a password"), bcrypt.DefaultCost)
+
+// Service manages user accounts and logins
+type Service struct {
+	userRepo     ports.UserRepository
+	activityRepo ports.ActivityRepository
+	verified     map[[sha256.Size]byte]time.Time // Keys of the passwords checked lately, to when they are forgotten
+	verifyKey    []byte                          // Random key of the process the remembered passwords are keyed with
+	mutex        sync.Mutex                      // Guards verified
+}
+
+// NewService creates a new auth service instance
+func NewService(userRepo ports.UserRepository, activityRepo ports.ActivityRepository) *Service {
+	verifyKey := make([]byte, sha256.Size)
+	if _, err := rand.Read(verifyKey); err != nil {
+		panic(fmt.Sprintf("failed to generate a key: %v", err))
+	}
+	return &Service{
+		userRepo:     userRepo,
+		activityRepo: activityRepo,
+		verified:     make(map[[sha256.Size]byte]time.Time),
+		verifyKey:    verifyKey,
+	}
+}
+
+// HasUsers reports whether any user account exists; until one does, only the first administrator can be created
+func (s *Service) HasUsers() (bool, error) {
+	users, err := s.userRepo.FindAll()
+	if err != nil {
+		return false, err
+	}
+	return len(users) > 0, nil
+}
+
+// Authenticate returns the user with a username and password, or ErrUnauthorized when they do not match an
+// enabled account
+func (s *Service) Authenticate(username, password string) (*domain.User, error) {
+	user, err := s.userRepo.FindByUsername(username)
+	if errors.Is(err, domain.ErrNotFound) {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The stored hash is part of the key, so a changed password is not remembered
+	mac := hmac.New(sha256.New, s.verifyKey)
+	mac.Write([]byte(user.PasswordHash + "\x00" + password))
+	var key [sha256.Size]byte
+	copy(key[:], mac.Sum(nil))
+	if !s.remembered(key) {
+		if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+			return nil, domain.ErrUnauthorized
+		}
+		s.remember(key)
+	}
+
+	if user.Disabled {
+		return nil, domain.ErrUnauthorized
+	}
+	return user, nil
+}
+
+// remembered reports whether a password was checked lately
+func (s *Service) remembered(key [sha256.Size]byte) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return time.Now().Before(s.verified[key])
+}
+
+// remember remembers a checked password for verifiedTTL and forgets the expired ones
+func (s *Service) remember(key [sha256.Size]byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for k, expires := range s.verified {
+		if !now.Before(expires) {
+			delete(s.verified, k)
+		}
+	}
+	s.verified[key] = now.Add(verifiedTTL)
+}
+
+// ActiveUser returns the current account of a logged-in user, or ErrUnauthorized once it is disabled or removed
+func (s *Service) ActiveUser(username string) (*domain.User, error) {
+	user, err := s.userRepo.FindByUsername(username)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, domain.ErrUnauthorized
+	}
+	return user, nil
+}
+
+// CreateUser creates a user account; actor is the administrator creating it, or nil for the first account, which
+// must then be an administrator
+func (s *Service) CreateUser(actor *domain.User, username, password string, roles []string) (*domain.User, error) {
+	if actor == nil {
+		hasUsers, err := s.HasUsers()
+		if err != nil {
+			return nil, err
+		}
+		if hasUsers {
+			return nil, fmt.Errorf("log in as an administrator to create users: %w", domain.ErrUnauthorized)
+		}
+		if !containsRole(roles, domain.RoleAdmin) {
+			return nil, fmt.Errorf("the first user must be an administrator")
+		}
+	} else if err := Require(actor, domain.RoleAdmin, "create users"); err != nil {
+		return nil, err
+	}
+
+	if !usernamePattern.MatchString(username) {
+		return nil, fmt.Errorf("username must be 3 to 32 lowercase letters, digits, dots, dashes or underscores")
+	}
+	if err := validateRoles(roles); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.FindByUsername(username); err == nil {
+		return nil, fmt.Errorf("user %s %w", username, domain.ErrAlreadyExists)
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &domain.User{
+		Username:     username,
+		PasswordHash: hash,
+		Roles:        normalizeRoles(roles),
+		CreatedAt:    time.Now(),
+	}
+	err = s.userRepo.Save(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	actorName := username
+	if actor != nil {
+		actorName = actor.Username
+	}
+	if err := s.record(actorName, "create user", username); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ChangePassword sets the password of a user; users may change their own, administrators anyone's
+func (s *Service) ChangePassword(actor *domain.User, username, password string) error {
+	if actor.Username != username {
+		if err := Require(actor, domain.RoleAdmin, "change the passwords of other users"); err != nil {
+			return err
+		}
+	}
+
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash, err = hashPassword(password)
+	if err != nil {
+		return err
+	}
+	err = s.userRepo.Save(user)
+	if err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	return s.record(actor.Username, "change password", username)
+}
+
+// SetRoles replaces the roles of a user
+func (s *Service) SetRoles(actor *domain.User, username string, roles []string) error {
+	if err := Require(actor, domain.RoleAdmin, "change roles"); err != nil {
+		return err
+	}
+	if err := validateRoles(roles); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	if containsRole(user.Roles, domain.RoleAdmin) && !containsRole(roles, domain.RoleAdmin) {
+		if err := s.keepAnAdmin(username); err != nil {
+			return err
+		}
+	}
+
+	user.Roles = normalizeRoles(roles)
+	err = s.userRepo.Save(user)
+	if err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	return s.record(actor.Username, "set roles", username)
+}
+
+// SetDisabled disables or re-enables a user account; disabled users can no longer log in
+func (s *Service) SetDisabled(actor *domain.User, username string, disabled bool) error {
+	if err := Require(actor, domain.RoleAdmin, "disable users"); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	if disabled && containsRole(user.Roles, domain.RoleAdmin) {
+		if err := s.keepAnAdmin(username); err != nil {
+			return err
+		}
+	}
+
+	user.Disabled = disabled
+	err = s.userRepo.Save(user)
+	if err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	action := "enable user"
+	if disabled {
+		action = "disable user"
+	}
+	return s.record(actor.Username, action, username)
+}
+
+// ListUsers retrieves all users sorted by username
+func (s *Service) ListUsers(actor *domain.User) ([]*domain.User, error) {
+	if err := Require(actor, domain.RoleAdmin, "list users"); err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+	return users, nil
+}
+
+// ListActivity retrieves the changes made by users, oldest first
+func (s *Service) ListActivity(actor *domain.User) ([]*domain.Activity, error) {
+	if err := Require(actor, domain.RoleAdmin, "view user activity"); err != nil {
+		return nil, err
+	}
+	return s.activityRepo.FindAll()
+}
+
+// keepAnAdmin refuses to take the administrator role away from the last enabled administrator
+func (s *Service) keepAnAdmin(username string) error {
+	users, err := s.userRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if user.Username != username && !user.Disabled && containsRole(user.Roles, domain.RoleAdmin) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is the last administrator", username)
+}
+
+// record appends a change made by a user to the activity log
+func (s *Service) record(actor, action, target string) error {
+	err := s.activityRepo.Append(&domain.Activity{
+		Actor:  actor,
+		Action: action,
+		Target: target,
+		At:     time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record activity: %w", err)
+	}
+	return nil
+}
+
+// Require returns ErrForbidden unless a user has a role, for checks outside the guarded services
+func Require(user *domain.User, role, action string) error {
+	if user == nil || !user.HasRole(role) {
+		name := "anonymous user"
+		if user != nil {
+			name = user.Username
+		}
+		return fmt.Errorf("%s may not %s, which needs the %s role: %w", name, action, role, domain.ErrForbidden)
+	}
+	return nil
+}
+
+// hashPassword checks the length of a password and hashes it
+func hashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	}
+	if len(password) > 72 {
+		return "", fmt.Errorf("password must be at most 72 bytes")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// validateRoles checks that a user gets at least one role and only known ones
+func validateRoles(roles []string) error {
+	if len(roles) == 0 {
+		return fmt.Errorf("a user needs at least one role")
+	}
+	for _, role := range roles {
+		if !domain.IsRole(role) {
+			return fmt.Errorf("unknown role %q", role)
+		}
+	}
+	return nil
+}
+
+// normalizeRoles returns roles without duplicates, in the order of domain.Roles
+func normalizeRoles(roles []string) []string {
+	var normalized []string
+	for _, role := range domain.Roles {
+		if containsRole(roles, role) {
+			normalized = append(normalized, role)
+		}
+	}
+	return normalized
+}
+
+// containsRole reports whether a role is listed, without counting admin as every role
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
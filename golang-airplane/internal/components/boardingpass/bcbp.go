@@ -0,0 +1,294 @@
+package boardingpass
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang.org/x/text/unicode/norm"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// mandatoryLength is the length of the format code, leg count and mandatory items of one leg
+const mandatoryLength = 60
+
+// Passenger status codes of the mandatory items
+const (
+	StatusNotCheckedIn = "0"
+	StatusCheckedIn    = "1"
+)
+
+// BCBP holds the mandatory items of a single-leg IATA Resolution 792 bar coded boarding pass
+type BCBP struct {
+	PassengerName   string // SURNAME/GIVEN NAMES, at most 20 characters
+	ETicket         bool
+	PNR             string // Booking reference, at most 7 characters
+	From            string // IATA airport code
+	To              string // IATA airport code
+	Carrier         string // Operating carrier designator
+	FlightNumber    string // Up to four digits and an optional operational suffix
+	FlightDay       int    // Day of the year of the flight date
+	Compartment     string
+	Seat            string // Seat as printed on the pass, e.g. 12A
+	CheckInSequence int
+	PassengerStatus string
+}
+
+// Encode builds the M1 data string of the boarding pass
+func (b *BCBP) Encode() (string, error) {
+	flightNumber, suffix, err := splitFlightNumber(b.FlightNumber)
+	if err != nil {
+		return "", err
+	}
+
+	seat, err := encodeSeat(b.Seat)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(b.PNR) == 0 || len(b.PNR) > 7:
+		return "", fmt.Errorf("booking reference %q must be 1 to 7 characters", b.PNR)
+	case len(b.From) != 3 || len(b.To) != 3:
+		return "", fmt.Errorf("airport codes %q and %q must be 3 characters", b.From, b.To)
+	case len(b.Carrier) < 2 || len(b.Carrier) > 3:
+		return "", fmt.Errorf("carrier designator %q must be 2 or 3 characters", b.Carrier)
+	case b.FlightDay < 1 || b.FlightDay > 366:
+		return "", fmt.Errorf("flight day %d is not a day of the year", b.FlightDay)
+	case len(b.Compartment) != 1:
+		return "", fmt.Errorf("compartment code %q must be 1 character", b.Compartment)
+	case b.CheckInSequence < 0 || b.CheckInSequence > 9999:
+		return "", fmt.Errorf("check-in sequence %d must be between 0 and 9999", b.CheckInSequence)
+	case len(b.PassengerStatus) != 1:
+		return "", fmt.Errorf("passenger status %q must be 1 character", b.PassengerStatus)
+	}
+
+	ticket := " "
+	if b.ETicket {
+		ticket = "E"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("M1")
+	sb.WriteString(pad(b.PassengerName, 20))
+	sb.WriteString(ticket)
+	sb.WriteString(pad(b.PNR, 7))
+	sb.WriteString(b.From)
+	sb.WriteString(b.To)
+	sb.WriteString(pad(b.Carrier, 3))
+	sb.WriteString(zeroPad(flightNumber, 4))
+	sb.WriteString(pad(suffix, 1))
+	sb.WriteString(fmt.Sprintf("%03d", b.FlightDay))
+	sb.WriteString(b.Compartment)
+	sb.WriteString(seat)
+	sb.WriteString(fmt.Sprintf("%04d ", b.CheckInSequence))
+	sb.WriteString(b.PassengerStatus)
+	sb.WriteString("00") // No conditional items follow
+
+	return sb.String(), nil
+}
+
+// Decode parses the mandatory items of the first leg of an M1 data string
+func Decode(data string) (*BCBP, error) {
+	if len(data) < mandatoryLength {
+		return nil, fmt.Errorf("boarding pass data is %d characters, expected at least %d", len(data), mandatoryLength)
+	}
+	if data[0] != 'M' {
+		return nil, fmt.Errorf("unsupported boarding pass format code %q", data[0])
+	}
+	if data[1] < '1' || data[1] > '4' {
+		return nil, fmt.Errorf("invalid number of legs %q", data[1])
+	}
+
+	field := func(from, to int) string {
+		return strings.TrimSpace(data[from:to])
+	}
+
+	day, err := strconv.Atoi(field(44, 47))
+	if err != nil {
+		return nil, fmt.Errorf("invalid flight date %q", data[44:47])
+	}
+
+	sequence := 0
+	if s := field(52, 57); s != "" {
+		sequence, err = strconv.Atoi(strings.TrimRightFunc(s, unicode.IsLetter))
+		if err != nil {
+			return nil, fmt.Errorf("invalid check-in sequence %q", data[52:57])
+		}
+	}
+
+	flightNumber := strings.TrimLeft(field(39, 43), "0") + field(43, 44)
+
+	return &BCBP{
+		PassengerName:   field(2, 22),
+		ETicket:         data[22] == 'E',
+		PNR:             field(23, 30),
+		From:            field(30, 33),
+		To:              field(33, 36),
+		Carrier:         field(36, 39),
+		FlightNumber:    flightNumber,
+		FlightDay:       day,
+		Compartment:     field(47, 48),
+		Seat:            decodeSeat(field(48, 52)),
+		CheckInSequence: sequence,
+		PassengerStatus: field(57, 58),
+	}, nil
+}
+
+// PassengerName formats a passenger's name as SURNAME/GIVEN NAMES, truncated to the 20
+// characters of the mandatory items
+func PassengerName(name string) string {
+	formatted := SurnameFirst(name)
+	if len(formatted) > 20 {
+		// Cutting between two words leaves a space the padded field would not keep
+		formatted = strings.TrimRight(formatted[:20], " ")
+	}
+	return formatted
+}
+
+// SurnameFirst formats a name written given names first as SURNAME/GIVEN NAMES, in the ASCII letters of FoldName
+func SurnameFirst(name string) string {
+	words := strings.FieldsFunc(FoldName(name), func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z')
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	if len(words) == 1 {
+		return words[0]
+	}
+
+	return words[len(words)-1] + "/" + strings.Join(words[:len(words)-1], " ")
+}
+
+// foldedLetters spells the capitals that do not decompose into an ASCII letter and diacritics
+var foldedLetters = map[rune]string{
+	'Đ': "D", 'Ð': "D", 'Ø': "O", 'Ł': "L", 'Ħ': "H", 'Æ': "AE", 'Œ': "OE", 'Þ': "TH", 'ß': "SS", 'ẞ': "SS",
+}
+
+// FoldName writes a name in capitals without diacritics, as machine-readable travel documents spell it,
+// so "Nguyễn Văn Đức" becomes "NGUYEN VAN DUC"
+func FoldName(name string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(strings.ToUpper(name)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case foldedLetters[r] != "":
+			sb.WriteString(foldedLetters[r])
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// CompartmentCode returns the compartment code of a travel class
+func CompartmentCode(class string) string {
+	if class == domain.ClassBusiness {
+		return "J"
+	}
+	return "Y"
+}
+
+// airportCodes maps normalised city names to IATA airport codes
+var airportCodes = map[string]string{
+	"hanoi":       "HAN",
+	"hochiminh":   "SGN",
+	"saigon":      "SGN",
+	"danang":      "DAD",
+	"haiphong":    "HPH",
+	"nhatrang":    "CXR",
+	"phuquoc":     "PQC",
+	"hue":         "HUI",
+	"dalat":       "DLI",
+	"cantho":      "VCA",
+	"vinh":        "VII",
+	"quynhon":     "UIH",
+	"bangkok":     "BKK",
+	"singapore":   "SIN",
+	"kualalumpur": "KUL",
+	"tokyo":       "NRT",
+	"seoul":       "ICN",
+	"paris":       "CDG",
+	"london":      "LHR",
+}
+
+// AirportCode returns the IATA code of a city, falling back to the first three letters of its name
+func AirportCode(city string) string {
+	normalised := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, city)
+
+	if code, ok := airportCodes[normalised]; ok {
+		return code
+	}
+
+	code := strings.ToUpper(normalised)
+	for len(code) < 3 {
+		code += "X"
+	}
+	return code[:3]
+}
+
+// JulianDay returns the day of the year of a flight date
+func JulianDay(t time.Time) int {
+	return t.YearDay()
+}
+
+// splitFlightNumber separates the digits of a flight number such as F1234 or 123A from its suffix
+func splitFlightNumber(flightNumber string) (string, string, error) {
+	digits := strings.TrimLeftFunc(flightNumber, unicode.IsLetter)
+	suffix := ""
+	if n := len(digits); n > 0 && unicode.IsLetter(rune(digits[n-1])) {
+		digits, suffix = digits[:n-1], digits[n-1:]
+	}
+
+	if len(digits) == 0 || len(digits) > 4 {
+		return "", "", fmt.Errorf("flight number %q must have 1 to 4 digits", flightNumber)
+	}
+	if _, err := strconv.Atoi(digits); err != nil {
+		return "", "", fmt.Errorf("flight number %q must have 1 to 4 digits", flightNumber)
+	}
+
+	return digits, suffix, nil
+}
+
+// encodeSeat pads a seat such as 12A to the four characters of the mandatory items
+func encodeSeat(seat string) (string, error) {
+	if seat == "" {
+		return "    ", nil
+	}
+
+	row := strings.TrimRightFunc(seat, unicode.IsLetter)
+	letter := seat[len(row):]
+	if _, err := strconv.Atoi(row); err != nil || len(row) > 3 || len(letter) != 1 {
+		return "", fmt.Errorf("seat %q must be a row number followed by one letter", seat)
+	}
+
+	return zeroPad(row, 3) + letter, nil
+}
+
+// decodeSeat strips the leading zeros of an encoded seat
+func decodeSeat(seat string) string {
+	return strings.TrimLeft(seat, "0")
+}
+
+// pad left-justifies a value in a field of the given width
+func pad(value string, width int) string {
+	if len(value) > width {
+		return value[:width]
+	}
+	return value + strings.Repeat(" ", width-len(value))
+}
+
+// zeroPad right-justifies digits in a field of the given width with leading zeros
+func zeroPad(digits string, width int) string {
+	if len(digits) >= width {
+		return digits
+	}
+	return strings.Repeat("0", width-len(digits)) + digits
+}
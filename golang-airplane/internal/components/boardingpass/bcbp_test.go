@@ -0,0 +1,27 @@
+package boardingpass_test
+
+import (
+	"testing"
+
+	"golang-airplane/internal/components/boardingpass"
+)
+
+func TestSurnameFirst(t *testing.T) {
+	for name, want := range map[string]string{
+		"John Smith":        "SMITH/JOHN",
+		"Nguyễn Văn Đức":    "DUC/NGUYEN VAN",
+		"Søren Kierkegaard": "KIERKEGAARD/SOREN",
+		"Cher":              "CHER",
+		"":                  "",
+	} {
+		if got := boardingpass.SurnameFirst(name); got != want {
+			t.Errorf("SurnameFirst(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestPassengerName(t *testing.T) {
+	if got, want := boardingpass.PassengerName("Nguyễn Thị Minh Khai Trường"), "TRUONG/NGUYEN THI MI"; got != want {
+		t.Errorf("PassengerName = %q, want %q", got, want)
+	}
+}
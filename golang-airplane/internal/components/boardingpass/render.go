@@ -0,0 +1,130 @@
+package boardingpass
+
+import (
+	"fmt"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/pdf417"
+	"github.com/boombuler/barcode/qr"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// Symbology is a two-dimensional barcode type accepted by IATA BCBP scanners
+type Symbology string
+
+// Supported symbologies
+const (
+	SymbologyPDF417 Symbology = "pdf417"
+	SymbologyQR     Symbology = "qr"
+)
+
+// pdf417SecurityLevel is the error correction level recommended by Resolution 792
+const pdf417SecurityLevel = 2
+
+// quietZone is the number of blank modules around a rendered barcode
+const quietZone = 2
+
+// Encode renders boarding pass data as a barcode of one pixel per module
+func Encode(data string, symbology Symbology) (barcode.Barcode, error) {
+	switch symbology {
+	case SymbologyPDF417:
+		return pdf417.Encode(data, pdf417SecurityLevel)
+	case SymbologyQR:
+		return qr.Encode(data, qr.M, qr.Auto)
+	}
+	return nil, fmt.Errorf("unknown barcode symbology %q", symbology)
+}
+
+// WritePNG writes boarding pass data as a PNG barcode, each module scale pixels wide
+func WritePNG(w io.Writer, data string, symbology Symbology, scale int) error {
+	code, err := Encode(data, symbology)
+	if err != nil {
+		return err
+	}
+
+	if scale < 1 {
+		scale = 1
+	}
+	bounds := code.Bounds()
+	scaled, err := barcode.Scale(code, bounds.Dx()*scale, bounds.Dy()*scale)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, withQuietZone(scaled, quietZone*scale))
+}
+
+// ANSI renders boarding pass data as a barcode for terminals, drawing two module
+// rows per line with half-block characters in black on white
+func ANSI(data string, symbology Symbology) (string, error) {
+	code, err := Encode(data, symbology)
+	if err != nil {
+		return "", err
+	}
+
+	img := withQuietZone(code, quietZone)
+	bounds := img.Bounds()
+
+	var sb strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		sb.WriteString("\x1b[30;47m")
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			top := isDark(img.At(x, y))
+			bottom := y+1 < bounds.Max.Y && isDark(img.At(x, y+1))
+
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+
+	return sb.String(), nil
+}
+
+// paddedImage surrounds an image with a white margin
+type paddedImage struct {
+	image.Image
+	margin int
+}
+
+// withQuietZone adds the blank margin scanners need around a barcode
+func withQuietZone(img image.Image, margin int) image.Image {
+	return &paddedImage{Image: img, margin: margin}
+}
+
+// ColorModel returns the colour model of the image, 8-bit grey for compact PNG output
+func (p *paddedImage) ColorModel() color.Model {
+	return color.GrayModel
+}
+
+// Bounds returns the bounds of the image including its margin
+func (p *paddedImage) Bounds() image.Rectangle {
+	bounds := p.Image.Bounds()
+	return image.Rect(0, 0, bounds.Dx()+2*p.margin, bounds.Dy()+2*p.margin)
+}
+
+// At returns the colour of a pixel, white inside the margin
+func (p *paddedImage) At(x, y int) color.Color {
+	bounds := p.Image.Bounds()
+	inner := image.Pt(x-p.margin, y-p.margin).Add(bounds.Min)
+	if !inner.In(bounds) {
+		return color.White
+	}
+	return p.Image.At(inner.X, inner.Y)
+}
+
+// isDark reports whether a pixel is a bar rather than a space
+func isDark(c color.Color) bool {
+	return color.GrayModel.Convert(c).(color.Gray).Y < 128
+}
@@ -0,0 +1,115 @@
+package boardingpass
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"strings"
+)
+
+// DefaultCarrier is the operating carrier designator printed on boarding passes
+const DefaultCarrier = "GA"
+
+// Service issues bar coded boarding passes and reads them back at the gate
+type Service struct {
+	flightRepo      ports.FlightRepository
+	reservationRepo ports.ReservationRepository
+	carrier         string
+}
+
+// NewService creates a new boarding pass Service for the given carrier designator
+func NewService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository, carrier string) *Service {
+	return &Service{
+		flightRepo:      flightRepo,
+		reservationRepo: reservationRepo,
+		carrier:         carrier,
+	}
+}
+
+// Issue builds the bar coded boarding pass of a checked-in reservation
+func (s *Service) Issue(reservationID string) (*BCBP, error) {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if !reservation.CheckedIn || reservation.SeatLocation == "" {
+		return nil, fmt.Errorf("reservation %s is not checked in", reservationID)
+	}
+
+	flight, err := s.flightRepo.FindByID(reservation.ReservationFlightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	return &BCBP{
+		PassengerName:   PassengerName(reservation.Name),
+		ETicket:         true,
+		PNR:             reservation.ReservationID,
+		From:            AirportCode(flight.DepartureCity),
+		To:              AirportCode(flight.DestinationCity),
+		Carrier:         s.carrier,
+		FlightNumber:    flight.FlightNumber,
+		FlightDay:       JulianDay(flight.DepartureTime),
+		Compartment:     CompartmentCode(reservation.Class),
+		Seat:            reservation.SeatLocation,
+		CheckInSequence: reservation.CheckInSequence,
+		PassengerStatus: StatusCheckedIn,
+	}, nil
+}
+
+// IssueData builds the M1 data string of a checked-in reservation's boarding pass
+func (s *Service) IssueData(reservationID string) (string, error) {
+	pass, err := s.Issue(reservationID)
+	if err != nil {
+		return "", err
+	}
+	return pass.Encode()
+}
+
+// Scan decodes a scanned boarding pass and returns its reservation and flight, rejecting
+// passes that no longer match the reservation
+func (s *Service) Scan(data string) (*domain.Reservation, *domain.Flight, error) {
+	pass, err := Decode(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !strings.EqualFold(pass.Carrier, s.carrier) {
+		return nil, nil, fmt.Errorf("boarding pass is for carrier %s", pass.Carrier)
+	}
+
+	reservation, err := s.reservationRepo.FindByID(pass.PNR)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	flight, err := s.flightRepo.FindByID(reservation.ReservationFlightNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	digits, suffix, err := splitFlightNumber(flight.FlightNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case pass.FlightNumber != strings.TrimLeft(digits, "0")+suffix:
+		return nil, nil, fmt.Errorf("boarding pass is for flight %s, reservation %s is on flight %s",
+			pass.FlightNumber, reservation.ReservationID, flight.FlightNumber)
+	case pass.FlightDay != JulianDay(flight.DepartureTime):
+		return nil, nil, fmt.Errorf("boarding pass is dated day %d, flight %s departs on day %d",
+			pass.FlightDay, flight.FlightNumber, JulianDay(flight.DepartureTime))
+	case !reservation.CheckedIn || !reservation.IsConfirmed():
+		return nil, nil, fmt.Errorf("reservation %s is no longer checked in", reservation.ReservationID)
+	case pass.Seat != reservation.SeatLocation:
+		return nil, nil, fmt.Errorf("boarding pass is for seat %s, reservation %s now holds seat %s",
+			pass.Seat, reservation.ReservationID, reservation.SeatLocation)
+	case pass.PassengerName != PassengerName(reservation.Name):
+		return nil, nil, fmt.Errorf("boarding pass name %s does not match reservation %s",
+			pass.PassengerName, reservation.ReservationID)
+	}
+
+	return reservation, flight, nil
+}
@@ -0,0 +1,330 @@
+package crew
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ComplementRule defines the minimum crew for aircraft up to a given seat count
+type ComplementRule struct {
+	MaxSeats          int // Largest seat count the rule applies to
+	Pilots            int
+	SeatsPerAttendant int // One attendant is required for every started block of seats
+	GroundStaff       int
+}
+
+// DefaultComplementRules covers regional, narrow-body and wide-body aircraft
+var DefaultComplementRules = []ComplementRule{
+	{MaxSeats: 100, Pilots: 2, SeatsPerAttendant: 50, GroundStaff: 1},
+	{MaxSeats: 250, Pilots: 2, SeatsPerAttendant: 50, GroundStaff: 2},
+	{MaxSeats: math.MaxInt, Pilots: 2, SeatsPerAttendant: 40, GroundStaff: 3},
+}
+
+// Complement is the number of crew members required per position
+type Complement struct {
+	Pilots      int
+	Attendants  int
+	GroundStaff int
+}
+
+// Count returns the number of crew members required for a position
+func (c Complement) Count(position string) int {
+	switch position {
+	case domain.PositionPilot:
+		return c.Pilots
+	case domain.PositionAttendant:
+		return c.Attendants
+	case domain.PositionGroundStaff:
+		return c.GroundStaff
+	}
+	return 0
+}
+
+// RequiredComplement returns the minimum crew for a flight based on its seat count
+func RequiredComplement(flight *domain.Flight, rules []ComplementRule) Complement {
+	for _, rule := range rules {
+		if flight.FlightCapacity <= rule.MaxSeats {
+			return Complement{
+				Pilots:      rule.Pilots,
+				Attendants:  (flight.FlightCapacity + rule.SeatsPerAttendant - 1) / rule.SeatsPerAttendant,
+				GroundStaff: rule.GroundStaff,
+			}
+		}
+	}
+	return Complement{Pilots: 2, Attendants: 1, GroundStaff: 1}
+}
+
+// DutyLimits bounds how much a crew member may work during a roster period
+type DutyLimits struct {
+	MaxDutyPeriod  time.Duration // Longest duty period, from first report to last release
+	MinTurnaround  time.Duration // Shortest ground time between two duties in one duty period
+	MinRest        time.Duration // Ground time after which a new duty period starts
+	MaxDutyTime    time.Duration // Total duty time allowed over the roster period
+	GroundHandling time.Duration // Time ground staff spend on a departure before it leaves
+}
+
+// DefaultDutyLimits returns duty limits suitable for short and medium haul operations
+func DefaultDutyLimits() DutyLimits {
+	return DutyLimits{
+		MaxDutyPeriod:  13 * time.Hour,
+		MinTurnaround:  45 * time.Minute,
+		MinRest:        10 * time.Hour,
+		MaxDutyTime:    60 * time.Hour,
+		GroundHandling: 90 * time.Minute,
+	}
+}
+
+// RosterAssignment is the proposed crew for a single flight
+type RosterAssignment struct {
+	FlightNumber string
+	Crew         []domain.Crew
+	Missing      map[string]int // Positions that could not be filled, with the shortfall
+}
+
+// Complete reports whether every required position has been filled
+func (a *RosterAssignment) Complete() bool {
+	return len(a.Missing) == 0
+}
+
+// RosterCrewSummary shows the workload given to a crew member by the roster
+type RosterCrewSummary struct {
+	Member      *domain.CrewMember
+	Duties      int
+	DutyTime    time.Duration
+	EndLocation string
+	EndsAtHome  bool
+}
+
+// Roster is a proposed crew assignment for the flights in a date range
+type Roster struct {
+	From        time.Time
+	To          time.Time
+	Assignments []*RosterAssignment
+	Summary     []*RosterCrewSummary
+}
+
+// Commit assigns the crew of every complete assignment through the flight service
+func (r *Roster) Commit(flights ports.FlightService) (int, error) {
+	committed := 0
+	for _, assignment := range r.Assignments {
+		if !assignment.Complete() {
+			continue
+		}
+
+		err := flights.AssignCrew(assignment.FlightNumber, assignment.Crew)
+		if err != nil {
+			return committed, fmt.Errorf("failed to assign crew to flight %s: %w", assignment.FlightNumber, err)
+		}
+		committed++
+	}
+
+	return committed, nil
+}
+
+// RosterGenerator proposes crew assignments from the crew registry
+type RosterGenerator struct {
+	flightRepo ports.FlightRepository
+	crewRepo   ports.CrewRepository
+	limits     DutyLimits
+	rules      []ComplementRule
+}
+
+// NewRosterGenerator creates a new roster generator using the default complement rules
+func NewRosterGenerator(flightRepo ports.FlightRepository, crewRepo ports.CrewRepository, limits DutyLimits) *RosterGenerator {
+	return &RosterGenerator{
+		flightRepo: flightRepo,
+		crewRepo:   crewRepo,
+		limits:     limits,
+		rules:      DefaultComplementRules,
+	}
+}
+
+// crewState tracks where a crew member is and how much they have worked
+type crewState struct {
+	member      *domain.CrewMember
+	location    string
+	lastRelease time.Time
+	dutyStart   time.Time
+	dutyTime    time.Duration
+	duties      int
+}
+
+// dutyWindow returns when a crew member reports and is released for a flight
+func (g *RosterGenerator) dutyWindow(member *domain.CrewMember, flight *domain.Flight) (time.Time, time.Time) {
+	if member.Position == domain.PositionGroundStaff {
+		return flight.DepartureTime.Add(-g.limits.GroundHandling), flight.DepartureTime
+	}
+	return flight.DepartureTime, flight.ArrivalTime
+}
+
+// canWork checks location continuity and duty limits for a flight
+func (g *RosterGenerator) canWork(state *crewState, flight *domain.Flight) bool {
+	if !strings.EqualFold(state.location, flight.DepartureCity) {
+		return false
+	}
+
+	report, release := g.dutyWindow(state.member, flight)
+	if state.dutyTime+release.Sub(report) > g.limits.MaxDutyTime {
+		return false
+	}
+
+	// First duty of the roster period
+	if state.lastRelease.IsZero() {
+		return true
+	}
+
+	gap := report.Sub(state.lastRelease)
+	if gap < g.limits.MinTurnaround {
+		return false
+	}
+
+	// Continuing the same duty period
+	if gap < g.limits.MinRest {
+		return release.Sub(state.dutyStart) <= g.limits.MaxDutyPeriod
+	}
+
+	return true
+}
+
+// work records a flight against the crew member's state
+func (g *RosterGenerator) work(state *crewState, flight *domain.Flight) {
+	report, release := g.dutyWindow(state.member, flight)
+	if state.lastRelease.IsZero() || report.Sub(state.lastRelease) >= g.limits.MinRest {
+		state.dutyStart = report
+	}
+
+	state.lastRelease = release
+	state.dutyTime += release.Sub(report)
+	state.duties++
+
+	// Ground staff stay at their station
+	if state.member.Position != domain.PositionGroundStaff {
+		state.location = flight.DestinationCity
+	}
+}
+
+// score ranks candidates for a flight; lower is better
+func (g *RosterGenerator) score(state *crewState, flight *domain.Flight) time.Duration {
+	score := state.dutyTime
+
+	// Prefer crew whose flight takes them back to their home base
+	if state.member.Position != domain.PositionGroundStaff &&
+		strings.EqualFold(flight.DestinationCity, state.member.HomeBase) &&
+		!strings.EqualFold(state.location, state.member.HomeBase) {
+		score -= 4 * time.Hour
+	}
+
+	return score
+}
+
+// Generate proposes a roster for the uncrewed flights departing between from and to
+func (g *RosterGenerator) Generate(from, to time.Time) (*Roster, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("roster end must be after roster start")
+	}
+
+	allFlights, err := g.flightRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := g.crewRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the flights of the roster period in departure order
+	var flights []*domain.Flight
+	for _, flight := range allFlights {
+		if !flight.DepartureTime.Before(from) && flight.DepartureTime.Before(to) {
+			flights = append(flights, flight)
+		}
+	}
+	sort.Slice(flights, func(i, j int) bool {
+		return flights[i].DepartureTime.Before(flights[j].DepartureTime)
+	})
+
+	// Every crew member starts the period at their home base
+	states := make(map[string]*crewState, len(members))
+	ordered := make([]*crewState, 0, len(members))
+	for _, member := range members {
+		state := &crewState{member: member, location: member.HomeBase}
+		states[member.ID] = state
+		ordered = append(ordered, state)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].member.ID < ordered[j].member.ID
+	})
+
+	roster := &Roster{From: from, To: to}
+	positions := []string{domain.PositionPilot, domain.PositionAttendant, domain.PositionGroundStaff}
+
+	for _, flight := range flights {
+		// Flights crewed already still count towards their crew's duty
+		if len(flight.CrewMembers) > 0 {
+			for _, crew := range flight.CrewMembers {
+				if state, ok := states[crew.ID]; ok {
+					g.work(state, flight)
+				}
+			}
+			continue
+		}
+
+		complement := RequiredComplement(flight, g.rules)
+		assignment := &RosterAssignment{
+			FlightNumber: flight.FlightNumber,
+			Missing:      make(map[string]int),
+		}
+		var selected []*crewState
+
+		for _, position := range positions {
+			var candidates []*crewState
+			for _, state := range ordered {
+				if state.member.Position == position && g.canWork(state, flight) {
+					candidates = append(candidates, state)
+				}
+			}
+
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return g.score(candidates[i], flight) < g.score(candidates[j], flight)
+			})
+
+			needed := complement.Count(position)
+			if len(candidates) < needed {
+				assignment.Missing[position] = needed - len(candidates)
+				needed = len(candidates)
+			}
+			selected = append(selected, candidates[:needed]...)
+		}
+
+		for _, state := range selected {
+			assignment.Crew = append(assignment.Crew, state.member.AsCrew())
+		}
+
+		// Only a complete crew actually operates the flight
+		if assignment.Complete() {
+			for _, state := range selected {
+				g.work(state, flight)
+			}
+		}
+
+		roster.Assignments = append(roster.Assignments, assignment)
+	}
+
+	for _, state := range ordered {
+		roster.Summary = append(roster.Summary, &RosterCrewSummary{
+			Member:      state.member,
+			Duties:      state.duties,
+			DutyTime:    state.dutyTime,
+			EndLocation: state.location,
+			EndsAtHome:  strings.EqualFold(state.location, state.member.HomeBase),
+		})
+	}
+
+	return roster, nil
+}
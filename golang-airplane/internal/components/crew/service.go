@@ -0,0 +1,69 @@
+package crew
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sort"
+)
+
+// Service manages the crew registry
+type Service struct {
+	crewRepo ports.CrewRepository
+}
+
+// NewService creates a new crew service instance
+func NewService(crewRepo ports.CrewRepository) *Service {
+	return &Service{crewRepo: crewRepo}
+}
+
+// RegisterCrewMember adds a new crew member to the registry
+func (s *Service) RegisterCrewMember(name, position, homeBase string) (*domain.CrewMember, error) {
+	// Verify the crew member data
+	if name == "" || homeBase == "" {
+		return nil, domain.Rejectf("crew member name and home base cannot be empty")
+	}
+	if !domain.IsCrewPosition(position) {
+		return nil, domain.Rejectf("unknown crew position %q", position)
+	}
+
+	// Create new crew member
+	members, err := s.crewRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	member := &domain.CrewMember{
+		ID:       fmt.Sprintf("C%04d", len(members)+1),
+		Name:     name,
+		Position: position,
+		HomeBase: homeBase,
+	}
+
+	// Store the crew member
+	err = s.crewRepo.Save(member)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save crew member: %w", err)
+	}
+
+	return member, nil
+}
+
+// GetCrewMember retrieves a crew member by their ID
+func (s *Service) GetCrewMember(id string) (*domain.CrewMember, error) {
+	return s.crewRepo.FindByID(id)
+}
+
+// ListCrew retrieves all registered crew members sorted by ID
+func (s *Service) ListCrew() ([]*domain.CrewMember, error) {
+	members, err := s.crewRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].ID < members[j].ID
+	})
+
+	return members, nil
+}
@@ -0,0 +1,51 @@
+package documents
+
+import (
+	_ "embed"
+	"github.com/jung-kurt/gofpdf"
+	"strings"
+)
+
+// defaultFont is the family documents are printed in unless a brand picks another one
+const defaultFont = "DejaVu Sans"
+
+// DejaVu Sans Condensed, free under the Bitstream Vera licence (https://dejavu-fonts.github.io/License.html),
+// covers the Latin, Vietnamese and most other diacritics of passenger names
+
+//go:embed fonts/DejaVuSansCondensed.ttf
+var dejaVuSans []byte
+
+//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+var dejaVuSansBold []byte
+
+//go:embed fonts/DejaVuSansCondensed-Oblique.ttf
+var dejaVuSansOblique []byte
+
+// fonts are the UTF-8 font families embedded in the renderer, with their files per style
+var fonts = map[string]map[string][]byte{
+	"DejaVu Sans": {"": dejaVuSans, "B": dejaVuSansBold, "I": dejaVuSansOblique},
+}
+
+// fontFamily returns the embedded font family of a name, ignoring case
+func fontFamily(name string) (string, bool) {
+	for family := range fonts {
+		if strings.EqualFold(family, name) {
+			return family, true
+		}
+	}
+	return "", false
+}
+
+// addFont registers the embedded font family of a brand on a PDF and returns it; brands saved
+// with a core PDF font get the default family, since core fonts cannot print most diacritics
+func addFont(pdf *gofpdf.Fpdf, name string) string {
+	family, ok := fontFamily(name)
+	if !ok {
+		family = defaultFont
+	}
+
+	for style, file := range fonts[family] {
+		pdf.AddUTF8FontFromBytes(family, style, file)
+	}
+	return family
+}
@@ -0,0 +1,215 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/jung-kurt/gofpdf"
+	"golang-airplane/internal/components/boardingpass"
+	"golang-airplane/internal/core/domain"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// rgb is a colour of the brand palette
+type rgb struct {
+	r, g, b int
+}
+
+// document draws branded elements on a PDF page
+type document struct {
+	pdf     *gofpdf.Fpdf
+	brand   *domain.Brand
+	font    string // Embedded UTF-8 font family the text is printed in
+	primary rgb
+	accent  rgb
+}
+
+// newDocument starts a one-page PDF in the colours and font of a brand
+func newDocument(pdf *gofpdf.Fpdf, brand *domain.Brand) (*document, error) {
+	doc := &document{
+		pdf:   pdf,
+		brand: brand,
+		font:  addFont(pdf, brand.Font),
+	}
+
+	var err error
+	doc.primary.r, doc.primary.g, doc.primary.b, err = parseColor(brand.PrimaryColor)
+	if err != nil {
+		return nil, err
+	}
+	doc.accent.r, doc.accent.g, doc.accent.b, err = parseColor(brand.AccentColor)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf.SetTitle(brand.AirlineName, true)
+	pdf.SetAuthor(brand.AirlineName, true)
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+
+	return doc, nil
+}
+
+// header draws the brand bar with the airline name, logo and document title
+func (d *document) header(title string) {
+	width, _ := d.pdf.GetPageSize()
+
+	d.pdf.SetFillColor(d.primary.r, d.primary.g, d.primary.b)
+	d.pdf.Rect(0, 0, width, 20, "F")
+	d.pdf.SetFillColor(d.accent.r, d.accent.g, d.accent.b)
+	d.pdf.Rect(0, 20, width, 1.5, "F")
+
+	x := 10.0
+	if d.brand.LogoPath != "" {
+		d.pdf.ImageOptions(d.brand.LogoPath, x, 3, 0, 14, false, gofpdf.ImageOptions{ReadDpi: true}, 0, "")
+		x += 20
+	}
+
+	d.pdf.SetTextColor(255, 255, 255)
+	d.pdf.SetFont(d.font, "B", 16)
+	d.pdf.SetXY(x, 5)
+	d.pdf.CellFormat(width/2, 10, d.brand.AirlineName, "", 0, "L", false, 0, "")
+
+	d.pdf.SetFont(d.font, "B", 12)
+	d.pdf.SetXY(width/2, 5)
+	d.pdf.CellFormat(width/2-10, 10, title, "", 0, "R", false, 0, "")
+}
+
+// field draws a small label above its value
+func (d *document) field(x, y, width float64, label, value string) {
+	d.labelled(x, y, width, label, value, false)
+}
+
+// highlight draws a field in the brand colour, for what the passenger looks for first
+func (d *document) highlight(x, y, width float64, label, value string) {
+	d.labelled(x, y, width, label, value, true)
+}
+
+// labelled draws a label and value pair
+func (d *document) labelled(x, y, width float64, label, value string, highlighted bool) {
+	d.pdf.SetTextColor(120, 120, 120)
+	d.pdf.SetFont(d.font, "", 7)
+	d.pdf.SetXY(x, y)
+	d.pdf.CellFormat(width, 4, label, "", 0, "L", false, 0, "")
+
+	d.pdf.SetTextColor(0, 0, 0)
+	size := 11.0
+	if highlighted {
+		d.pdf.SetTextColor(d.primary.r, d.primary.g, d.primary.b)
+		size = 14
+	}
+	d.pdf.SetFont(d.font, "B", size)
+	d.pdf.SetXY(x, y+4)
+	d.pdf.CellFormat(width, 7, value, "", 0, "L", false, 0, "")
+}
+
+// section draws a section heading with an accent rule
+func (d *document) section(y float64, title string) {
+	width, _ := d.pdf.GetPageSize()
+
+	d.pdf.SetTextColor(d.primary.r, d.primary.g, d.primary.b)
+	d.pdf.SetFont(d.font, "B", 12)
+	d.pdf.SetXY(10, y)
+	d.pdf.CellFormat(width-20, 6, title, "", 0, "L", false, 0, "")
+
+	d.pdf.SetDrawColor(d.accent.r, d.accent.g, d.accent.b)
+	d.pdf.SetLineWidth(0.5)
+	d.pdf.Line(10, y+6.5, width-10, y+6.5)
+}
+
+// table draws a header row in the brand colour followed by the rows; the last
+// column is right-aligned
+func (d *document) table(y float64, widths []float64, headers []string, rows [][]string) {
+	align := func(i int) string {
+		if i == len(widths)-1 && len(widths) == 2 {
+			return "R"
+		}
+		return "L"
+	}
+
+	d.pdf.SetXY(10, y)
+	d.pdf.SetFillColor(d.primary.r, d.primary.g, d.primary.b)
+	d.pdf.SetTextColor(255, 255, 255)
+	d.pdf.SetFont(d.font, "B", 9)
+	for i, header := range headers {
+		d.pdf.CellFormat(widths[i], 7, header, "", 0, align(i), true, 0, "")
+	}
+	d.pdf.Ln(-1)
+
+	d.pdf.SetTextColor(0, 0, 0)
+	d.pdf.SetDrawColor(200, 200, 200)
+	d.pdf.SetLineWidth(0.2)
+	for n, row := range rows {
+		style := ""
+		if n == len(rows)-1 && len(rows) > 1 {
+			style = "B"
+		}
+		d.pdf.SetFont(d.font, style, 9)
+		d.pdf.SetX(10)
+		for i, cell := range row {
+			d.pdf.CellFormat(widths[i], 7, cell, "B", 0, align(i), false, 0, "")
+		}
+		d.pdf.Ln(-1)
+	}
+}
+
+// note draws a line of plain text
+func (d *document) note(y float64, text string) {
+	width, _ := d.pdf.GetPageSize()
+
+	d.pdf.SetTextColor(80, 80, 80)
+	d.pdf.SetFont(d.font, "I", 9)
+	d.pdf.SetXY(10, y)
+	d.pdf.CellFormat(width-20, 6, text, "", 0, "L", false, 0, "")
+}
+
+// barcode draws boarding pass data as a barcode of the given width
+func (d *document) barcode(name, data string, symbology boardingpass.Symbology, x, y, width float64) error {
+	var buf bytes.Buffer
+	if err := boardingpass.WritePNG(&buf, data, symbology, 2); err != nil {
+		return err
+	}
+
+	d.pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, &buf)
+	d.pdf.ImageOptions(name, x, y, width, 0, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	return nil
+}
+
+// footer draws the brand's footer text at the bottom of the page
+func (d *document) footer(text string) {
+	width, height := d.pdf.GetPageSize()
+
+	d.pdf.SetFillColor(d.accent.r, d.accent.g, d.accent.b)
+	d.pdf.Rect(0, height-12, width, 0.8, "F")
+
+	d.pdf.SetTextColor(90, 90, 90)
+	d.pdf.SetFont(d.font, "", 8)
+	d.pdf.SetXY(10, height-10)
+	d.pdf.MultiCell(width-20, 4, text, "", "C", false)
+}
+
+// output writes the finished document
+func (d *document) output(w io.Writer) error {
+	if err := d.pdf.Error(); err != nil {
+		return fmt.Errorf("failed to render document: %w", err)
+	}
+	return d.pdf.Output(w)
+}
+
+// parseColor parses a hex colour such as #0B3D91
+func parseColor(hex string) (int, int, int, error) {
+	value := strings.TrimPrefix(hex, "#")
+	if len(value) != 6 {
+		return 0, 0, 0, fmt.Errorf("colour %q must be in #RRGGBB form", hex)
+	}
+
+	n, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("colour %q must be in #RRGGBB form", hex)
+	}
+
+	return int(n >> 16 & 0xFF), int(n >> 8 & 0xFF), int(n & 0xFF), nil
+}
@@ -0,0 +1,278 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/jung-kurt/gofpdf"
+	"golang-airplane/internal/components/boardingpass"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// documentData is what brand templates are executed with
+type documentData struct {
+	Brand       *domain.Brand
+	Reservation *domain.Reservation
+	Flight      *domain.Flight
+}
+
+// Renderer writes printable PDF boarding passes and booking confirmations
+type Renderer struct {
+	flightRepo      ports.FlightRepository
+	reservationRepo ports.ReservationRepository
+	brandRepo       ports.BrandRepository
+	passes          *boardingpass.Service
+}
+
+// NewRenderer creates a new Renderer instance
+func NewRenderer(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository,
+	brandRepo ports.BrandRepository, passes *boardingpass.Service) *Renderer {
+	return &Renderer{
+		flightRepo:      flightRepo,
+		reservationRepo: reservationRepo,
+		brandRepo:       brandRepo,
+		passes:          passes,
+	}
+}
+
+// SaveBrand validates and stores an airline brand
+func (r *Renderer) SaveBrand(brand *domain.Brand) error {
+	if brand.ID == "" || brand.AirlineName == "" {
+		return fmt.Errorf("brand ID and airline name cannot be empty")
+	}
+
+	for _, hex := range []string{brand.PrimaryColor, brand.AccentColor} {
+		if _, _, _, err := parseColor(hex); err != nil {
+			return err
+		}
+	}
+
+	if brand.Font == "" {
+		brand.Font = defaultFont
+	}
+	family, ok := fontFamily(brand.Font)
+	if !ok {
+		return fmt.Errorf("font %q is not embedded in the renderer; use %s", brand.Font, defaultFont)
+	}
+	brand.Font = family
+
+	if brand.LogoPath != "" {
+		if _, err := os.Stat(brand.LogoPath); err != nil {
+			return fmt.Errorf("logo not found: %w", err)
+		}
+	}
+
+	for _, text := range []string{brand.BoardingPassFooter, brand.ReceiptFooter} {
+		if _, err := template.New("footer").Parse(text); err != nil {
+			return fmt.Errorf("invalid footer template: %w", err)
+		}
+	}
+
+	return r.brandRepo.Save(brand)
+}
+
+// Brand retrieves a brand by ID, or the default brand when the ID is empty
+func (r *Renderer) Brand(id string) (*domain.Brand, error) {
+	if id == "" || id == domain.DefaultBrand.ID {
+		brand := domain.DefaultBrand
+		return &brand, nil
+	}
+	return r.brandRepo.FindByID(id)
+}
+
+// BoardingPass writes the PDF boarding pass of a checked-in reservation
+func (r *Renderer) BoardingPass(w io.Writer, reservationID, brandID string) error {
+	data, err := r.load(reservationID, brandID)
+	if err != nil {
+		return err
+	}
+
+	bcbp, err := r.passes.IssueData(reservationID)
+	if err != nil {
+		return err
+	}
+
+	footer, err := execute(data.Brand.BoardingPassFooter, data)
+	if err != nil {
+		return err
+	}
+
+	// A boarding pass is printed on a landscape card
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: "L",
+		UnitStr:        "mm",
+		Size:           gofpdf.SizeType{Wd: 99, Ht: 210},
+	})
+	doc, err := newDocument(pdf, data.Brand)
+	if err != nil {
+		return err
+	}
+	reservation, flight := data.Reservation, data.Flight
+
+	doc.header("BOARDING PASS")
+
+	y := 25.0
+	doc.field(10, y, 95, "PASSENGER", reservation.Name)
+	doc.field(110, y, 40, "CLASS", titleCase(reservation.Class))
+	doc.field(155, y, 45, "BOOKING", reservation.ReservationID)
+
+	y += 12
+	doc.field(10, y, 95, "FROM", fmt.Sprintf("%s (%s)", flight.DepartureCity, boardingpass.AirportCode(flight.DepartureCity)))
+	doc.field(110, y, 90, "TO", fmt.Sprintf("%s (%s)", flight.DestinationCity, boardingpass.AirportCode(flight.DestinationCity)))
+
+	y += 12
+	doc.field(10, y, 30, "FLIGHT", flight.FlightNumber)
+	doc.field(45, y, 30, "DATE", flight.DepartureTime.Format("02 Jan 2006"))
+	doc.field(80, y, 25, "DEPARTS", flight.DepartureTime.Format("15:04"))
+	doc.highlight(110, y, 30, "BOARDING", flight.BoardingTime().Format("15:04"))
+	doc.highlight(145, y, 25, "GATE", orDash(flight.Gate))
+	doc.highlight(175, y, 25, "SEAT", reservation.SeatLocation)
+
+	// The PDF417 symbol is what gate scanners read
+	y += 14
+	if err := doc.barcode("pdf417", bcbp, boardingpass.SymbologyPDF417, 10, y, 75); err != nil {
+		return err
+	}
+	if err := doc.barcode("qr", bcbp, boardingpass.SymbologyQR, 176, y, 22); err != nil {
+		return err
+	}
+
+	doc.footer(footer)
+
+	return doc.output(w)
+}
+
+// Receipt writes the PDF booking confirmation of a reservation with its fare breakdown
+func (r *Renderer) Receipt(w io.Writer, reservationID, brandID string) error {
+	data, err := r.load(reservationID, brandID)
+	if err != nil {
+		return err
+	}
+
+	footer, err := execute(data.Brand.ReceiptFooter, data)
+	if err != nil {
+		return err
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	doc, err := newDocument(pdf, data.Brand)
+	if err != nil {
+		return err
+	}
+	reservation, flight := data.Reservation, data.Flight
+
+	doc.header("BOOKING CONFIRMATION")
+
+	y := 32.0
+	doc.field(10, y, 90, "BOOKING REFERENCE", reservation.ReservationID)
+	doc.field(110, y, 90, "STATUS", titleCase(statusOf(reservation)))
+
+	y += 14
+	doc.field(10, y, 90, "PASSENGER", reservation.Name)
+	doc.field(110, y, 90, "PHONE", strconv.FormatInt(reservation.PhoneNumber, 10))
+	y += 14
+	doc.field(10, y, 190, "ADDRESS", reservation.Address)
+
+	// Itinerary
+	y += 20
+	doc.section(y, "Itinerary")
+	y += 8
+	doc.table(y, []float64{25, 40, 40, 35, 35, 15},
+		[]string{"Flight", "From", "To", "Departs", "Arrives", "Seat"},
+		[][]string{{
+			flight.FlightNumber,
+			flight.DepartureCity,
+			flight.DestinationCity,
+			flight.DepartureTime.Format("02/01/2006 15:04"),
+			flight.ArrivalTime.Format("02/01/2006 15:04"),
+			orDash(reservation.SeatLocation),
+		}})
+
+	// Fare breakdown
+	y += 26
+	doc.section(y, "Fare breakdown")
+	y += 8
+	if reservation.Fare == nil {
+		doc.note(y, "No fare was recorded for this booking.")
+	} else {
+		fare := reservation.Fare
+		rows := [][]string{{"Base fare (" + titleCase(reservation.Class) + ")", domain.FormatAmount(fare.Base, fare.Currency)}}
+		for _, tax := range fare.Taxes {
+			rows = append(rows, []string{fmt.Sprintf("%s (%s)", tax.Description, tax.Code), domain.FormatAmount(tax.Amount, fare.Currency)})
+		}
+		for _, fee := range fare.Fees {
+			rows = append(rows, []string{fmt.Sprintf("%s (%s)", fee.Description, fee.Code), domain.FormatAmount(fee.Amount, fare.Currency)})
+		}
+		rows = append(rows, []string{"Total", domain.FormatAmount(fare.Total(), fare.Currency)})
+		doc.table(y, []float64{140, 50}, []string{"Item", "Amount"}, rows)
+	}
+
+	doc.footer(footer)
+
+	return doc.output(w)
+}
+
+// load retrieves the reservation, flight and brand of a document
+func (r *Renderer) load(reservationID, brandID string) (*documentData, error) {
+	reservation, err := r.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	flight, err := r.flightRepo.FindByID(reservation.ReservationFlightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	brand, err := r.Brand(brandID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &documentData{Brand: brand, Reservation: reservation, Flight: flight}, nil
+}
+
+// execute runs a brand template against a document
+func execute(text string, data *documentData) (string, error) {
+	tmpl, err := template.New("footer").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid brand template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute brand template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// statusOf returns the status of a reservation for display
+func statusOf(reservation *domain.Reservation) string {
+	if reservation.Status == "" {
+		return domain.ReservationConfirmed
+	}
+	return strings.ReplaceAll(reservation.Status, "_", " ")
+}
+
+// orDash returns a placeholder for values that are not known yet
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// titleCase capitalises the first letter of every word
+func titleCase(value string) string {
+	words := strings.Fields(value)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
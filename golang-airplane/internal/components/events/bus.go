@@ -0,0 +1,489 @@
+// Package events carries the domain events published by the services to their subscribers. An event is written to an
+// outbox in the same step of the storage as the change it records, so it is stored if and only if the change is, and
+// asynchronous subscribers get each stored event at least once. Events are kept for a while after that to be replayed.
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sync"
+	"time"
+)
+
+// DefaultRetryInterval is how often events that asynchronous subscribers failed on are delivered again
+const DefaultRetryInterval = 30 * time.Second
+
+// DefaultRetention is how long events are kept in the outbox; older ones are removed once every asynchronous
+// subscriber has them
+const DefaultRetention = 7 * 24 * time.Hour
+
+// watchBuffer is how many events a watcher may fall behind by before it misses some
+const watchBuffer = 16
+
+// Handler handles an event, found in message.Event. A synchronous handler's error fails the change that published
+// the event; an asynchronous handler's error has the event delivered to it again later.
+type Handler func(message *domain.EventMessage) error
+
+// subscription is a handler of some or all event types
+type subscription struct {
+	name    string
+	types   map[string]bool // Empty for every type
+	handler Handler
+	async   bool
+}
+
+// handles reports whether the subscription is for an event type
+func (s *subscription) handles(eventType string) bool {
+	return len(s.types) == 0 || s.types[eventType]
+}
+
+// watcher is a channel following some or all event types
+type watcher struct {
+	types  map[string]bool // Empty for every type
+	events chan *domain.EventMessage
+}
+
+// Bus hands published events to the subscribers: synchronous ones while the change is being made, asynchronous ones
+// from the outbox in the background
+type Bus struct {
+	outbox        ports.OutboxRepository
+	tx            ports.Transactor
+	retention     time.Duration
+	mutex         sync.Mutex // Guards the fields below and serialises outbox writes
+	subscriptions []*subscription
+	watchers      map[*watcher]bool
+	open          bool                   // Whether a change is being made by Atomically
+	published     []*domain.EventMessage // Events of the open change, announced once it is stored
+	changing      sync.Mutex             // Held by Atomically, so changes are made one at a time
+	delivering    sync.Mutex             // Held while pending events are delivered, so each is delivered once per pass
+	wake          chan struct{}
+}
+
+// NewBus creates an event bus keeping its outbox in a repository of the storage tx makes steps of, so events are
+// stored with the changes they record. Events older than retention, or DefaultRetention when not positive, are removed
+// from the outbox once every asynchronous subscriber has them.
+func NewBus(outbox ports.OutboxRepository, tx ports.Transactor, retention time.Duration) *Bus {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Bus{
+		outbox:    outbox,
+		tx:        tx,
+		retention: retention,
+		watchers:  make(map[*watcher]bool),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Subscribe registers a handler called with the events of the given types, or of every type when none is given,
+// as they are published
+func (b *Bus) Subscribe(name string, handler Handler, types ...string) {
+	b.subscribe(name, handler, types, false)
+}
+
+// SubscribeAsync registers a handler called in the background with the events of the given types, or of every type
+// when none is given; events it fails on are delivered again until it succeeds. It runs outside the change that
+// published the event, so it must not change flights or reservations.
+func (b *Bus) SubscribeAsync(name string, handler Handler, types ...string) {
+	b.subscribe(name, handler, types, true)
+}
+
+// Watch returns a channel receiving the events of the given types, or of every type when none is given, published
+// from now on until stop is called. Watchers are not recorded in the outbox and are never waited for: one that falls
+// behind misses events, so it should read again what it follows whenever it is woken.
+func (b *Bus) Watch(types ...string) (<-chan *domain.EventMessage, func()) {
+	w := &watcher{types: make(map[string]bool), events: make(chan *domain.EventMessage, watchBuffer)}
+	for _, eventType := range types {
+		w.types[eventType] = true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.watchers[w] = true
+
+	stop := func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		delete(b.watchers, w)
+	}
+	return w.events, stop
+}
+
+// subscribe registers a subscription
+func (b *Bus) subscribe(name string, handler Handler, types []string, async bool) {
+	subscription := &subscription{name: name, types: make(map[string]bool), handler: handler, async: async}
+	for _, eventType := range types {
+		subscription.types[eventType] = true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.subscriptions = append(b.subscriptions, subscription)
+}
+
+// Atomically makes a change and records the events it publishes in one step of the storage: if the change or a
+// synchronous subscriber fails, neither the change nor its events are stored. The watchers and the asynchronous
+// subscribers are only told of the events once they are stored. Changes must not be nested.
+func (b *Bus) Atomically(change func() error) error {
+	b.changing.Lock()
+	defer b.changing.Unlock()
+
+	err := b.step(func() error {
+		b.mutex.Lock()
+		b.open = true
+		b.mutex.Unlock()
+		return change()
+	})
+
+	b.mutex.Lock()
+	published := b.published
+	b.open, b.published = false, nil
+	b.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	b.announce(published)
+	return nil
+}
+
+// Publish records an event in the outbox and calls the synchronous subscribers; within Atomically, the event is stored
+// with the change, otherwise the watchers and the asynchronous subscribers are told of it at once
+func (b *Bus) Publish(event domain.Event) error {
+	message, err := newMessage(event)
+	if err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	var synchronous []*subscription
+	for _, subscription := range b.subscriptions {
+		switch {
+		case !subscription.handles(message.Type):
+		case subscription.async:
+			message.Subscribers = append(message.Subscribers, subscription.name)
+		default:
+			synchronous = append(synchronous, subscription)
+		}
+	}
+	err = b.outbox.Append(message)
+	open := b.open
+	if err == nil && open {
+		b.published = append(b.published, message)
+	}
+	b.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %w", message.Type, err)
+	}
+
+	for _, subscription := range synchronous {
+		if err := subscription.handler(message); err != nil {
+			return fmt.Errorf("%s subscriber failed on %s event: %w", subscription.name, message.Type, err)
+		}
+	}
+
+	if !open {
+		b.announce([]*domain.EventMessage{message})
+	}
+	return nil
+}
+
+// StartDelivery delivers pending events to the asynchronous subscribers as they are published, and retries the
+// failed ones every interval, until the context is cancelled
+func (b *Bus) StartDelivery(ctx context.Context, interval time.Duration, onError func(err error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := b.DeliverPending(); err != nil && onError != nil {
+				onError(err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-b.wake:
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// DeliverPending delivers the events the asynchronous subscribers are still owed, in the order they were published,
+// then removes the events past their retention. A subscriber that fails is skipped until the next call, so it gets its
+// events in order; the first error is returned.
+func (b *Bus) DeliverPending() error {
+	b.delivering.Lock()
+	defer b.delivering.Unlock()
+
+	messages, err := b.stored()
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	failed := make(map[string]bool)
+	for _, subscription := range b.asynchronous() {
+		for _, message := range messages {
+			if failed[subscription.name] || !message.IsPending(subscription.name) {
+				continue
+			}
+			if err := b.deliver(subscription, message); err != nil {
+				failed[subscription.name] = true
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+	}
+
+	if err := b.prune(messages); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// prune removes the events of the outbox every asynchronous subscriber has had for longer than the retention, if
+// messages, as last read, hold any
+func (b *Bus) prune(messages []*domain.EventMessage) error {
+	before := time.Now().UTC().Add(-b.retention)
+	expired := false
+	for _, message := range messages {
+		if message.OccurredAt.Before(before) && !message.HasPending() {
+			expired = true
+			break
+		}
+	}
+	if !expired {
+		return nil
+	}
+
+	return b.step(func() error {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		if _, err := b.outbox.Prune(before); err != nil {
+			return fmt.Errorf("failed to prune the outbox: %w", err)
+		}
+		return nil
+	})
+}
+
+// Pending returns the messages some asynchronous subscriber is still owed
+func (b *Bus) Pending() ([]*domain.EventMessage, error) {
+	messages, err := b.stored()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []*domain.EventMessage{}
+	for _, message := range messages {
+		if message.HasPending() {
+			pending = append(pending, message)
+		}
+	}
+	return pending, nil
+}
+
+// Messages returns the messages of the outbox selected by a filter, oldest first
+func (b *Bus) Messages(filter domain.EventFilter) ([]*domain.EventMessage, error) {
+	messages, err := b.stored()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []*domain.EventMessage{}
+	for _, message := range messages {
+		if filter.Matches(message) {
+			selected = append(selected, message)
+		}
+	}
+	return selected, nil
+}
+
+// Replay delivers the past events selected by a filter to a subscriber again, whether or not it handled them before,
+// and returns how many it was given; it stops at the first event the subscriber fails on. Only the events still in
+// the outbox, within their retention, can be replayed.
+func (b *Bus) Replay(name string, filter domain.EventFilter) (int, error) {
+	subscription := b.subscription(name)
+	if subscription == nil {
+		return 0, fmt.Errorf("subscriber %s %w", name, domain.ErrNotFound)
+	}
+
+	b.delivering.Lock()
+	defer b.delivering.Unlock()
+
+	messages, err := b.Messages(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, message := range messages {
+		if !subscription.handles(message.Type) {
+			continue
+		}
+		if err := b.deliver(subscription, message); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+// Subscribers returns the names of the subscribers, in the order they subscribed
+func (b *Bus) Subscribers() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	names := make([]string, len(b.subscriptions))
+	for i, subscription := range b.subscriptions {
+		names[i] = subscription.name
+	}
+	return names
+}
+
+// deliver decodes a message and hands it to a subscriber, recording the delivery of asynchronous ones in the same step
+// as the changes the subscriber makes
+func (b *Bus) deliver(subscription *subscription, message *domain.EventMessage) error {
+	if message.Event == nil {
+		event, err := domain.NewEvent(message.Type)
+		if err != nil {
+			return fmt.Errorf("event %s: %w", message.ID, err)
+		}
+		if err := json.Unmarshal(message.Payload, event); err != nil {
+			return fmt.Errorf("failed to decode event %s: %w", message.ID, err)
+		}
+		message.Event = event
+	}
+
+	return b.step(func() error {
+		if err := subscription.handler(message); err != nil {
+			return fmt.Errorf("%s subscriber failed on event %s: %w", subscription.name, message.ID, err)
+		}
+		if !subscription.async {
+			return nil
+		}
+
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		if err := b.outbox.MarkDelivered(message.ID, subscription.name, time.Now().UTC()); err != nil {
+			return fmt.Errorf("failed to record delivery of event %s: %w", message.ID, err)
+		}
+		return nil
+	})
+}
+
+// step makes a change in one step of the storage, if the bus has one
+func (b *Bus) step(change func() error) error {
+	if b.tx == nil {
+		return change()
+	}
+	return b.tx.Atomically(change)
+}
+
+// stored returns the messages of the outbox, read in a step so none of a change still being made is seen
+func (b *Bus) stored() ([]*domain.EventMessage, error) {
+	var messages []*domain.EventMessage
+	err := b.step(func() error {
+		var err error
+		messages, err = b.outbox.FindAll()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the outbox: %w", err)
+	}
+	return messages, nil
+}
+
+// announce hands stored messages to the watchers and wakes the delivery to the asynchronous subscribers
+func (b *Bus) announce(messages []*domain.EventMessage) {
+	owed := false
+	b.mutex.Lock()
+	for _, message := range messages {
+		b.notify(message)
+		owed = owed || len(message.Subscribers) > 0
+	}
+	b.mutex.Unlock()
+
+	if owed {
+		select {
+		case b.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// notify hands a message to the watchers of its type that have room for it; the caller holds the mutex
+func (b *Bus) notify(message *domain.EventMessage) {
+	for w := range b.watchers {
+		if len(w.types) > 0 && !w.types[message.Type] {
+			continue
+		}
+		select {
+		case w.events <- message:
+		default:
+		}
+	}
+}
+
+// asynchronous returns the asynchronous subscriptions
+func (b *Bus) asynchronous() []*subscription {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var subscriptions []*subscription
+	for _, subscription := range b.subscriptions {
+		if subscription.async {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions
+}
+
+// subscription returns the subscription of a name, or nil
+func (b *Bus) subscription(name string) *subscription {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, subscription := range b.subscriptions {
+		if subscription.name == name {
+			return subscription
+		}
+	}
+	return nil
+}
+
+// newMessage encodes an event as a message of the outbox
+func newMessage(event domain.Event) (*domain.EventMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+	id, err := newEventID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EventMessage{
+		ID:         id,
+		Type:       event.EventType(),
+		OccurredAt: time.Now().UTC(),
+		Payload:    payload,
+		Event:      event,
+	}, nil
+}
+
+// newEventID returns a random event ID
+func newEventID() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate event ID: %w", err)
+	}
+	return "evt_" + hex.EncodeToString(buf), nil
+}
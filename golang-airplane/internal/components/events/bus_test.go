@@ -0,0 +1,271 @@
+package events_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang-airplane/internal/components/events"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/storage/json"
+)
+
+// recorder is a subscriber remembering the flights of the events it handled, failing while err is set
+type recorder struct {
+	flights []string
+	err     error
+}
+
+// handle records the flight of an event, or fails
+func (r *recorder) handle(message *domain.EventMessage) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.flights = append(r.flights, message.Event.(*domain.SeatReleased).FlightNumber)
+	return nil
+}
+
+// released returns a SeatReleased event of a flight
+func released(flightNumber string) *domain.SeatReleased {
+	return &domain.SeatReleased{FlightNumber: flightNumber, Seats: 1}
+}
+
+// newOutbox returns an outbox over empty JSON storage
+func newOutbox(t *testing.T) ports.OutboxRepository {
+	t.Helper()
+	return json.NewOutboxRepository(json.NewStorage(t.TempDir()))
+}
+
+// newBus returns a bus keeping its outbox in empty JSON storage
+func newBus(t *testing.T) *events.Bus {
+	t.Helper()
+	storage := json.NewStorage(t.TempDir())
+	return events.NewBus(json.NewOutboxRepository(storage), storage, events.DefaultRetention)
+}
+
+// publish publishes an event in a change of its own
+func publish(t *testing.T, bus *events.Bus, event domain.Event) {
+	t.Helper()
+	if err := bus.Atomically(func() error { return bus.Publish(event) }); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+}
+
+// pending returns how many messages are still owed to an asynchronous subscriber
+func pending(t *testing.T, bus *events.Bus) int {
+	t.Helper()
+	messages, err := bus.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	return len(messages)
+}
+
+// flightFixture is a flight service publishing to a bus, both over the same empty JSON storage
+type flightFixture struct {
+	bus     *events.Bus
+	flights *flight.Service
+}
+
+// newFlightFixture creates a flight fixture whose bus keeps its outbox in the repository outbox returns
+func newFlightFixture(t *testing.T, outbox func(storage *json.Storage) ports.OutboxRepository) *flightFixture {
+	t.Helper()
+	storage := json.NewStorage(t.TempDir())
+	flightRepo := json.NewFlightRepository(storage)
+	holds := flight.NewHoldService(flightRepo, json.NewSeatHoldRepository(storage), flight.DefaultHoldTTL)
+	bus := events.NewBus(outbox(storage), storage, events.DefaultRetention)
+	flights := flight.NewService(flightRepo, json.NewReservationRepository(storage), json.NewCrewChangeRepository(storage),
+		json.NewAirplaneRepository(storage), holds, bus)
+	return &flightFixture{bus: bus, flights: flights}
+}
+
+// addFlight adds flight AB123 and returns the error of the change
+func (f *flightFixture) addFlight() error {
+	departure := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	_, err := f.flights.AddFlight("AB123", "Hanoi", "Saigon", departure, departure.Add(2*time.Hour), 100)
+	return err
+}
+
+// assertNothingStored fails the test if flight AB123 or an event was stored
+func (f *flightFixture) assertNothingStored(t *testing.T) {
+	t.Helper()
+	if _, err := f.flights.GetFlight("AB123"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetFlight of the failed change: %v, want ErrNotFound", err)
+	}
+	messages, err := f.bus.Messages(domain.EventFilter{})
+	if err != nil {
+		t.Fatalf("Messages: %v", err)
+	}
+	if len(messages) != 0 {
+		t.Errorf("outbox holds %d events of a change that was not stored", len(messages))
+	}
+}
+
+func TestDelivery(t *testing.T) {
+	bus := newBus(t)
+	synchronous, asynchronous := &recorder{}, &recorder{}
+	bus.Subscribe("sync", synchronous.handle, domain.EventSeatReleased)
+	bus.SubscribeAsync("async", asynchronous.handle, domain.EventSeatReleased)
+
+	publish(t, bus, released("AB123"))
+	publish(t, bus, released("CD456"))
+
+	if len(synchronous.flights) != 2 {
+		t.Errorf("synchronous subscriber got %v, want both events as published", synchronous.flights)
+	}
+	if len(asynchronous.flights) != 0 || pending(t, bus) != 2 {
+		t.Fatalf("asynchronous subscriber got %v before delivery", asynchronous.flights)
+	}
+
+	if err := bus.DeliverPending(); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+	if err := bus.DeliverPending(); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+	if len(asynchronous.flights) != 2 || asynchronous.flights[0] != "AB123" || asynchronous.flights[1] != "CD456" {
+		t.Errorf("asynchronous subscriber got %v, want AB123 then CD456 once each", asynchronous.flights)
+	}
+	if n := pending(t, bus); n != 0 {
+		t.Errorf("%d events still pending after delivery", n)
+	}
+}
+
+func TestAsynchronousRetry(t *testing.T) {
+	bus := newBus(t)
+	subscriber := &recorder{err: errors.New("partner down")}
+	bus.SubscribeAsync("async", subscriber.handle)
+
+	publish(t, bus, released("AB123"))
+	publish(t, bus, released("CD456"))
+
+	if err := bus.DeliverPending(); err == nil {
+		t.Fatal("DeliverPending succeeded while the subscriber fails")
+	}
+	if n := pending(t, bus); n != 2 {
+		t.Fatalf("%d events pending after a failed delivery, want 2", n)
+	}
+
+	subscriber.err = nil
+	if err := bus.DeliverPending(); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+	if len(subscriber.flights) != 2 || subscriber.flights[0] != "AB123" {
+		t.Errorf("subscriber got %v once it recovered, want AB123 then CD456", subscriber.flights)
+	}
+}
+
+func TestStoredOnlyWithChange(t *testing.T) {
+	bus := newBus(t)
+	watched, stop := bus.Watch()
+	defer stop()
+
+	err := bus.Atomically(func() error {
+		if err := bus.Publish(released("AB123")); err != nil {
+			return err
+		}
+		return errors.New("change failed")
+	})
+	if err == nil {
+		t.Fatal("Atomically succeeded with a failing change")
+	}
+
+	messages, err := bus.Messages(domain.EventFilter{})
+	if err != nil {
+		t.Fatalf("Messages: %v", err)
+	}
+	if len(messages) != 0 {
+		t.Errorf("outbox holds %d events of a change that failed", len(messages))
+	}
+	select {
+	case message := <-watched:
+		t.Errorf("watcher was told of event %s of a change that failed", message.ID)
+	default:
+	}
+}
+
+func TestFailingSynchronousSubscriber(t *testing.T) {
+	f := newFlightFixture(t, func(storage *json.Storage) ports.OutboxRepository {
+		return json.NewOutboxRepository(storage)
+	})
+	f.bus.Subscribe("failing", func(message *domain.EventMessage) error {
+		return errors.New("subscriber broken")
+	})
+
+	if err := f.addFlight(); err == nil {
+		t.Fatal("AddFlight succeeded while a synchronous subscriber fails")
+	}
+	f.assertNothingStored(t)
+}
+
+// brokenOutbox is an outbox that fails to record messages
+type brokenOutbox struct {
+	ports.OutboxRepository
+}
+
+// Append fails
+func (brokenOutbox) Append(message *domain.EventMessage) error {
+	return errors.New("disk full")
+}
+
+func TestFailedOutboxWrite(t *testing.T) {
+	f := newFlightFixture(t, func(storage *json.Storage) ports.OutboxRepository {
+		return brokenOutbox{json.NewOutboxRepository(storage)}
+	})
+	synchronous := &recorder{}
+	f.bus.Subscribe("sync", synchronous.handle)
+	watched, stop := f.bus.Watch()
+	defer stop()
+
+	if err := f.addFlight(); err == nil {
+		t.Fatal("AddFlight succeeded while its event could not be recorded")
+	}
+	f.assertNothingStored(t)
+	if len(synchronous.flights) != 0 {
+		t.Error("synchronous subscriber got an event the outbox failed to record")
+	}
+	select {
+	case <-watched:
+		t.Error("watcher was told of an event the outbox failed to record")
+	default:
+	}
+}
+
+func TestPrune(t *testing.T) {
+	outbox := newOutbox(t)
+	old := time.Now().UTC().Add(-2 * time.Hour)
+	messages := []*domain.EventMessage{
+		{ID: "delivered", Type: domain.EventSeatReleased, OccurredAt: old, Payload: []byte(`{}`),
+			Subscribers: []string{"async"}, Delivered: map[string]time.Time{"async": old}},
+		{ID: "unsubscribed", Type: domain.EventSeatReleased, OccurredAt: old, Payload: []byte(`{}`)},
+		{ID: "pending", Type: domain.EventSeatReleased, OccurredAt: old, Payload: []byte(`{}`),
+			Subscribers: []string{"async"}},
+		{ID: "recent", Type: domain.EventSeatReleased, OccurredAt: time.Now().UTC(), Payload: []byte(`{}`),
+			Subscribers: []string{"async"}, Delivered: map[string]time.Time{"async": time.Now().UTC()}},
+	}
+	for _, message := range messages {
+		if err := outbox.Append(message); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	bus := events.NewBus(outbox, nil, time.Hour)
+	bus.SubscribeAsync("async", (&recorder{err: errors.New("partner down")}).handle)
+	if err := bus.DeliverPending(); err == nil {
+		t.Fatal("DeliverPending succeeded while the subscriber fails")
+	}
+
+	kept, err := bus.Messages(domain.EventFilter{})
+	if err != nil {
+		t.Fatalf("Messages: %v", err)
+	}
+	var ids []string
+	for _, message := range kept {
+		ids = append(ids, message.ID)
+	}
+	if len(ids) != 2 || ids[0] != "pending" || ids[1] != "recent" {
+		t.Errorf("outbox kept %v, want the pending and the recent events", ids)
+	}
+}
@@ -0,0 +1,244 @@
+package flight
+
+import (
+	"errors"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"strings"
+	"time"
+)
+
+// DefaultCarrierCode is the three-digit airline code that prefixes bag tag numbers
+const DefaultCarrierCode = "999"
+
+// BaggagePolicy defines the free allowance per class and the charges for excess baggage
+type BaggagePolicy struct {
+	Allowances       map[string]domain.BaggageAllowance
+	MaxPieceKg       float64 // Heaviest bag accepted at all
+	ExtraPieceCharge int64   // Per piece above the allowance, in minor currency units
+	OverweightCharge int64   // Per piece above the allowed weight, in minor currency units
+}
+
+// DefaultBaggagePolicy allows one 23 kg bag in economy and two 32 kg bags in business
+var DefaultBaggagePolicy = BaggagePolicy{
+	Allowances: map[string]domain.BaggageAllowance{
+		domain.ClassEconomy:  {Pieces: 1, MaxPieceKg: 23},
+		domain.ClassBusiness: {Pieces: 2, MaxPieceKg: 32},
+	},
+	MaxPieceKg:       32,
+	ExtraPieceCharge: 5000,
+	OverweightCharge: 7500,
+}
+
+// BaggageService checks passenger bags, issues bag tags and tracks bags until they arrive
+type BaggageService struct {
+	flightRepo      ports.FlightRepository
+	reservationRepo ports.ReservationRepository
+	carrierCode     string
+	policy          BaggagePolicy
+}
+
+// NewBaggageService creates a new BaggageService instance issuing tags with the given carrier code
+func NewBaggageService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository,
+	carrierCode string, policy BaggagePolicy) *BaggageService {
+	return &BaggageService{
+		flightRepo:      flightRepo,
+		reservationRepo: reservationRepo,
+		carrierCode:     carrierCode,
+		policy:          policy,
+	}
+}
+
+// Allowance returns the free baggage allowance of a reservation
+func (s *BaggageService) Allowance(reservation *domain.Reservation) domain.BaggageAllowance {
+	if allowance, ok := s.policy.Allowances[reservation.Class]; ok {
+		return allowance
+	}
+	return s.policy.Allowances[domain.ClassEconomy]
+}
+
+// CheckBag checks a bag for a checked-in reservation, tagging it and charging any excess
+// over the class allowance to the reservation's fare
+func (s *BaggageService) CheckBag(reservationID string, weightKg float64) (*domain.Bag, error) {
+	if weightKg <= 0 {
+		return nil, errors.New("bag weight must be positive")
+	}
+	if weightKg > s.policy.MaxPieceKg {
+		return nil, fmt.Errorf("bags over %.0f kg must be sent as cargo", s.policy.MaxPieceKg)
+	}
+
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if !reservation.CheckedIn || !reservation.IsConfirmed() {
+		return nil, fmt.Errorf("reservation %s must be checked in before bags are accepted", reservationID)
+	}
+
+	flight, err := s.flightRepo.FindByID(reservation.ReservationFlightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	if flight.IsClosed() {
+		return nil, fmt.Errorf("flight %s is %s", flight.FlightNumber, flight.CurrentStatus())
+	}
+
+	tagNumber, err := s.nextTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	// Pieces above the allowance and overweight pieces are charged separately
+	allowance := s.Allowance(reservation)
+	var charge int64
+	if len(reservation.Bags) >= allowance.Pieces {
+		charge += s.policy.ExtraPieceCharge
+	}
+	if weightKg > allowance.MaxPieceKg {
+		charge += s.policy.OverweightCharge
+	}
+
+	bag := domain.Bag{
+		TagNumber: tagNumber,
+		WeightKg:  weightKg,
+		Charge:    charge,
+	}
+	bag.MoveTo(domain.BagChecked, flight.DepartureCity, time.Now())
+	reservation.Bags = append(reservation.Bags, bag)
+
+	if charge > 0 {
+		if reservation.Fare == nil {
+			reservation.Fare = &domain.Fare{Currency: DefaultFareRules.Currency}
+		}
+		reservation.Fare.Fees = append(reservation.Fare.Fees, domain.FareComponent{
+			Code:        "XBAG",
+			Description: "Excess baggage " + tagNumber,
+			Amount:      charge,
+		})
+	}
+
+	err = s.reservationRepo.Update(reservation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return &reservation.Bags[len(reservation.Bags)-1], nil
+}
+
+// FindBag finds a bag and its reservation by tag number
+func (s *BaggageService) FindBag(tagNumber string) (*domain.Reservation, *domain.Bag, error) {
+	reservations, err := s.reservationRepo.FindAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, reservation := range reservations {
+		if bag := reservation.FindBag(tagNumber); bag != nil {
+			return reservation, bag, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("bag tag %s %w", tagNumber, domain.ErrNotFound)
+}
+
+// UpdateBagStatus moves a bag along its lifecycle, recording where it happened
+func (s *BaggageService) UpdateBagStatus(tagNumber, status, location string) (*domain.Bag, error) {
+	reservation, bag, err := s.FindBag(tagNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bag.CanMoveTo(status) {
+		return nil, fmt.Errorf("bag %s is %s and cannot become %s", tagNumber, bag.Status, status)
+	}
+
+	bag.MoveTo(status, location, time.Now())
+
+	err = s.reservationRepo.Update(reservation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return bag, nil
+}
+
+// LoadFlight marks the checked bags of travelling passengers on a flight as loaded and
+// returns how many were loaded. Bags of passengers who are not travelling stay behind.
+func (s *BaggageService) LoadFlight(flightNumber string) (int, error) {
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return 0, fmt.Errorf("flight not found: %w", err)
+	}
+
+	reservations, err := s.reservationRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	loaded := 0
+	now := time.Now()
+	for _, reservation := range reservations {
+		if !reservation.IsConfirmed() {
+			continue
+		}
+
+		changed := false
+		for i := range reservation.Bags {
+			if reservation.Bags[i].Status == domain.BagChecked {
+				reservation.Bags[i].MoveTo(domain.BagLoaded, flight.DepartureCity, now)
+				changed = true
+				loaded++
+			}
+		}
+
+		if changed {
+			if err := s.reservationRepo.Update(reservation); err != nil {
+				return loaded, fmt.Errorf("failed to update reservation: %w", err)
+			}
+		}
+	}
+
+	return loaded, nil
+}
+
+// tagSerials is the number of six-digit bag tag serial numbers, 000000 included though never issued
+const tagSerials = 1000000
+
+// nextTagNumber issues the next ten-digit bag tag: a leading zero, the carrier code
+// and a six-digit serial number. Serials run up to 999999, then start again at the
+// first one no bag carries, as a tag number must find a single bag.
+func (s *BaggageService) nextTagNumber() (string, error) {
+	reservations, err := s.reservationRepo.FindAll()
+	if err != nil {
+		return "", err
+	}
+
+	prefix := "0" + s.carrierCode
+	issued := make(map[int]bool)
+	last := 0
+	for _, reservation := range reservations {
+		for _, bag := range reservation.Bags {
+			if !strings.HasPrefix(bag.TagNumber, prefix) {
+				continue
+			}
+			var n int
+			if _, err := fmt.Sscanf(bag.TagNumber[len(prefix):], "%d", &n); err == nil {
+				issued[n] = true
+				if n > last {
+					last = n
+				}
+			}
+		}
+	}
+
+	for i := 1; i < tagSerials; i++ {
+		serial := (last+i-1)%(tagSerials-1) + 1
+		if !issued[serial] {
+			return fmt.Sprintf("%s%06d", prefix, serial), nil
+		}
+	}
+	return "", fmt.Errorf("all bag tag numbers of carrier %s are issued", s.carrierCode)
+}
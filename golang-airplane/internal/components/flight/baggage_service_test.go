@@ -0,0 +1,35 @@
+package flight_test
+
+import (
+	"testing"
+
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+)
+
+func TestTagNumbersAfterWrap(t *testing.T) {
+	f := newFixture(t, 4)
+	reservation := f.book(t, "Ann Lee", domain.ClassEconomy)
+	if err := f.service.CheckIn(reservation.ReservationID, "", ""); err != nil {
+		t.Fatalf("CheckIn: %v", err)
+	}
+
+	// The last serial was issued and the first ones are still on bags
+	reservation, err := f.reservations.FindByID(reservation.ReservationID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	reservation.Bags = []domain.Bag{{TagNumber: "0999999999"}, {TagNumber: "0999000001"}, {TagNumber: "0999000002"}}
+	if err := f.reservations.Update(reservation); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	baggage := flight.NewBaggageService(f.flights, f.reservations, flight.DefaultCarrierCode, flight.DefaultBaggagePolicy)
+	bag, err := baggage.CheckBag(reservation.ReservationID, 20)
+	if err != nil {
+		t.Fatalf("CheckBag: %v", err)
+	}
+	if bag.TagNumber != "0999000003" {
+		t.Errorf("tag %s issued after the last serial, want the first free one, 0999000003", bag.TagNumber)
+	}
+}
@@ -0,0 +1,294 @@
+package flight
+
+import (
+	"errors"
+	"fmt"
+	"golang-airplane/internal/components/boardingpass"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"time"
+)
+
+// Boarding scan rejections
+var (
+	ErrBoardingClosed = errors.New("boarding is closed")
+	ErrWrongFlight    = errors.New("boarding pass is for another flight")
+	ErrAlreadyBoarded = errors.New("passenger has already boarded")
+	ErrGroupNotCalled = errors.New("boarding group has not been called")
+)
+
+// GroupStatus counts the checked-in and boarded passengers of a boarding group
+type GroupStatus struct {
+	Group     int
+	CheckedIn int
+	Boarded   int
+}
+
+// BoardingStatus shows the progress of boarding at the gate
+type BoardingStatus struct {
+	Session *domain.BoardingSession
+	Groups  []GroupStatus
+}
+
+// BoardingService controls boarding at the gate: calling groups, scanning boarding
+// passes and reconciling checked-in against boarded passengers when boarding closes
+type BoardingService struct {
+	flightRepo      ports.FlightRepository
+	reservationRepo ports.ReservationRepository
+	boardingRepo    ports.BoardingSessionRepository
+	passes          *boardingpass.Service
+}
+
+// NewBoardingService creates a new BoardingService instance
+func NewBoardingService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository,
+	boardingRepo ports.BoardingSessionRepository, passes *boardingpass.Service) *BoardingService {
+	return &BoardingService{
+		flightRepo:      flightRepo,
+		reservationRepo: reservationRepo,
+		boardingRepo:    boardingRepo,
+		passes:          passes,
+	}
+}
+
+// OpenBoarding starts boarding a flight and calls the first group. With the rows
+// scheme, economy is split into economyGroups groups boarded from the back.
+func (s *BoardingService) OpenBoarding(flightNumber, scheme string, economyGroups int) (*domain.BoardingSession, error) {
+	if scheme != domain.BoardingByClass && scheme != domain.BoardingByRows {
+		return nil, fmt.Errorf("unknown boarding scheme %q", scheme)
+	}
+	if scheme == domain.BoardingByRows && economyGroups < 1 {
+		return nil, errors.New("the rows scheme needs at least one economy group")
+	}
+
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	if flight.CurrentStatus() == domain.FlightCancelled || flight.CurrentStatus() == domain.FlightDeparted {
+		return nil, fmt.Errorf("flight %s is %s", flightNumber, flight.CurrentStatus())
+	}
+
+	if session, err := s.boardingRepo.FindByFlightNumber(flightNumber); err == nil {
+		if session.IsOpen() {
+			return nil, fmt.Errorf("boarding of flight %s is already open", flightNumber)
+		}
+		return nil, fmt.Errorf("%w for flight %s", ErrBoardingClosed, flightNumber)
+	}
+
+	session := &domain.BoardingSession{
+		FlightNumber:  flightNumber,
+		Scheme:        scheme,
+		EconomyGroups: economyGroups,
+		CalledGroup:   1,
+		OpenedAt:      time.Now(),
+	}
+
+	err = s.boardingRepo.Save(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save boarding session: %w", err)
+	}
+
+	flight.Status = domain.FlightBoarding
+	err = s.flightRepo.Update(flight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update flight: %w", err)
+	}
+
+	return session, nil
+}
+
+// CallNextGroup calls the next boarding group of a flight and returns its number
+func (s *BoardingService) CallNextGroup(flightNumber string) (int, error) {
+	session, err := s.openSession(flightNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	if session.CalledGroup >= session.GroupCount() {
+		return 0, fmt.Errorf("all %d boarding groups of flight %s have been called", session.GroupCount(), flightNumber)
+	}
+
+	session.CalledGroup++
+	return session.CalledGroup, s.boardingRepo.Save(session)
+}
+
+// Scan boards the passenger of a scanned boarding pass at the gate of a flight
+func (s *BoardingService) Scan(flightNumber, data string) (*domain.Reservation, error) {
+	reservation, passFlight, err := s.passes.Scan(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if passFlight.FlightNumber != flightNumber {
+		return nil, fmt.Errorf("%w: reservation %s is on flight %s", ErrWrongFlight, reservation.ReservationID, passFlight.FlightNumber)
+	}
+
+	return s.board(passFlight, reservation)
+}
+
+// Board boards a passenger by reservation ID, for passes that cannot be scanned
+func (s *BoardingService) Board(flightNumber, reservationID string) (*domain.Reservation, error) {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if reservation.ReservationFlightNumber != flightNumber {
+		return nil, fmt.Errorf("%w: reservation %s is on flight %s", ErrWrongFlight, reservationID, reservation.ReservationFlightNumber)
+	}
+	if !reservation.CheckedIn || !reservation.IsConfirmed() {
+		return nil, fmt.Errorf("reservation %s is not checked in", reservationID)
+	}
+
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	return s.board(flight, reservation)
+}
+
+// Status returns the progress of boarding per group
+func (s *BoardingService) Status(flightNumber string) (*BoardingStatus, error) {
+	session, err := s.boardingRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	reservations, err := s.reservationRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &BoardingStatus{Session: session, Groups: make([]GroupStatus, session.GroupCount())}
+	for i := range status.Groups {
+		status.Groups[i].Group = i + 1
+	}
+
+	for _, reservation := range reservations {
+		if !reservation.CheckedIn || !reservation.IsConfirmed() {
+			continue
+		}
+
+		group := &status.Groups[session.GroupOf(reservation, flight.SeatRows())-1]
+		group.CheckedIn++
+		if reservation.IsBoarded() {
+			group.Boarded++
+		}
+	}
+
+	return status, nil
+}
+
+// CloseBoarding ends boarding of a flight, offloading checked-in passengers who did not
+// board as no-shows and recording the reconciliation
+func (s *BoardingService) CloseBoarding(flightNumber string) (*domain.BoardingReconciliation, error) {
+	session, err := s.openSession(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	reservations, err := s.reservationRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := &domain.BoardingReconciliation{ClosedAt: time.Now()}
+	for _, reservation := range reservations {
+		if !reservation.CheckedIn || !reservation.IsConfirmed() {
+			continue
+		}
+
+		reconciliation.CheckedIn++
+		if reservation.IsBoarded() {
+			reconciliation.Boarded++
+			continue
+		}
+
+		// No-shows lose their seat and their check-in
+		if reservation.SeatLocation != "" {
+			flight.SeatList[reservation.SeatLocation] = true
+		}
+		flight.AvailableSeat++
+		reservation.Status = domain.ReservationOffloaded
+		reservation.CheckedIn = false
+		reservation.SeatLocation = ""
+
+		err = s.reservationRepo.Update(reservation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to offload reservation %s: %w", reservation.ReservationID, err)
+		}
+		reconciliation.Offloaded = append(reconciliation.Offloaded, reservation.ReservationID)
+	}
+
+	if len(reconciliation.Offloaded) > 0 {
+		err = s.flightRepo.Update(flight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update flight: %w", err)
+		}
+	}
+
+	session.ClosedAt = &reconciliation.ClosedAt
+	session.Reconciliation = reconciliation
+
+	err = s.boardingRepo.Save(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save boarding session: %w", err)
+	}
+
+	return reconciliation, nil
+}
+
+// board records that a checked-in passenger has boarded
+func (s *BoardingService) board(flight *domain.Flight, reservation *domain.Reservation) (*domain.Reservation, error) {
+	session, err := s.openSession(flight.FlightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.IsBoarded() {
+		return nil, fmt.Errorf("%w: reservation %s boarded at %s", ErrAlreadyBoarded,
+			reservation.ReservationID, reservation.BoardedAt.Format("15:04:05"))
+	}
+
+	group := session.GroupOf(reservation, flight.SeatRows())
+	if group > session.CalledGroup {
+		return nil, fmt.Errorf("%w: reservation %s is in group %d, boarding group %d", ErrGroupNotCalled,
+			reservation.ReservationID, group, session.CalledGroup)
+	}
+
+	now := time.Now()
+	reservation.BoardedAt = &now
+
+	err = s.reservationRepo.Update(reservation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// openSession returns the boarding session of a flight if boarding is in progress
+func (s *BoardingService) openSession(flightNumber string) (*domain.BoardingSession, error) {
+	session, err := s.boardingRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !session.IsOpen() {
+		return nil, fmt.Errorf("%w for flight %s", ErrBoardingClosed, flightNumber)
+	}
+
+	return session, nil
+}
@@ -0,0 +1,45 @@
+package flight_test
+
+import (
+	"testing"
+
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/storage/json"
+)
+
+func TestCloseBoardingOffloadsNoShows(t *testing.T) {
+	f := newFixture(t, 4)
+	noShow := f.book(t, "Ann Lee", domain.ClassEconomy)
+	if err := f.service.CheckIn(noShow.ReservationID, "1A", ""); err != nil {
+		t.Fatalf("CheckIn: %v", err)
+	}
+
+	boarding := flight.NewBoardingService(f.flights, f.reservations, json.NewBoardingSessionRepository(f.storage), nil)
+	if _, err := boarding.OpenBoarding("F1000", domain.BoardingByClass, 0); err != nil {
+		t.Fatalf("OpenBoarding: %v", err)
+	}
+	reconciliation, err := boarding.CloseBoarding("F1000")
+	if err != nil {
+		t.Fatalf("CloseBoarding: %v", err)
+	}
+	if len(reconciliation.Offloaded) != 1 || reconciliation.Offloaded[0] != noShow.ReservationID {
+		t.Errorf("reconciliation offloaded %v, want the passenger who did not board", reconciliation.Offloaded)
+	}
+
+	noShow, err = f.reservations.FindByID(noShow.ReservationID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if noShow.Status != domain.ReservationOffloaded || noShow.CheckedIn || noShow.SeatLocation != "" {
+		t.Errorf("no-show is %s, checked in %v on %q, want offloaded without check-in or seat", noShow.Status,
+			noShow.CheckedIn, noShow.SeatLocation)
+	}
+	flight1000, err := f.flights.FindByID("F1000")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if !flight1000.SeatList["1A"] {
+		t.Error("the no-show's seat was not freed")
+	}
+}
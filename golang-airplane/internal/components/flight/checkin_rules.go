@@ -0,0 +1,137 @@
+package flight
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"time"
+)
+
+// CheckInRules decides whether a reservation may be checked in
+type CheckInRules struct {
+	policyRepo ports.CheckInPolicyRepository
+}
+
+// NewCheckInRules creates a new CheckInRules instance
+func NewCheckInRules(policyRepo ports.CheckInPolicyRepository) *CheckInRules {
+	return &CheckInRules{policyRepo: policyRepo}
+}
+
+// SetPolicy configures the check-in window and required documents of a route
+func (r *CheckInRules) SetPolicy(departureCity, destinationCity string, opensMinutesBefore, closesMinutesBefore int,
+	requiredDocuments []string) (*domain.CheckInPolicy, error) {
+	if departureCity == "" || destinationCity == "" {
+		return nil, domain.Rejectf("departure and destination city cannot be empty")
+	}
+	if closesMinutesBefore < 0 || opensMinutesBefore <= closesMinutesBefore {
+		return nil, domain.Rejectf("check-in must open before it closes, and close no later than departure")
+	}
+
+	for _, document := range requiredDocuments {
+		switch document {
+		case domain.DocumentPassport, domain.DocumentVisa, domain.DocumentIDCard:
+		default:
+			return nil, domain.Rejectf("unknown document type %q", document)
+		}
+	}
+
+	policy := &domain.CheckInPolicy{
+		DepartureCity:       departureCity,
+		DestinationCity:     destinationCity,
+		OpensMinutesBefore:  opensMinutesBefore,
+		ClosesMinutesBefore: closesMinutesBefore,
+		RequiredDocuments:   requiredDocuments,
+	}
+
+	err := r.policyRepo.Save(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save check-in policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// PolicyFor returns the check-in policy of a flight's route, or the default policy
+func (r *CheckInRules) PolicyFor(flight *domain.Flight) (domain.CheckInPolicy, error) {
+	policies, err := r.policyRepo.FindAll()
+	if err != nil {
+		return domain.CheckInPolicy{}, err
+	}
+
+	for _, policy := range policies {
+		if policy.AppliesTo(flight) {
+			return *policy, nil
+		}
+	}
+
+	return domain.DefaultCheckInPolicy, nil
+}
+
+// Check verifies that a reservation is eligible for check-in at the given time,
+// returning a *domain.CheckInRejection when it is not
+func (r *CheckInRules) Check(reservation *domain.Reservation, flight *domain.Flight, now time.Time) error {
+	reject := func(reason domain.CheckInRejectionReason, format string, args ...interface{}) error {
+		return &domain.CheckInRejection{
+			Reason:        reason,
+			ReservationID: reservation.ReservationID,
+			Message:       fmt.Sprintf(format, args...),
+		}
+	}
+
+	// Flight status
+	switch flight.CurrentStatus() {
+	case domain.FlightCancelled:
+		return reject(domain.CheckInFlightCancelled, "flight %s is cancelled", flight.FlightNumber)
+	case domain.FlightDeparted:
+		return reject(domain.CheckInFlightDeparted, "flight %s has departed", flight.FlightNumber)
+	case domain.FlightClosed:
+		return reject(domain.CheckInClosed, "flight %s is closed", flight.FlightNumber)
+	}
+
+	policy, err := r.PolicyFor(flight)
+	if err != nil {
+		return err
+	}
+
+	// Check-in window
+	opens, closes := policy.Window(flight)
+	if now.Before(opens) {
+		return reject(domain.CheckInNotOpen, "check-in opens at %s", opens.Format("02/01/2006-15:04"))
+	}
+	if !now.Before(closes) {
+		return reject(domain.CheckInClosed, "check-in closed at %s", closes.Format("02/01/2006-15:04"))
+	}
+
+	// Required documents must be valid on the day of travel
+	for _, required := range policy.RequiredDocuments {
+		document := findDocument(reservation, required)
+		if document == nil {
+			return reject(domain.CheckInMissingDocument, "a %s is required on this route", required)
+		}
+		if !document.ExpiryAt.IsZero() && document.ExpiryAt.Before(flight.ArrivalTime) {
+			return reject(domain.CheckInDocumentExpired, "%s %s expires on %s, before the flight arrives", required,
+				document.Number, document.ExpiryAt.Format("02/01/2006"))
+		}
+	}
+
+	return nil
+}
+
+// findDocument returns the passenger's document of a type, counting the identity card
+// given at booking as an id_card document
+func findDocument(reservation *domain.Reservation, documentType string) *domain.TravelDocument {
+	for i := range reservation.Documents {
+		if reservation.Documents[i].Type == documentType {
+			return &reservation.Documents[i]
+		}
+	}
+
+	if documentType == domain.DocumentIDCard && reservation.IdentityCardNumber != 0 {
+		return &domain.TravelDocument{
+			Type:   domain.DocumentIDCard,
+			Number: fmt.Sprint(reservation.IdentityCardNumber),
+		}
+	}
+
+	return nil
+}
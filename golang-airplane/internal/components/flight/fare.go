@@ -0,0 +1,52 @@
+package flight
+
+import "golang-airplane/internal/core/domain"
+
+// TaxRule charges a percentage of the base fare
+type TaxRule struct {
+	Code        string
+	Description string
+	Percent     int64
+}
+
+// FareRules prices reservations from the base fares of a flight
+type FareRules struct {
+	Currency string
+	Taxes    []TaxRule
+	Fees     []domain.FareComponent // Flat fees charged per reservation
+}
+
+// DefaultFareRules applies value added tax and the usual airport charges
+var DefaultFareRules = FareRules{
+	Currency: "USD",
+	Taxes: []TaxRule{
+		{Code: "VAT", Description: "Value added tax", Percent: 10},
+	},
+	Fees: []domain.FareComponent{
+		{Code: "AP", Description: "Airport charge", Amount: 500},
+		{Code: "SC", Description: "Security charge", Amount: 200},
+	},
+}
+
+// Price returns the fare of a class on a flight, or nil if the flight has no fare for it
+func (r FareRules) Price(flight *domain.Flight, class string) *domain.Fare {
+	base, ok := flight.Fares[class]
+	if !ok {
+		return nil
+	}
+
+	fare := &domain.Fare{
+		Currency: r.Currency,
+		Base:     base,
+	}
+	for _, tax := range r.Taxes {
+		fare.Taxes = append(fare.Taxes, domain.FareComponent{
+			Code:        tax.Code,
+			Description: tax.Description,
+			Amount:      base * tax.Percent / 100,
+		})
+	}
+	fare.Fees = append(fare.Fees, r.Fees...)
+
+	return fare
+}
@@ -0,0 +1,344 @@
+package flight
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sync"
+	"time"
+)
+
+// DefaultHoldTTL is how long a hold protects inventory or a seat
+const DefaultHoldTTL = 10 * time.Minute
+
+// HoldService manages temporary holds on flight inventory and seats.
+// A nil *HoldService holds nothing.
+type HoldService struct {
+	flightRepo ports.FlightRepository
+	holdRepo   ports.SeatHoldRepository
+	ttl        time.Duration
+	mutex      sync.Mutex // Serialises changes between sessions and the reaper
+}
+
+// NewHoldService creates a new HoldService instance
+func NewHoldService(flightRepo ports.FlightRepository, holdRepo ports.SeatHoldRepository, ttl time.Duration) *HoldService {
+	return &HoldService{
+		flightRepo: flightRepo,
+		holdRepo:   holdRepo,
+		ttl:        ttl,
+	}
+}
+
+// NewSessionToken generates a random token identifying a booking or check-in session
+func NewSessionToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// HoldSeat reserves a specific available seat of a flight for a session
+func (h *HoldService) HoldSeat(sessionToken, flightNumber, seat string) (*domain.SeatHold, error) {
+	if h == nil {
+		return nil, fmt.Errorf("seat holds are not enabled")
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	flight, err := h.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	if available, exists := flight.SeatList[seat]; !exists || !available {
+		return nil, domain.Rejectf("seat %s is not available", seat)
+	}
+	if flight.IsSeatBlocked(seat) {
+		return nil, domain.Rejectf("seat %s is blocked", seat)
+	}
+
+	holds, err := h.activeHolds(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hold := range holds {
+		if hold.Seat != seat {
+			continue
+		}
+		if hold.SessionToken != sessionToken {
+			return nil, domain.Rejectf("seat %s is held by another session until %s", seat, hold.ExpiresAt.Format("15:04:05"))
+		}
+
+		// Holding the same seat again extends the hold
+		hold.ExpiresAt = time.Now().Add(h.ttl)
+		return hold, h.holdRepo.Save(hold)
+	}
+
+	return h.create(sessionToken, flightNumber, seat, 1)
+}
+
+// Release removes a hold of a session
+func (h *HoldService) Release(sessionToken, holdID string) error {
+	if h == nil {
+		return nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	holds, err := h.holdRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for _, hold := range holds {
+		if hold.ID == holdID {
+			if hold.SessionToken != sessionToken {
+				return domain.Rejectf("hold %s belongs to another session", holdID)
+			}
+			return h.holdRepo.Delete(holdID)
+		}
+	}
+
+	return nil
+}
+
+// ReleaseSession removes every hold of a session
+func (h *HoldService) ReleaseSession(sessionToken string) error {
+	if h == nil {
+		return nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	holds, err := h.holdRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, hold := range holds {
+		if hold.SessionToken == sessionToken {
+			ids = append(ids, hold.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return h.holdRepo.Delete(ids...)
+}
+
+// ReapExpired removes expired holds and returns how many were removed
+func (h *HoldService) ReapExpired() (int, error) {
+	if h == nil {
+		return 0, nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	holds, err := h.holdRepo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var ids []string
+	for _, hold := range holds {
+		if !hold.IsActive(now) {
+			ids = append(ids, hold.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return len(ids), h.holdRepo.Delete(ids...)
+}
+
+// StartReaper releases expired holds every interval until the context is cancelled
+func (h *HoldService) StartReaper(ctx context.Context, interval time.Duration, onError func(err error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := h.ReapExpired(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// HeldInventory returns the inventory of a flight held by sessions other than the given one
+func (h *HoldService) HeldInventory(flightNumber, exceptSession string) (int, error) {
+	if h == nil {
+		return 0, nil
+	}
+
+	holds, err := h.activeHolds(flightNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	held := 0
+	for _, hold := range holds {
+		if hold.Seat == "" && hold.SessionToken != exceptSession {
+			held += hold.Quantity
+		}
+	}
+
+	return held, nil
+}
+
+// HeldSeats returns the seats of a flight held by sessions other than the given one
+func (h *HoldService) HeldSeats(flightNumber, exceptSession string) (map[string]bool, error) {
+	seats := make(map[string]bool)
+	if h == nil {
+		return seats, nil
+	}
+
+	holds, err := h.activeHolds(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hold := range holds {
+		if hold.Seat != "" && hold.SessionToken != exceptSession {
+			seats[hold.Seat] = true
+		}
+	}
+
+	return seats, nil
+}
+
+// holdInventory reserves unassigned inventory of a flight for a session, given how
+// many seats are still bookable before any hold is taken into account
+func (h *HoldService) holdInventory(sessionToken, flightNumber string, quantity, bookable int) (*domain.SeatHold, error) {
+	if h == nil {
+		return nil, fmt.Errorf("seat holds are not enabled")
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if quantity <= 0 {
+		return nil, domain.Rejectf("quantity must be positive")
+	}
+
+	holds, err := h.activeHolds(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session's own inventory hold is replaced
+	var own *domain.SeatHold
+	for _, hold := range holds {
+		if hold.Seat != "" {
+			continue
+		}
+		if hold.SessionToken == sessionToken {
+			own = hold
+		} else {
+			bookable -= hold.Quantity
+		}
+	}
+
+	if bookable < quantity {
+		return nil, fmt.Errorf("%w for flight %s: only %d seat(s) not held by other sessions", ErrNoSeatsAvailable, flightNumber, bookable)
+	}
+
+	if own != nil {
+		own.Quantity = quantity
+		own.ExpiresAt = time.Now().Add(h.ttl)
+		return own, h.holdRepo.Save(own)
+	}
+
+	return h.create(sessionToken, flightNumber, "", quantity)
+}
+
+// consume uses up one unit of a session's hold on a flight's inventory or on a seat
+func (h *HoldService) consume(sessionToken, flightNumber, seat string) error {
+	if h == nil || sessionToken == "" {
+		return nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	holds, err := h.activeHolds(flightNumber)
+	if err != nil {
+		return err
+	}
+
+	for _, hold := range holds {
+		if hold.SessionToken != sessionToken || hold.Seat != seat {
+			continue
+		}
+
+		hold.Quantity--
+		if hold.Quantity <= 0 {
+			return h.holdRepo.Delete(hold.ID)
+		}
+		return h.holdRepo.Save(hold)
+	}
+
+	return nil
+}
+
+// create stores a new hold
+func (h *HoldService) create(sessionToken, flightNumber, seat string, quantity int) (*domain.SeatHold, error) {
+	token, err := NewSessionToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	hold := &domain.SeatHold{
+		ID:           "H" + token[:12],
+		SessionToken: sessionToken,
+		FlightNumber: flightNumber,
+		Seat:         seat,
+		Quantity:     quantity,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(h.ttl),
+	}
+
+	err = h.holdRepo.Save(hold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save hold: %w", err)
+	}
+
+	return hold, nil
+}
+
+// activeHolds returns the unexpired holds of a flight
+func (h *HoldService) activeHolds(flightNumber string) ([]*domain.SeatHold, error) {
+	holds, err := h.holdRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var active []*domain.SeatHold
+	for _, hold := range holds {
+		if hold.FlightNumber == flightNumber && hold.IsActive(now) {
+			active = append(active, hold)
+		}
+	}
+
+	return active, nil
+}
@@ -0,0 +1,234 @@
+package flight
+
+import (
+	"errors"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrDeniedBoarding is returned when a passenger loses their seat on an oversold flight
+var ErrDeniedBoarding = errors.New("denied boarding")
+
+// minNoShowSample is the number of past reservations needed before the no-show rate is trusted
+const minNoShowSample = 20
+
+// OverbookingService decides how far flights may be sold above capacity and who
+// gives up their seat when more passengers check in than there are seats
+type OverbookingService struct {
+	flightRepo      ports.FlightRepository
+	reservationRepo ports.ReservationRepository
+	policyRepo      ports.OverbookingPolicyRepository
+}
+
+// NewOverbookingService creates a new OverbookingService instance
+func NewOverbookingService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository,
+	policyRepo ports.OverbookingPolicyRepository) *OverbookingService {
+	return &OverbookingService{
+		flightRepo:      flightRepo,
+		reservationRepo: reservationRepo,
+		policyRepo:      policyRepo,
+	}
+}
+
+// SetPolicy configures the overbooking allowance of a route
+func (s *OverbookingService) SetPolicy(departureCity, destinationCity string, limit int, fromHistory bool) (*domain.OverbookingPolicy, error) {
+	if departureCity == "" || destinationCity == "" {
+		return nil, domain.Rejectf("departure and destination city cannot be empty")
+	}
+	if limit < 0 {
+		return nil, domain.Rejectf("overbooking limit cannot be negative")
+	}
+
+	policy := &domain.OverbookingPolicy{
+		DepartureCity:   departureCity,
+		DestinationCity: destinationCity,
+		Limit:           limit,
+		FromHistory:     fromHistory,
+	}
+
+	err := s.policyRepo.Save(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save overbooking policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// GetPolicy retrieves the overbooking policy of a flight's route, or nil if the route has none
+func (s *OverbookingService) GetPolicy(flight *domain.Flight) (*domain.OverbookingPolicy, error) {
+	policies, err := s.policyRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policies {
+		if policy.AppliesTo(flight) {
+			return policy, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// NoShowStats computes the share of reservations on departed flights of a route whose passenger never
+// checked in or was offloaded at the gate
+func (s *OverbookingService) NoShowStats(departureCity, destinationCity string) (domain.NoShowStats, error) {
+	var stats domain.NoShowStats
+
+	flights, err := s.flightRepo.FindAll()
+	if err != nil {
+		return stats, err
+	}
+
+	now := time.Now()
+	for _, flight := range flights {
+		if !strings.EqualFold(flight.DepartureCity, departureCity) ||
+			!strings.EqualFold(flight.DestinationCity, destinationCity) || flight.DepartureTime.After(now) {
+			continue
+		}
+
+		reservations, err := s.reservationRepo.FindByFlightNumber(flight.FlightNumber)
+		if err != nil {
+			return stats, err
+		}
+
+		stats.Flights++
+		for _, reservation := range reservations {
+			// Passengers offloaded at the gate checked in but never flew
+			if !reservation.IsConfirmed() && reservation.Status != domain.ReservationOffloaded {
+				continue
+			}
+			stats.Reservations++
+			if !reservation.CheckedIn {
+				stats.NoShows++
+			}
+		}
+	}
+
+	if stats.Reservations > 0 {
+		stats.Rate = float64(stats.NoShows) / float64(stats.Reservations)
+	}
+
+	return stats, nil
+}
+
+// AuthorizedOverbook returns how many seats of a flight may be sold above its capacity
+func (s *OverbookingService) AuthorizedOverbook(flight *domain.Flight) (int, error) {
+	policy, err := s.GetPolicy(flight)
+	if err != nil || policy == nil {
+		return 0, err
+	}
+
+	if !policy.FromHistory {
+		return policy.Limit, nil
+	}
+
+	stats, err := s.NoShowStats(flight.DepartureCity, flight.DestinationCity)
+	if err != nil {
+		return 0, err
+	}
+
+	// Too little history to rely on
+	if stats.Reservations < minNoShowSample {
+		return 0, nil
+	}
+
+	allowance := int(stats.Rate * float64(flight.FlightCapacity))
+	if policy.Limit > 0 && allowance > policy.Limit {
+		allowance = policy.Limit
+	}
+
+	return allowance, nil
+}
+
+// Volunteer records that a passenger is willing to give up their seat if the flight is oversold
+func (s *OverbookingService) Volunteer(reservationID string) error {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if !reservation.IsConfirmed() {
+		return domain.Rejectf("reservation %s is %s", reservationID, reservation.Status)
+	}
+
+	reservation.Volunteer = true
+	return s.reservationRepo.Update(reservation)
+}
+
+// ResolveOversale runs the denied-boarding workflow when a passenger checks in on a
+// flight with no free seat left. Volunteers give up their seat first; otherwise
+// economy passengers are offloaded before business ones, last booked first. It
+// returns the passenger to offload without changing anything, so the check-in
+// can still be rejected before anyone gives up their seat; Offload then stores
+// the outcome.
+func (s *OverbookingService) ResolveOversale(flight *domain.Flight, claimant *domain.Reservation) (*domain.Reservation, error) {
+	reservations, err := s.reservationRepo.FindByFlightNumber(flight.FlightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Everyone holding a seat competes with the claimant
+	candidates := []*domain.Reservation{claimant}
+	for _, reservation := range reservations {
+		if reservation.ReservationID != claimant.ReservationID && reservation.IsConfirmed() &&
+			reservation.CheckedIn && reservation.SeatLocation != "" {
+			candidates = append(candidates, reservation)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := boardingPriority(candidates[i]), boardingPriority(candidates[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return candidates[i].ReservationTime.After(candidates[j].ReservationTime)
+	})
+	return candidates[0], nil
+}
+
+// Offload denies boarding to a passenger of an oversold flight and frees their seat.
+// It returns ErrDeniedBoarding if the passenger is the claimant, who then never had
+// a seat, once the denial is stored.
+func (s *OverbookingService) Offload(flight *domain.Flight, denied, claimant *domain.Reservation) error {
+	if denied.SeatLocation != "" {
+		flight.SeatList[denied.SeatLocation] = true
+	}
+	flight.AvailableSeat++
+
+	err := s.flightRepo.Update(flight)
+	if err != nil {
+		return fmt.Errorf("failed to update flight: %w", err)
+	}
+
+	denied.Status = domain.ReservationDenied
+	denied.SeatLocation = ""
+	denied.CheckedIn = false
+
+	err = s.reservationRepo.Update(denied)
+	if err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	if denied.ReservationID == claimant.ReservationID {
+		return fmt.Errorf("%w: flight %s is oversold and reservation %s was offloaded",
+			ErrDeniedBoarding, flight.FlightNumber, claimant.ReservationID)
+	}
+
+	return nil
+}
+
+// boardingPriority ranks passengers for denied boarding; the lowest rank is offloaded first
+func boardingPriority(reservation *domain.Reservation) int {
+	switch {
+	case reservation.Volunteer:
+		return 0
+	case reservation.Class == domain.ClassBusiness:
+		return 2
+	}
+	return 1
+}
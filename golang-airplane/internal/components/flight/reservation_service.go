@@ -1,40 +1,115 @@
 package flight
 
 import (
+	"errors"
 	"fmt"
 	"golang-airplane/internal/core/domain"
 	"golang-airplane/internal/core/ports"
+	"time"
 )
 
+// ErrNoSeatsAvailable is returned when booking a flight that is sold out
+var ErrNoSeatsAvailable = errors.New("no available seats")
+
 // ReservationService implements the ReservationService interface
 type ReservationService struct {
 	flightRepo      ports.FlightRepository
 	reservationRepo ports.ReservationRepository
+	overbooking     *OverbookingService
+	holds           *HoldService
+	checkInRules    *CheckInRules
+	fareRules       FareRules
+	events          ports.EventPublisher
 }
 
-// NewReservationService creates a new ReservationService instance
-func NewReservationService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository) *ReservationService {
+// NewReservationService creates a new ReservationService instance; overbooking may be nil
+// to never sell above capacity, holds may be nil to disable seat holds and checkInRules
+// may be nil to allow check-in at any time, and events may be nil to publish no domain events
+func NewReservationService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository,
+	overbooking *OverbookingService, holds *HoldService, checkInRules *CheckInRules,
+	events ports.EventPublisher) *ReservationService {
 	return &ReservationService{
 		flightRepo:      flightRepo,
 		reservationRepo: reservationRepo,
+		overbooking:     overbooking,
+		holds:           holds,
+		checkInRules:    checkInRules,
+		fareRules:       DefaultFareRules,
+		events:          events,
+	}
+}
+
+// HoldInventory reserves seats of a flight for a booking session until they are booked or the hold expires
+func (s *ReservationService) HoldInventory(sessionToken, flightNumber string, quantity int) (*domain.SeatHold, error) {
+	if s.holds == nil {
+		return nil, errors.New("seat holds are not enabled")
+	}
+	
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+	
+	bookable, err := s.bookableSeats(flight)
+	if err != nil {
+		return nil, err
 	}
+	
+	return s.holds.holdInventory(sessionToken, flightNumber, quantity, bookable)
+}
+
+// BookFlight creates a new reservation for a flight, using up a seat held by the
+// booking session if there is one; the session token may be empty
+func (s *ReservationService) BookFlight(name, address string, phoneNumber, identityCardNumber int64, flightNumber, class, sessionToken string) (*domain.Reservation, error) {
+	var reservation *domain.Reservation
+	err := atomically(s.events, func() error {
+		var err error
+		reservation, err = s.bookFlight(name, address, phoneNumber, identityCardNumber, flightNumber, class, sessionToken)
+		return err
+	})
+	return reservation, err
 }
 
-// BookFlight creates a new reservation for a flight
-func (s *ReservationService) BookFlight(name, address string, phoneNumber, identityCardNumber int64, flightNumber string) (*domain.Reservation, error) {
+// bookFlight makes the change of BookFlight, publishing its event
+func (s *ReservationService) bookFlight(name, address string, phoneNumber, identityCardNumber int64, flightNumber, class, sessionToken string) (*domain.Reservation, error) {
 	// Verify that the flight exists
 	flight, err := s.flightRepo.FindByID(flightNumber)
 	if err != nil {
 		return nil, fmt.Errorf("flight not found: %w", err)
 	}
 	
-	// Check if there are available seats
-	if flight.AvailableSeat <= 0 {
-		return nil, fmt.Errorf("no available seats for flight %s", flightNumber)
+	if flight.IsClosed() {
+		return nil, domain.Rejectf("flight %s is %s and no longer accepts bookings", flightNumber, flight.CurrentStatus())
+	}
+	
+	if class == "" {
+		class = domain.ClassEconomy
+	}
+	if err := domain.CheckClass(class); err != nil {
+		return nil, err
+	}
+	
+	// Check if there are available seats, including the authorized overbooking,
+	// that are not held by other sessions
+	bookable, err := s.bookableSeats(flight)
+	if err != nil {
+		return nil, err
+	}
+	held, err := s.holds.HeldInventory(flightNumber, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	if bookable-held <= 0 {
+		return nil, fmt.Errorf("%w for flight %s", ErrNoSeatsAvailable, flightNumber)
+	}
+	if err := s.checkCabin(flight, class, bookable-flight.AvailableSeat); err != nil {
+		return nil, err
 	}
 	
 	// Create new reservation
 	reservation := domain.NewReservation(name, address, phoneNumber, identityCardNumber, flightNumber)
+	reservation.Class = class
+	reservation.Fare = s.fareRules.Price(flight, reservation.Class)
 	
 	// Save the reservation
 	err = s.reservationRepo.Save(reservation)
@@ -49,16 +124,100 @@ func (s *ReservationService) BookFlight(name, address string, phoneNumber, ident
 		return nil, fmt.Errorf("failed to update flight: %w", err)
 	}
 	
+	// The held seat has been booked
+	err = s.holds.consume(sessionToken, flightNumber, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to release hold: %w", err)
+	}
+	
+	err = publish(s.events, &domain.ReservationBooked{
+		ReservationID: reservation.ReservationID,
+		FlightNumber:  flightNumber,
+		Name:          reservation.Name,
+		Class:         reservation.Class,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish event: %w", err)
+	}
+	
 	return reservation, nil
 }
 
+// BookableSeats returns how many more reservations a flight accepts, including the authorized overbooking
+func (s *ReservationService) BookableSeats(flightNumber string) (int, error) {
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return 0, fmt.Errorf("flight not found: %w", err)
+	}
+	
+	return s.bookableSeats(flight)
+}
+
+// bookableSeats returns the unsold seats of a flight plus its overbooking allowance
+func (s *ReservationService) bookableSeats(flight *domain.Flight) (int, error) {
+	if s.overbooking == nil {
+		return flight.AvailableSeat, nil
+	}
+	
+	allowance, err := s.overbooking.AuthorizedOverbook(flight)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute overbooking allowance: %w", err)
+	}
+	
+	return flight.AvailableSeat + allowance, nil
+}
+
+// checkCabin rejects a booking in a class whose cabin is sold out. The overbooking allowance is sold in the
+// economy cabin, whose passengers are offloaded before business ones.
+func (s *ReservationService) checkCabin(flight *domain.Flight, class string, allowance int) error {
+	// A single cabin is checked with the whole flight
+	if flight.BusinessRows == 0 {
+		return nil
+	}
+	
+	reservations, err := s.reservationRepo.FindByFlightNumber(flight.FlightNumber)
+	if err != nil {
+		return err
+	}
+	
+	capacity := flight.CabinCapacity(class)
+	if class == domain.ClassEconomy {
+		capacity += allowance
+	}
+	if classSold(reservations, class) >= capacity {
+		return domain.Rejectf("the %s cabin of flight %s is sold out", class, flight.FlightNumber)
+	}
+	return nil
+}
+
 // GetReservation retrieves a reservation by its ID
 func (s *ReservationService) GetReservation(reservationID string) (*domain.Reservation, error) {
 	return s.reservationRepo.FindByID(reservationID)
 }
 
-// CheckIn performs the check-in process for a reservation and assigns a seat
-func (s *ReservationService) CheckIn(reservationID string, seatNumber string) error {
+// CheckIn performs the check-in process for a reservation and assigns a seat. Seats held
+// by other sessions are refused; the session token may be empty. Without a seat number the
+// seat that suits the passenger best is assigned for free, while a chosen seat with a price
+// is charged as a fee.
+func (s *ReservationService) CheckIn(reservationID, seatNumber, sessionToken string) error {
+	// A passenger denied boarding is refused the check-in, but the denial is stored
+	var denied error
+	err := atomically(s.events, func() error {
+		err := s.checkIn(reservationID, seatNumber, sessionToken)
+		if errors.Is(err, ErrDeniedBoarding) {
+			denied = err
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return denied
+}
+
+// checkIn makes the change of CheckIn, publishing its event
+func (s *ReservationService) checkIn(reservationID, seatNumber, sessionToken string) error {
 	// Get the reservation
 	reservation, err := s.reservationRepo.FindByID(reservationID)
 	if err != nil {
@@ -67,7 +226,20 @@ func (s *ReservationService) CheckIn(reservationID string, seatNumber string) er
 	
 	// Don't allow check-in if already checked in
 	if reservation.CheckedIn {
-		return fmt.Errorf("reservation %s is already checked in", reservationID)
+		return &domain.CheckInRejection{
+			Reason:        domain.CheckInAlreadyCheckedIn,
+			ReservationID: reservationID,
+			Message:       "the reservation is already checked in",
+		}
+	}
+	
+	// Only confirmed reservations hold a seat
+	if !reservation.IsConfirmed() {
+		return &domain.CheckInRejection{
+			Reason:        domain.CheckInNotConfirmed,
+			ReservationID: reservationID,
+			Message:       fmt.Sprintf("the reservation is %s", reservation.Status),
+		}
 	}
 	
 	// Get the flight for this reservation
@@ -76,9 +248,78 @@ func (s *ReservationService) CheckIn(reservationID string, seatNumber string) er
 		return fmt.Errorf("flight not found: %w", err)
 	}
 	
+	// Check the check-in window, flight status and documents
+	if s.checkInRules != nil {
+		if err := s.checkInRules.Check(reservation, flight, time.Now()); err != nil {
+			return err
+		}
+	}
+	
+	// A seat chosen by the passenger may be charged for
+	chosen := seatNumber != ""
+	
+	heldSeats, err := s.holds.HeldSeats(flight.FlightNumber, sessionToken)
+	if err != nil {
+		return err
+	}
+	
+	// On an oversold flight, someone gives up their seat once none is left
+	var offloaded *domain.Reservation
+	if !flight.HasFreeSeat(heldSeats) && s.overbooking != nil {
+		offloaded, err = s.overbooking.ResolveOversale(flight, reservation)
+		if err != nil {
+			return err
+		}
+		if offloaded.ReservationID == reservation.ReservationID {
+			return s.overbooking.Offload(flight, offloaded, reservation)
+		}
+		
+		// The seat is taken over once the check-in passes every check below
+		chosen = false
+		seatNumber = offloaded.SeatLocation
+		flight.SeatList[seatNumber] = true
+	}
+	
+	// Without a seat number, assign the seat that suits the passenger best
+	if seatNumber == "" {
+		companions, err := s.seatedCompanions(reservation)
+		if err != nil {
+			return err
+		}
+		seatNumber, err = assignSeat(flight, reservation, companions, heldSeats)
+		if err != nil {
+			return err
+		}
+	}
+	
 	// Check if the seat is available
 	if available, exists := flight.SeatList[seatNumber]; !exists || !available {
-		return fmt.Errorf("seat %s is not available", seatNumber)
+		return domain.Rejectf("seat %s is not available", seatNumber)
+	}
+	if flight.IsSeatBlocked(seatNumber) {
+		return domain.Rejectf("seat %s is blocked", seatNumber)
+	}
+	if chosen && !flight.InCabinOf(seatNumber, reservation) {
+		return domain.Rejectf("seat %s is not in the %s cabin", seatNumber, reservation.Class)
+	}
+	
+	// Passengers needing assistance, minors and pets stay out of exit rows
+	if flight.IsExitRow(seatNumber) && reservation.ExitRowRestricted() {
+		return &domain.CheckInRejection{
+			Reason:        domain.CheckInSeatRestricted,
+			ReservationID: reservationID,
+			Message:       fmt.Sprintf("seat %s is in an exit row, which the passenger's special service requests rule out", seatNumber),
+		}
+	}
+	
+	if heldSeats[seatNumber] {
+		return domain.Rejectf("seat %s is being held by another passenger", seatNumber)
+	}
+	
+	if offloaded != nil {
+		if err := s.overbooking.Offload(flight, offloaded, reservation); err != nil {
+			return err
+		}
 	}
 	
 	// Mark the seat as occupied
@@ -90,8 +331,26 @@ func (s *ReservationService) CheckIn(reservationID string, seatNumber string) er
 		return fmt.Errorf("failed to update flight: %w", err)
 	}
 	
+	sequence, err := s.nextCheckInSequence(flight.FlightNumber)
+	if err != nil {
+		return err
+	}
+	
+	// Charge for a chosen seat with a price
+	if charge := seatCharge(flight, reservation, seatNumber); chosen && charge > 0 {
+		if reservation.Fare == nil {
+			reservation.Fare = &domain.Fare{Currency: s.fareRules.Currency}
+		}
+		reservation.Fare.Fees = append(reservation.Fare.Fees, domain.FareComponent{
+			Code:        "SEAT",
+			Description: "Seat selection " + seatNumber,
+			Amount:      charge,
+		})
+	}
+	
 	// Assign the seat and mark as checked in
 	reservation.SeatLocation = seatNumber
+	reservation.CheckInSequence = sequence
 	reservation.CheckIn()
 	
 	// Update the reservation
@@ -100,9 +359,91 @@ func (s *ReservationService) CheckIn(reservationID string, seatNumber string) er
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
 	
+	// The held seat has been taken
+	err = s.holds.consume(sessionToken, flight.FlightNumber, seatNumber)
+	if err != nil {
+		return err
+	}
+	
+	err = publish(s.events, &domain.CheckedIn{
+		ReservationID: reservationID,
+		FlightNumber:  flight.FlightNumber,
+		Seat:          seatNumber,
+		Sequence:      sequence,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	
 	return nil
 }
 
+// seatedCompanions returns the other reservations of the reservation's group that already have a seat
+func (s *ReservationService) seatedCompanions(reservation *domain.Reservation) ([]*domain.Reservation, error) {
+	if reservation.GroupID == "" {
+		return nil, nil
+	}
+	
+	reservations, err := s.reservationRepo.FindByFlightNumber(reservation.ReservationFlightNumber)
+	if err != nil {
+		return nil, err
+	}
+	
+	companions := []*domain.Reservation{}
+	for _, other := range reservations {
+		if other.GroupID == reservation.GroupID && other.ReservationID != reservation.ReservationID && other.SeatLocation != "" {
+			companions = append(companions, other)
+		}
+	}
+	return companions, nil
+}
+
+// nextCheckInSequence returns the check-in sequence number of the next passenger on a flight
+func (s *ReservationService) nextCheckInSequence(flightNumber string) (int, error) {
+	reservations, err := s.reservationRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return 0, err
+	}
+	
+	sequence := 0
+	for _, reservation := range reservations {
+		if reservation.CheckInSequence > sequence {
+			sequence = reservation.CheckInSequence
+		}
+	}
+	
+	return sequence + 1, nil
+}
+
+// AddTravelDocument records a travel document presented by the passenger of a reservation,
+// replacing any earlier document of the same type
+func (s *ReservationService) AddTravelDocument(reservationID string, document domain.TravelDocument) error {
+	switch document.Type {
+	case domain.DocumentPassport, domain.DocumentVisa, domain.DocumentIDCard:
+	default:
+		return domain.Rejectf("unknown document type %q", document.Type)
+	}
+	
+	if document.Number == "" {
+		return domain.Rejectf("document number cannot be empty")
+	}
+	
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+	
+	documents := []domain.TravelDocument{}
+	for _, existing := range reservation.Documents {
+		if existing.Type != document.Type {
+			documents = append(documents, existing)
+		}
+	}
+	reservation.Documents = append(documents, document)
+	
+	return s.reservationRepo.Update(reservation)
+}
+
 // GetReservationsForFlight retrieves all reservations for a specific flight
 func (s *ReservationService) GetReservationsForFlight(flightNumber string) ([]*domain.Reservation, error) {
 	// First check if the flight exists
@@ -113,4 +454,65 @@ func (s *ReservationService) GetReservationsForFlight(flightNumber string) ([]*d
 	
 	// Find all reservations for the flight
 	return s.reservationRepo.FindByFlightNumber(flightNumber)
-}
\ No newline at end of file
+}
+
+// CancelReservation cancels a reservation and releases its seat
+func (s *ReservationService) CancelReservation(reservationID string) error {
+	return atomically(s.events, func() error {
+		return s.cancelReservation(reservationID)
+	})
+}
+
+// cancelReservation makes the change of CancelReservation, publishing its event
+func (s *ReservationService) cancelReservation(reservationID string) error {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+	
+	if reservation.IsCancelled() {
+		return domain.Rejectf("reservation %s is already cancelled", reservationID)
+	}
+	
+	flight, err := s.flightRepo.FindByID(reservation.ReservationFlightNumber)
+	if err != nil {
+		return fmt.Errorf("flight not found: %w", err)
+	}
+	
+	if flight.IsClosed() {
+		return domain.Rejectf("flight %s is %s and its reservations can no longer change", flight.FlightNumber, flight.CurrentStatus())
+	}
+	
+	// Release the seat and the inventory
+	released := &domain.SeatReleased{
+		FlightNumber:  flight.FlightNumber,
+		Seats:         1,
+		ReservationID: reservationID,
+		Seat:          reservation.SeatLocation,
+	}
+	if reservation.SeatLocation != "" {
+		flight.SeatList[reservation.SeatLocation] = true
+	}
+	flight.AvailableSeat++
+	
+	err = s.flightRepo.Update(flight)
+	if err != nil {
+		return fmt.Errorf("failed to update flight: %w", err)
+	}
+	
+	reservation.Status = domain.ReservationCancelled
+	reservation.SeatLocation = ""
+	reservation.CheckedIn = false
+	
+	err = s.reservationRepo.Update(reservation)
+	if err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	
+	err = publish(s.events, released)
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,135 @@
+package flight_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/storage/json"
+)
+
+// fixture is a reservation service over empty JSON storage holding one flight
+type fixture struct {
+	storage      *json.Storage
+	flights      ports.FlightRepository
+	reservations ports.ReservationRepository
+	overbooking  *flight.OverbookingService
+	service      *flight.ReservationService
+}
+
+// newFixture creates a fixture whose flight F1000 has a capacity and no business cabin
+func newFixture(t *testing.T, capacity int) *fixture {
+	t.Helper()
+	storage := json.NewStorage(t.TempDir())
+	flightRepo := json.NewFlightRepository(storage)
+	reservationRepo := json.NewReservationRepository(storage)
+	holds := flight.NewHoldService(flightRepo, json.NewSeatHoldRepository(storage), flight.DefaultHoldTTL)
+	overbooking := flight.NewOverbookingService(flightRepo, reservationRepo, json.NewOverbookingPolicyRepository(storage))
+
+	departure := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	if err := flightRepo.Save(domain.NewFlight("F1000", "Hanoi", "Saigon", departure, departure.Add(2*time.Hour), capacity)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return &fixture{
+		storage:      storage,
+		flights:      flightRepo,
+		reservations: reservationRepo,
+		overbooking:  overbooking,
+		service:      flight.NewReservationService(flightRepo, reservationRepo, overbooking, holds, nil, nil),
+	}
+}
+
+// book books flight F1000 in a class and returns the reservation
+func (f *fixture) book(t *testing.T, name, class string) *domain.Reservation {
+	t.Helper()
+	reservation, err := f.service.BookFlight(name, "1 Main Street", 900000000, 100000000, "F1000", class, "")
+	if err != nil {
+		t.Fatalf("BookFlight: %v", err)
+	}
+	return reservation
+}
+
+func TestBusinessCheckInWithoutBusinessCabin(t *testing.T) {
+	f := newFixture(t, 8)
+	assigned := f.book(t, "Ann Lee", domain.ClassBusiness)
+	chosen := f.book(t, "Bob Tran", domain.ClassBusiness)
+
+	if err := f.service.CheckIn(assigned.ReservationID, "", ""); err != nil {
+		t.Errorf("CheckIn with an assigned seat: %v", err)
+	}
+	if err := f.service.CheckIn(chosen.ReservationID, "2C", ""); err != nil {
+		t.Errorf("CheckIn on seat 2C: %v", err)
+	}
+}
+
+func TestRejectedCheckInOffloadsNobody(t *testing.T) {
+	f := newFixture(t, 1)
+	if _, err := f.overbooking.SetPolicy("Hanoi", "Saigon", 1, false); err != nil {
+		t.Fatalf("SetPolicy: %v", err)
+	}
+	flight1000, err := f.flights.FindByID("F1000")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	flight1000.ExitRows = []int{1}
+	if err := f.flights.Update(flight1000); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	seated := f.book(t, "Ann Lee", domain.ClassEconomy)
+	if err := f.service.CheckIn(seated.ReservationID, "", ""); err != nil {
+		t.Fatalf("CheckIn: %v", err)
+	}
+
+	// The only seat is in an exit row, which a wheelchair passenger may not take
+	claimant := f.book(t, "Bob Tran", domain.ClassBusiness)
+	claimant.SpecialServices = []domain.SpecialServiceRequest{{Code: "WCHR", CreatedAt: time.Now()}}
+	if err := f.reservations.Update(claimant); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := f.service.CheckIn(claimant.ReservationID, "", ""); err == nil {
+		t.Fatal("CheckIn on an exit row seat succeeded for a wheelchair passenger")
+	}
+
+	seated, err = f.reservations.FindByID(seated.ReservationID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if !seated.IsConfirmed() || !seated.CheckedIn || seated.SeatLocation != "1A" {
+		t.Errorf("a rejected check-in offloaded %s: %s, checked in %v on %q", seated.Name, seated.Status,
+			seated.CheckedIn, seated.SeatLocation)
+	}
+}
+
+func TestBookingWithinCabin(t *testing.T) {
+	f := newFixture(t, 8)
+	flight1000, err := f.flights.FindByID("F1000")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	flight1000.BusinessRows = 1
+	if err := f.flights.Update(flight1000); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		f.book(t, fmt.Sprintf("Business %d", i), domain.ClassBusiness)
+	}
+
+	var rejection *domain.Rejection
+	_, err = f.service.BookFlight("Cal Vo", "1 Main Street", 900000000, 100000000, "F1000", domain.ClassBusiness, "")
+	if !errors.As(err, &rejection) {
+		t.Errorf("BookFlight in a full business cabin: %v, want a rejection", err)
+	}
+	_, err = f.service.BookFlight("Cal Vo", "1 Main Street", 900000000, 100000000, "F1000", "First", "")
+	if !errors.As(err, &rejection) {
+		t.Errorf("BookFlight in an unknown class: %v, want a rejection", err)
+	}
+	if reservation := f.book(t, "Cal Vo", ""); reservation.Class != domain.ClassEconomy {
+		t.Errorf("booking without a class is in %s, want %s", reservation.Class, domain.ClassEconomy)
+	}
+}
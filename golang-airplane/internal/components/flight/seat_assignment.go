@@ -0,0 +1,109 @@
+package flight
+
+import (
+	"golang-airplane/internal/core/domain"
+)
+
+// Seat assignment scores; the available seat with the highest score is assigned
+const (
+	scorePosition     = 10 // Window or aisle as preferred
+	scoreExtraLegroom = 5  // Extra legroom as preferred
+	scoreBassinet     = 8  // Bassinet seat for a passenger travelling with an infant
+	scoreSameRow      = 20 // Next to a companion, plus the adjacency bonus below
+	scoreAdjacentSeat = 10
+	scoreNearbyRow    = 6 // Directly in front of or behind a companion
+	penaltyPaidSeat   = 4 // Seats sold at a price are kept for passengers who pay for them
+	penaltyBassinet   = 3 // Bassinet seats are kept for passengers with infants
+)
+
+// assignSeat chooses the best available seat of a flight for a reservation, following the passenger's
+// preference, keeping the reservation next to its group companions and respecting exit-row restrictions
+func assignSeat(flight *domain.Flight, reservation *domain.Reservation, companions []*domain.Reservation,
+	heldSeats map[string]bool) (string, error) {
+	preference := domain.SeatPreference{}
+	if reservation.SeatPreference != nil {
+		preference = *reservation.SeatPreference
+	}
+
+	best, bestScore := "", 0
+	for _, seat := range flight.SeatNumbers() {
+		if !flight.SeatList[seat] || heldSeats[seat] || flight.IsSeatBlocked(seat) {
+			continue
+		}
+		if !flight.InCabinOf(seat, reservation) {
+			continue
+		}
+		if flight.IsExitRow(seat) && reservation.ExitRowRestricted() {
+			continue
+		}
+
+		score := scoreSeat(flight, seat, preference, companions)
+		if best == "" || score > bestScore {
+			best, bestScore = seat, score
+		}
+	}
+
+	if best == "" {
+		return "", domain.Rejectf("no available seat on flight %s suits the passenger", flight.FlightNumber)
+	}
+	return best, nil
+}
+
+// scoreSeat rates how well a seat suits a passenger
+func scoreSeat(flight *domain.Flight, seat string, preference domain.SeatPreference, companions []*domain.Reservation) int {
+	score := 0
+
+	if preference.Position != "" && flight.HasSeatAttribute(seat, preference.Position) {
+		score += scorePosition
+	}
+	if preference.ExtraLegroom && flight.HasSeatAttribute(seat, domain.SeatExtraLegroom) {
+		score += scoreExtraLegroom
+	}
+	if flight.HasSeatAttribute(seat, domain.SeatBassinet) {
+		if preference.Bassinet {
+			score += scoreBassinet
+		} else {
+			score -= penaltyBassinet
+		}
+	}
+	if flight.SeatPrice(seat) > 0 {
+		score -= penaltyPaidSeat
+	}
+
+	// Sit with the closest companion already seated
+	row := (&domain.Reservation{SeatLocation: seat}).SeatRow()
+	letter := domain.SeatLetter(seat)
+	closeness := 0
+	for _, companion := range companions {
+		companionRow := companion.SeatRow()
+		companionLetter := domain.SeatLetter(companion.SeatLocation)
+
+		value := 0
+		switch {
+		case companionRow == row && sameSideOfAisle(letter, companionLetter):
+			value = scoreSameRow + scoreAdjacentSeat
+		case companionRow == row:
+			value = scoreSameRow
+		case companionRow == row-1 || companionRow == row+1:
+			value = scoreNearbyRow
+		}
+		if value > closeness {
+			closeness = value
+		}
+	}
+
+	return score + closeness
+}
+
+// sameSideOfAisle reports whether two seat letters of a row are next to each other without the aisle between them
+func sameSideOfAisle(a, b rune) bool {
+	return (a <= 'B') == (b <= 'B')
+}
+
+// seatCharge returns the price of choosing a seat; business class passengers choose their seat for free
+func seatCharge(flight *domain.Flight, reservation *domain.Reservation, seat string) int64 {
+	if reservation.Class == domain.ClassBusiness {
+		return 0
+	}
+	return flight.SeatPrice(seat)
+}
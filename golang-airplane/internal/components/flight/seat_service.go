@@ -0,0 +1,192 @@
+package flight
+
+import (
+	"errors"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// SeatService configures seat attributes and prices and records the seating wishes of passengers
+type SeatService struct {
+	flightRepo      ports.FlightRepository
+	reservationRepo ports.ReservationRepository
+	holds           *HoldService // Optional; without it no seat shows as held
+}
+
+// NewSeatService creates a new SeatService instance
+func NewSeatService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository, holds *HoldService) *SeatService {
+	return &SeatService{
+		flightRepo:      flightRepo,
+		reservationRepo: reservationRepo,
+		holds:           holds,
+	}
+}
+
+// SeatMap builds the seat map of a flight as seen by a booking session, with the seats held by
+// other sessions and the selected seat, which may be empty
+func (s *SeatService) SeatMap(flightNumber, sessionToken, selected string) (*domain.SeatMap, error) {
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	heldSeats := map[string]bool{}
+	if s.holds != nil {
+		heldSeats, err = s.holds.HeldSeats(flightNumber, sessionToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.NewSeatMap(flight, heldSeats, selected), nil
+}
+
+// SetBusinessRows sets how many front rows of a flight form the business cabin
+func (s *SeatService) SetBusinessRows(flightNumber string, rows int) error {
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+
+	if rows < 0 || rows > flight.SeatRows() {
+		return fmt.Errorf("flight %s has %d rows", flightNumber, flight.SeatRows())
+	}
+
+	flight.BusinessRows = rows
+	return s.flightRepo.Update(flight)
+}
+
+// SetSeatAttributes replaces the configured attributes of a seat; window, aisle and exit row
+// follow from the cabin layout and are not configured per seat
+func (s *SeatService) SetSeatAttributes(flightNumber, seat string, attributes []string) error {
+	for _, attribute := range attributes {
+		if !containsAttribute(domain.ConfigurableSeatAttributes, attribute) {
+			return fmt.Errorf("seat attribute %q cannot be configured", attribute)
+		}
+	}
+
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+
+	available, exists := flight.SeatList[seat]
+	if !exists {
+		return fmt.Errorf("seat %s does not exist on flight %s", seat, flightNumber)
+	}
+	if !available && containsAttribute(attributes, domain.SeatBlocked) {
+		return fmt.Errorf("seat %s is occupied and cannot be blocked", seat)
+	}
+
+	if flight.SeatAttributes == nil {
+		flight.SeatAttributes = make(map[string][]string)
+	}
+	if len(attributes) == 0 {
+		delete(flight.SeatAttributes, seat)
+	} else {
+		flight.SeatAttributes[seat] = attributes
+	}
+
+	return s.flightRepo.Update(flight)
+}
+
+// SetSeatPrice sets the price of choosing a seat with an attribute on a flight, in minor currency units
+func (s *SeatService) SetSeatPrice(flightNumber, attribute string, amount int64) error {
+	if !containsAttribute(domain.SeatAttributeNames, attribute) || attribute == domain.SeatBlocked {
+		return fmt.Errorf("unknown seat attribute %q", attribute)
+	}
+	if amount < 0 {
+		return errors.New("seat price cannot be negative")
+	}
+
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+
+	// The first change starts from the default prices
+	if flight.SeatPrices == nil {
+		flight.SeatPrices = make(map[string]int64)
+		for defaultAttribute, price := range domain.DefaultSeatPrices {
+			flight.SeatPrices[defaultAttribute] = price
+		}
+	}
+	flight.SeatPrices[attribute] = amount
+
+	return s.flightRepo.Update(flight)
+}
+
+// SeatCharge returns what the passenger of a reservation pays to choose a seat
+func (s *SeatService) SeatCharge(reservationID, seat string) (int64, error) {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return 0, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	flight, err := s.flightRepo.FindByID(reservation.ReservationFlightNumber)
+	if err != nil {
+		return 0, fmt.Errorf("flight not found: %w", err)
+	}
+
+	return seatCharge(flight, reservation, seat), nil
+}
+
+// SetPreference records the seat a passenger would like to be given at check-in
+func (s *SeatService) SetPreference(reservationID string, preference domain.SeatPreference) error {
+	switch preference.Position {
+	case "", domain.SeatWindow, domain.SeatAisle, domain.SeatMiddle:
+	default:
+		return fmt.Errorf("unknown seat position %q", preference.Position)
+	}
+
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+
+	reservation.SeatPreference = &preference
+	return s.reservationRepo.Update(reservation)
+}
+
+// GroupReservations links reservations of one flight so they are seated together, and returns the group ID
+func (s *SeatService) GroupReservations(reservationIDs []string) (string, error) {
+	if len(reservationIDs) < 2 {
+		return "", errors.New("a group needs at least two reservations")
+	}
+
+	reservations := make([]*domain.Reservation, 0, len(reservationIDs))
+	for _, reservationID := range reservationIDs {
+		reservation, err := s.reservationRepo.FindByID(reservationID)
+		if err != nil {
+			return "", fmt.Errorf("reservation not found: %w", err)
+		}
+		if len(reservations) > 0 && reservation.ReservationFlightNumber != reservations[0].ReservationFlightNumber {
+			return "", fmt.Errorf("reservation %s is not on flight %s", reservationID, reservations[0].ReservationFlightNumber)
+		}
+		if !reservation.IsConfirmed() {
+			return "", fmt.Errorf("reservation %s is %s", reservationID, reservation.Status)
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	groupID := "G" + reservations[0].ReservationID
+	for _, reservation := range reservations {
+		reservation.GroupID = groupID
+		if err := s.reservationRepo.Update(reservation); err != nil {
+			return "", fmt.Errorf("failed to update reservation: %w", err)
+		}
+	}
+
+	return groupID, nil
+}
+
+// containsAttribute reports whether a list of seat attributes contains an attribute
+func containsAttribute(attributes []string, attribute string) bool {
+	for _, a := range attributes {
+		if a == attribute {
+			return true
+		}
+	}
+	return false
+}
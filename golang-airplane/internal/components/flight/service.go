@@ -12,24 +12,46 @@ import (
 type Service struct {
 	flightRepo      ports.FlightRepository
 	reservationRepo ports.ReservationRepository
+	crewChangeRepo  ports.CrewChangeRepository
+	airplaneRepo    ports.AirplaneRepository
+	holds           *HoldService
+	events          ports.EventPublisher
 }
 
-// NewService creates a new flight service instance
-func NewService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository) *Service {
+// NewService creates a new flight service instance; events may be nil to publish no domain events
+func NewService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository,
+	crewChangeRepo ports.CrewChangeRepository, airplaneRepo ports.AirplaneRepository, holds *HoldService,
+	events ports.EventPublisher) *Service {
 	return &Service{
 		flightRepo:      flightRepo,
 		reservationRepo: reservationRepo,
+		crewChangeRepo:  crewChangeRepo,
+		airplaneRepo:    airplaneRepo,
+		holds:           holds,
+		events:          events,
 	}
 }
 
 // AddFlight adds a new flight
 func (s *Service) AddFlight(flightNumber, departureCity, destinationCity string, 
 	departureTime, arrivalTime time.Time, availableSeat int) (*domain.Flight, error) {
+	var flight *domain.Flight
+	err := atomically(s.events, func() error {
+		var err error
+		flight, err = s.addFlight(flightNumber, departureCity, destinationCity, departureTime, arrivalTime, availableSeat)
+		return err
+	})
+	return flight, err
+}
+
+// addFlight makes the change of AddFlight, publishing its event
+func (s *Service) addFlight(flightNumber, departureCity, destinationCity string, 
+	departureTime, arrivalTime time.Time, availableSeat int) (*domain.Flight, error) {
 	
 	// Check if flight with the same number already exists
 	existingFlight, err := s.flightRepo.FindByID(flightNumber)
 	if err == nil && existingFlight != nil {
-		return nil, fmt.Errorf("flight with number %s already exists", flightNumber)
+		return nil, fmt.Errorf("flight with number %s %w", flightNumber, domain.ErrAlreadyExists)
 	}
 	
 	// Create new flight
@@ -42,6 +64,18 @@ func (s *Service) AddFlight(flightNumber, departureCity, destinationCity string,
 		return nil, fmt.Errorf("failed to save flight: %w", err)
 	}
 	
+	err = publish(s.events, &domain.FlightCreated{
+		FlightNumber:    flight.FlightNumber,
+		DepartureCity:   flight.DepartureCity,
+		DestinationCity: flight.DestinationCity,
+		DepartureTime:   flight.DepartureTime,
+		ArrivalTime:     flight.ArrivalTime,
+		Capacity:        flight.FlightCapacity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish event: %w", err)
+	}
+	
 	return flight, nil
 }
 
@@ -50,14 +84,35 @@ func (s *Service) GetFlight(flightNumber string) (*domain.Flight, error) {
 	return s.flightRepo.FindByID(flightNumber)
 }
 
-// SearchFlights searches for flights by location and date
+// SearchFlights searches for flights by location and date; seats held by booking
+// sessions are not counted as available
 func (s *Service) SearchFlights(location string, date time.Time) ([]*domain.Flight, error) {
 	dateStr := date.Format("02/01/2006")
-	return s.flightRepo.SearchFlights(location, dateStr)
+	flights, err := s.flightRepo.SearchFlights(location, dateStr)
+	if err != nil {
+		return nil, err
+	}
+	
+	for _, flight := range flights {
+		held, err := s.holds.HeldInventory(flight.FlightNumber, "")
+		if err != nil {
+			return nil, err
+		}
+		flight.AvailableSeat -= held
+	}
+	
+	return flights, nil
 }
 
 // AssignCrew assigns crew members to a flight
 func (s *Service) AssignCrew(flightNumber string, crewMembers []domain.Crew) error {
+	return atomically(s.events, func() error {
+		return s.assignCrew(flightNumber, crewMembers)
+	})
+}
+
+// assignCrew makes the change of AssignCrew, publishing its event
+func (s *Service) assignCrew(flightNumber string, crewMembers []domain.Crew) error {
 	// Get the flight
 	flight, err := s.flightRepo.FindByID(flightNumber)
 	if err != nil {
@@ -66,16 +121,334 @@ func (s *Service) AssignCrew(flightNumber string, crewMembers []domain.Crew) err
 	
 	// Verify that the flight doesn't already have a crew assigned
 	if len(flight.CrewMembers) > 0 {
-		return fmt.Errorf("flight %s already has crew assigned", flightNumber)
+		return domain.Rejectf("flight %s already has crew assigned", flightNumber)
+	}
+	
+	// Verify the positions and their minimums
+	for _, member := range crewMembers {
+		if err := domain.CheckCrewPosition(member); err != nil {
+			return err
+		}
+	}
+	if err := domain.ValidateCrew(crewMembers); err != nil {
+		return err
 	}
 	
 	// Assign crew members
 	flight.AssignCrew(crewMembers)
 	
+	// Update flight
+	err = s.flightRepo.Update(flight)
+	if err != nil {
+		return err
+	}
+	
+	err = publish(s.events, &domain.CrewAssigned{FlightNumber: flightNumber, Action: domain.CrewAssign, Crew: flight.CrewMembers})
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	
+	return nil
+}
+
+// AddCrewMember adds a crew member to a flight that already has a crew
+func (s *Service) AddCrewMember(flightNumber string, member domain.Crew, changedBy string) error {
+	return s.editCrew(flightNumber, changedBy, func(flight *domain.Flight) (*domain.CrewChange, error) {
+		if member.Name == "" {
+			return nil, domain.Rejectf("crew member name cannot be empty")
+		}
+		if err := domain.CheckCrewPosition(member); err != nil {
+			return nil, err
+		}
+		if flight.FindCrewMember(member.Name) >= 0 || (member.ID != "" && flight.FindCrewMember(member.ID) >= 0) {
+			return nil, domain.Rejectf("%s is already part of the crew of flight %s", member.Name, flightNumber)
+		}
+
+		crew := append(append([]domain.Crew{}, flight.CrewMembers...), member)
+		flight.AssignCrew(crew)
+
+		return &domain.CrewChange{Action: domain.CrewChangeAdd, Added: &member}, nil
+	})
+}
+
+// RemoveCrewMember removes a crew member, identified by registry ID or name, from a flight
+func (s *Service) RemoveCrewMember(flightNumber, idOrName, changedBy string) error {
+	return s.editCrew(flightNumber, changedBy, func(flight *domain.Flight) (*domain.CrewChange, error) {
+		index := flight.FindCrewMember(idOrName)
+		if index < 0 {
+			return nil, domain.Rejectf("%s is not part of the crew of flight %s", idOrName, flightNumber)
+		}
+		removed := flight.CrewMembers[index]
+
+		crew := append([]domain.Crew{}, flight.CrewMembers[:index]...)
+		crew = append(crew, flight.CrewMembers[index+1:]...)
+		flight.AssignCrew(crew)
+
+		return &domain.CrewChange{Action: domain.CrewChangeRemove, Removed: &removed}, nil
+	})
+}
+
+// SwapCrewMember replaces a crew member of a flight with another one
+func (s *Service) SwapCrewMember(flightNumber, idOrName string, replacement domain.Crew, changedBy string) error {
+	return s.editCrew(flightNumber, changedBy, func(flight *domain.Flight) (*domain.CrewChange, error) {
+		index := flight.FindCrewMember(idOrName)
+		if index < 0 {
+			return nil, domain.Rejectf("%s is not part of the crew of flight %s", idOrName, flightNumber)
+		}
+		if replacement.Name == "" {
+			return nil, domain.Rejectf("crew member name cannot be empty")
+		}
+		if err := domain.CheckCrewPosition(replacement); err != nil {
+			return nil, err
+		}
+		removed := flight.CrewMembers[index]
+
+		// The replacement must not already be on the flight, other than in the slot being swapped
+		for i, crew := range flight.CrewMembers {
+			if i != index && (crew.Matches(replacement.Name) || (replacement.ID != "" && crew.Matches(replacement.ID))) {
+				return nil, domain.Rejectf("%s is already part of the crew of flight %s", replacement.Name, flightNumber)
+			}
+		}
+
+		crew := append([]domain.Crew{}, flight.CrewMembers...)
+		crew[index] = replacement
+		flight.AssignCrew(crew)
+
+		return &domain.CrewChange{Action: domain.CrewChangeSwap, Removed: &removed, Added: &replacement}, nil
+	})
+}
+
+// GetCrewChanges retrieves the crew change audit trail of a flight
+func (s *Service) GetCrewChanges(flightNumber string) ([]*domain.CrewChange, error) {
+	return s.crewChangeRepo.FindByFlightNumber(flightNumber)
+}
+
+// editCrew applies a change to an assigned crew, re-checks the position minimums
+// and records the change in the audit trail
+func (s *Service) editCrew(flightNumber, changedBy string, edit func(flight *domain.Flight) (*domain.CrewChange, error)) error {
+	return atomically(s.events, func() error {
+		return s.applyCrewEdit(flightNumber, changedBy, edit)
+	})
+}
+
+// applyCrewEdit makes the change of editCrew, publishing its event
+func (s *Service) applyCrewEdit(flightNumber, changedBy string, edit func(flight *domain.Flight) (*domain.CrewChange, error)) error {
+	if changedBy == "" {
+		return domain.Rejectf("the person making the change must be recorded")
+	}
+	
+	// Get the flight
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+	
+	if len(flight.CrewMembers) == 0 {
+		return domain.Rejectf("flight %s has no crew assigned yet", flightNumber)
+	}
+	
+	change, err := edit(flight)
+	if err != nil {
+		return err
+	}
+	
+	// Verify the position minimums still hold
+	if err := domain.ValidateCrew(flight.CrewMembers); err != nil {
+		return fmt.Errorf("crew change rejected: %w", err)
+	}
+	
+	// Update flight
+	err = s.flightRepo.Update(flight)
+	if err != nil {
+		return fmt.Errorf("failed to update flight: %w", err)
+	}
+	
+	// Record the change
+	change.FlightNumber = flightNumber
+	change.ChangedBy = changedBy
+	change.ChangedAt = time.Now()
+	err = s.crewChangeRepo.Append(change)
+	if err != nil {
+		return fmt.Errorf("failed to record crew change: %w", err)
+	}
+	
+	err = publish(s.events, &domain.CrewAssigned{FlightNumber: flightNumber, Action: change.Action,
+		Crew: flight.CrewMembers, ChangedBy: changedBy})
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	
+	return nil
+}
+
+// AssignAirplane assigns an airplane to a flight, rejecting airplanes that are
+// too small, in maintenance or operating another flight at the same time
+func (s *Service) AssignAirplane(flightNumber, airplaneID string) error {
+	// Get the flight
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+	
+	airplane, err := s.airplaneRepo.FindByID(airplaneID)
+	if err != nil {
+		return err
+	}
+	
+	if airplane.Capacity < flight.FlightCapacity {
+		return domain.Rejectf("airplane %s has %d seats but flight %s needs %d", airplaneID, airplane.Capacity,
+			flightNumber, flight.FlightCapacity)
+	}
+	
+	// Reject airplanes in maintenance during the flight
+	if event := airplane.MaintenanceDuring(flight.DepartureTime, flight.ArrivalTime); event != nil {
+		return domain.Rejectf("airplane %s is in %s maintenance at %s from %s to %s", airplaneID, event.Type,
+			event.Location, event.Start.Format("02/01/2006-15:04"), event.End.Format("02/01/2006-15:04"))
+	}
+	
+	// Reject airplanes already operating an overlapping flight
+	flights, err := s.flightRepo.FindAll()
+	if err != nil {
+		return err
+	}
+	for _, other := range flights {
+		if other.FlightNumber != flightNumber && other.AirplaneID == airplaneID &&
+			other.DepartureTime.Before(flight.ArrivalTime) && flight.DepartureTime.Before(other.ArrivalTime) {
+			return domain.Rejectf("airplane %s already operates flight %s at that time", airplaneID, other.FlightNumber)
+		}
+	}
+	
+	flight.AirplaneID = airplaneID
+	
 	// Update flight
 	return s.flightRepo.Update(flight)
 }
 
+// ChangeCapacity changes the number of seats of a flight
+func (s *Service) ChangeCapacity(flightNumber string, capacity int) error {
+	return atomically(s.events, func() error {
+		return s.changeCapacity(flightNumber, capacity)
+	})
+}
+
+// changeCapacity makes the change of ChangeCapacity, publishing its event
+func (s *Service) changeCapacity(flightNumber string, capacity int) error {
+	// Get the flight
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+	
+	if flight.AirplaneID != "" {
+		airplane, err := s.airplaneRepo.FindByID(flight.AirplaneID)
+		if err == nil && capacity > airplane.Capacity {
+			return domain.Rejectf("airplane %s only has %d seats", airplane.ID, airplane.Capacity)
+		}
+	}
+	
+	previousAvailable := flight.AvailableSeat
+	if err := flight.Resize(capacity); err != nil {
+		return err
+	}
+	
+	// Update flight
+	err = s.flightRepo.Update(flight)
+	if err != nil {
+		return fmt.Errorf("failed to update flight: %w", err)
+	}
+	
+	if flight.AvailableSeat > previousAvailable {
+		err = publish(s.events, &domain.SeatReleased{FlightNumber: flightNumber, Seats: flight.AvailableSeat - previousAvailable})
+		if err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	
+	return nil
+}
+
+// SetFare sets the base fare of a class on a flight, in minor currency units
+func (s *Service) SetFare(flightNumber, class string, amount int64) error {
+	if err := domain.CheckClass(class); err != nil {
+		return err
+	}
+	if amount < 0 {
+		return domain.Rejectf("fare cannot be negative")
+	}
+	
+	// Get the flight
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+	
+	if flight.Fares == nil {
+		flight.Fares = make(map[string]int64)
+	}
+	flight.Fares[class] = amount
+	
+	// Update flight
+	return s.flightRepo.Update(flight)
+}
+
+// SetGate sets the departure gate of a flight
+func (s *Service) SetGate(flightNumber, gate string) error {
+	// Get the flight
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+	
+	flight.Gate = gate
+	
+	// Update flight
+	return s.flightRepo.Update(flight)
+}
+
+// UpdateStatus changes the operational status of a flight
+func (s *Service) UpdateStatus(flightNumber, status string) error {
+	return atomically(s.events, func() error {
+		return s.updateStatus(flightNumber, status)
+	})
+}
+
+// updateStatus makes the change of UpdateStatus, publishing its event
+func (s *Service) updateStatus(flightNumber, status string) error {
+	switch status {
+	case domain.FlightScheduled, domain.FlightDelayed, domain.FlightBoarding, domain.FlightCancelled, domain.FlightDeparted:
+	default:
+		return domain.Rejectf("unknown flight status %q", status)
+	}
+	
+	// Get the flight
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+	
+	if flight.CurrentStatus() == domain.FlightCancelled || flight.CurrentStatus() == domain.FlightDeparted {
+		return domain.Rejectf("flight %s is %s and its status can no longer change", flightNumber, flight.CurrentStatus())
+	}
+	
+	previousStatus := flight.CurrentStatus()
+	flight.Status = status
+	
+	// Update flight
+	err = s.flightRepo.Update(flight)
+	if err != nil {
+		return err
+	}
+	
+	if status != previousStatus {
+		err = publish(s.events, &domain.FlightStatusChanged{FlightNumber: flightNumber, PreviousStatus: previousStatus, Status: status})
+		if err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	
+	return nil
+}
+
 // ListAllFlights retrieves all flights sorted by departure time (descending)
 func (s *Service) ListAllFlights() ([]*domain.Flight, error) {
 	flights, err := s.flightRepo.FindAll()
@@ -89,4 +462,20 @@ func (s *Service) ListAllFlights() ([]*domain.Flight, error) {
 	})
 	
 	return flights, nil
-}
\ No newline at end of file
+}
+
+// atomically makes a change in one step with the events it publishes, or directly when there is no publisher
+func atomically(events ports.EventPublisher, change func() error) error {
+	if events == nil {
+		return change()
+	}
+	return events.Atomically(change)
+}
+
+// publish hands a domain event to the publisher, if there is one, to be stored with the change it records
+func publish(events ports.EventPublisher, event domain.Event) error {
+	if events == nil {
+		return nil
+	}
+	return events.Publish(event)
+}
@@ -0,0 +1,237 @@
+package flight
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SSRLine is one special service request on a flight, as handed to catering or ground handling
+type SSRLine struct {
+	Code          string
+	ReservationID string
+	Name          string
+	Class         string
+	Seat          string
+	Text          string
+}
+
+// SSRSummary lists the special service requests of a flight by the team that fulfils them
+type SSRSummary struct {
+	FlightNumber   string
+	Counts         map[string]int // Requests per code
+	Catering       []SSRLine      // Special meals
+	GroundHandling []SSRLine      // Assistance, minors and animals
+}
+
+// SSRService records special service requests on reservations and enforces their per-flight limits
+type SSRService struct {
+	flightRepo      ports.FlightRepository
+	reservationRepo ports.ReservationRepository
+}
+
+// NewSSRService creates a new SSRService instance
+func NewSSRService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository) *SSRService {
+	return &SSRService{
+		flightRepo:      flightRepo,
+		reservationRepo: reservationRepo,
+	}
+}
+
+// AddSpecialService attaches a special service request to a reservation
+func (s *SSRService) AddSpecialService(reservationID, code, text string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	service, ok := domain.SpecialServices[code]
+	if !ok {
+		return fmt.Errorf("unknown special service code %q", code)
+	}
+
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if !reservation.IsConfirmed() {
+		return fmt.Errorf("reservation %s is %s", reservationID, reservation.Status)
+	}
+
+	if reservation.HasSpecialService(code) {
+		return fmt.Errorf("reservation %s already has a %s request", reservationID, code)
+	}
+
+	// Catering loads a single meal per passenger
+	if service.Category == domain.SSRCategoryMeal {
+		for _, request := range reservation.SpecialServices {
+			if domain.SpecialServices[request.Code].Category == domain.SSRCategoryMeal {
+				return fmt.Errorf("reservation %s already has a %s meal; remove it first", reservationID, request.Code)
+			}
+		}
+	}
+
+	flight, err := s.flightRepo.FindByID(reservation.ReservationFlightNumber)
+	if err != nil {
+		return fmt.Errorf("flight not found: %w", err)
+	}
+
+	if flight.IsClosed() {
+		return fmt.Errorf("flight %s is %s", flight.FlightNumber, flight.CurrentStatus())
+	}
+
+	// A passenger already seated in an exit row has to move first
+	if service.ExitRowRestricted && reservation.SeatLocation != "" && flight.IsExitRow(reservation.SeatLocation) {
+		return fmt.Errorf("seat %s is in an exit row, which %s passengers may not occupy", reservation.SeatLocation, code)
+	}
+
+	if limit := flight.SpecialServiceLimit(code); limit > 0 {
+		count, err := s.countRequests(flight.FlightNumber, code)
+		if err != nil {
+			return err
+		}
+		if count >= limit {
+			return fmt.Errorf("flight %s already has the maximum of %d %s requests", flight.FlightNumber, limit, code)
+		}
+	}
+
+	reservation.SpecialServices = append(reservation.SpecialServices, domain.SpecialServiceRequest{
+		Code:      code,
+		Text:      strings.TrimSpace(text),
+		CreatedAt: time.Now(),
+	})
+
+	return s.reservationRepo.Update(reservation)
+}
+
+// RemoveSpecialService removes a special service request from a reservation
+func (s *SSRService) RemoveSpecialService(reservationID, code string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+
+	requests := []domain.SpecialServiceRequest{}
+	for _, request := range reservation.SpecialServices {
+		if request.Code != code {
+			requests = append(requests, request)
+		}
+	}
+
+	if len(requests) == len(reservation.SpecialServices) {
+		return fmt.Errorf("reservation %s has no %s request", reservationID, code)
+	}
+
+	reservation.SpecialServices = requests
+	return s.reservationRepo.Update(reservation)
+}
+
+// SetExitRows sets the exit rows of a flight
+func (s *SSRService) SetExitRows(flightNumber string, rows []int) error {
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if row < 1 || row > flight.SeatRows() {
+			return fmt.Errorf("row %d does not exist on flight %s", row, flightNumber)
+		}
+	}
+
+	flight.ExitRows = rows
+	return s.flightRepo.Update(flight)
+}
+
+// SetLimit sets how many requests of a code a flight accepts, overriding the catalogue limit; 0 removes the limit
+func (s *SSRService) SetLimit(flightNumber, code string, limit int) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if _, ok := domain.SpecialServices[code]; !ok {
+		return fmt.Errorf("unknown special service code %q", code)
+	}
+	if limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return err
+	}
+
+	if flight.SSRLimits == nil {
+		flight.SSRLimits = make(map[string]int)
+	}
+	flight.SSRLimits[code] = limit
+
+	return s.flightRepo.Update(flight)
+}
+
+// Summary lists the special service requests of the confirmed reservations of a flight
+func (s *SSRService) Summary(flightNumber string) (*SSRSummary, error) {
+	if _, err := s.flightRepo.FindByID(flightNumber); err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.reservationRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &SSRSummary{
+		FlightNumber: flightNumber,
+		Counts:       make(map[string]int),
+	}
+
+	for _, reservation := range reservations {
+		if !reservation.IsConfirmed() {
+			continue
+		}
+
+		for _, request := range reservation.SpecialServices {
+			line := SSRLine{
+				Code:          request.Code,
+				ReservationID: reservation.ReservationID,
+				Name:          reservation.Name,
+				Class:         reservation.Class,
+				Seat:          reservation.SeatLocation,
+				Text:          request.Text,
+			}
+
+			summary.Counts[request.Code]++
+			if domain.SpecialServices[request.Code].Category == domain.SSRCategoryMeal {
+				summary.Catering = append(summary.Catering, line)
+			} else {
+				summary.GroundHandling = append(summary.GroundHandling, line)
+			}
+		}
+	}
+
+	for _, lines := range [][]SSRLine{summary.Catering, summary.GroundHandling} {
+		sort.Slice(lines, func(i, j int) bool {
+			if lines[i].Code != lines[j].Code {
+				return lines[i].Code < lines[j].Code
+			}
+			return lines[i].Name < lines[j].Name
+		})
+	}
+
+	return summary, nil
+}
+
+// countRequests counts the confirmed reservations of a flight with a request of a code
+func (s *SSRService) countRequests(flightNumber, code string) (int, error) {
+	reservations, err := s.reservationRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, reservation := range reservations {
+		if reservation.IsConfirmed() && reservation.HasSpecialService(code) {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,421 @@
+package flight
+
+import (
+	"context"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sync"
+	"time"
+)
+
+// DefaultWaitlistHold is how long a promoted passenger has to confirm the offered seat
+const DefaultWaitlistHold = 24 * time.Hour
+
+// WaitlistService manages the waitlists of sold-out flights
+type WaitlistService struct {
+	flightRepo      ports.FlightRepository
+	reservationRepo ports.ReservationRepository
+	waitlistRepo    ports.WaitlistRepository
+	notifier        ports.Notifier
+	holdPeriod      time.Duration
+	mutex           sync.Mutex // Serialises offers, so the reaper and the passengers do not race on a seat
+}
+
+// NewWaitlistService creates a new WaitlistService instance
+func NewWaitlistService(flightRepo ports.FlightRepository, reservationRepo ports.ReservationRepository,
+	waitlistRepo ports.WaitlistRepository, notifier ports.Notifier, holdPeriod time.Duration) *WaitlistService {
+	return &WaitlistService{
+		flightRepo:      flightRepo,
+		reservationRepo: reservationRepo,
+		waitlistRepo:    waitlistRepo,
+		notifier:        notifier,
+		holdPeriod:      holdPeriod,
+	}
+}
+
+// JoinWaitlist adds a passenger to the waitlist of a flight whose cabin of their class is sold out
+func (s *WaitlistService) JoinWaitlist(name, address string, phoneNumber, identityCardNumber int64, flightNumber, class string) (*domain.WaitlistEntry, error) {
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	if class == "" {
+		class = domain.ClassEconomy
+	}
+	if err := domain.CheckClass(class); err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.reservationRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+	if classAvailableSeats(flight, reservations, class) > 0 {
+		return nil, fmt.Errorf("flight %s still has available %s seats", flightNumber, class)
+	}
+
+	entries, err := s.waitlistRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// A passenger only waits once per flight
+	for _, entry := range entries {
+		if entry.FlightNumber == flightNumber && entry.IdentityCardNumber == identityCardNumber && entry.IsActive() {
+			return nil, fmt.Errorf("passenger is already on the waitlist of flight %s as %s", flightNumber, entry.ID)
+		}
+	}
+
+	entry := &domain.WaitlistEntry{
+		ID:                 fmt.Sprintf("W%04d", len(entries)+1),
+		FlightNumber:       flightNumber,
+		Class:              class,
+		Name:               name,
+		Address:            address,
+		PhoneNumber:        phoneNumber,
+		IdentityCardNumber: identityCardNumber,
+		Status:             domain.WaitlistWaiting,
+		RequestedAt:        time.Now(),
+	}
+
+	err = s.waitlistRepo.Save(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetWaitlist retrieves the active waitlist entries of a flight in order
+func (s *WaitlistService) GetWaitlist(flightNumber string) ([]*domain.WaitlistEntry, error) {
+	if _, err := s.ExpireOffers(flightNumber); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.waitlistRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []*domain.WaitlistEntry
+	for _, entry := range entries {
+		if entry.IsActive() {
+			active = append(active, entry)
+		}
+	}
+
+	return active, nil
+}
+
+// Promote offers the released seats of a flight to the first eligible waitlisted passengers whose cabin
+// has seats left, holding a pending reservation for each of them until their offer expires
+func (s *WaitlistService) Promote(flightNumber string) ([]*domain.WaitlistEntry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.promote(flightNumber)
+}
+
+// promote offers the released seats of a flight; the caller holds the mutex
+func (s *WaitlistService) promote(flightNumber string) ([]*domain.WaitlistEntry, error) {
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	entries, err := s.waitlistRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.reservationRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var promoted []*domain.WaitlistEntry
+	for _, entry := range entries {
+		if flight.AvailableSeat <= 0 {
+			break
+		}
+		if entry.Status != domain.WaitlistWaiting {
+			continue
+		}
+
+		// Passengers who got a seat another way are no longer eligible
+		if hasActiveReservation(reservations, entry.IdentityCardNumber) {
+			entry.Status = domain.WaitlistCancelled
+			if err := s.waitlistRepo.Save(entry); err != nil {
+				return promoted, fmt.Errorf("failed to update waitlist entry: %w", err)
+			}
+			continue
+		}
+
+		// Only the seats left in the passenger's cabin can be offered
+		if classAvailableSeats(flight, reservations, entry.Class) <= 0 {
+			continue
+		}
+
+		// Hold the seat with a pending reservation
+		reservation := domain.NewReservation(entry.Name, entry.Address, entry.PhoneNumber, entry.IdentityCardNumber, flightNumber)
+		reservation.Class = entry.Class
+		reservation.Status = domain.ReservationPending
+
+		err = s.reservationRepo.Save(reservation)
+		if err != nil {
+			return promoted, fmt.Errorf("failed to save reservation: %w", err)
+		}
+		reservations = append(reservations, reservation)
+
+		flight.AvailableSeat--
+		err = s.flightRepo.Update(flight)
+		if err != nil {
+			return promoted, fmt.Errorf("failed to update flight: %w", err)
+		}
+
+		entry.Status = domain.WaitlistOffered
+		entry.ReservationID = reservation.ReservationID
+		entry.OfferExpiresAt = time.Now().Add(s.holdPeriod)
+		err = s.waitlistRepo.Save(entry)
+		if err != nil {
+			return promoted, fmt.Errorf("failed to update waitlist entry: %w", err)
+		}
+
+		err = s.notifier.Notify(&domain.Notification{
+			Type:        domain.NotificationWaitlistOffer,
+			Name:        entry.Name,
+			PhoneNumber: entry.PhoneNumber,
+			Message: fmt.Sprintf("A seat on flight %s is held for you under reservation %s. Please confirm before %s.",
+				flightNumber, reservation.ReservationID, entry.OfferExpiresAt.Format("02/01/2006-15:04")),
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return promoted, fmt.Errorf("failed to notify passenger: %w", err)
+		}
+
+		promoted = append(promoted, entry)
+	}
+
+	return promoted, nil
+}
+
+// ConfirmOffer confirms the reservation offered to a waitlisted passenger
+func (s *WaitlistService) ConfirmOffer(entryID string) (*domain.Reservation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, err := s.waitlistRepo.FindByID(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.expireOffers(entry.FlightNumber); err != nil {
+		return nil, err
+	}
+
+	// Reload the entry in case its offer just expired
+	entry, err = s.waitlistRepo.FindByID(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Status != domain.WaitlistOffered {
+		return nil, fmt.Errorf("waitlist entry %s has no open offer (status: %s)", entryID, entry.Status)
+	}
+
+	reservation, err := s.reservationRepo.FindByID(entry.ReservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	reservation.Status = domain.ReservationConfirmed
+	err = s.reservationRepo.Update(reservation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	entry.Status = domain.WaitlistConfirmed
+	err = s.waitlistRepo.Save(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// ExpireOffers releases the seats of offers that were not confirmed in time and
+// promotes the next passengers in their place
+func (s *WaitlistService) ExpireOffers(flightNumber string) ([]*domain.WaitlistEntry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.expireOffers(flightNumber)
+}
+
+// ExpireAllOffers releases the seats of the offers of every flight that were not confirmed in time,
+// promoting the next passengers in their place, and returns how many offers expired
+func (s *WaitlistService) ExpireAllOffers() (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entries, err := s.waitlistRepo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	expired := 0
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Status != domain.WaitlistOffered || now.Before(entry.OfferExpiresAt) || seen[entry.FlightNumber] {
+			continue
+		}
+		seen[entry.FlightNumber] = true
+
+		released, err := s.expireOffers(entry.FlightNumber)
+		expired += len(released)
+		if err != nil {
+			return expired, err
+		}
+	}
+
+	return expired, nil
+}
+
+// StartReaper expires the offers not confirmed in time every interval until the context is cancelled,
+// so their seats are offered again without waiting for the next waitlist change of their flight
+func (s *WaitlistService) StartReaper(ctx context.Context, interval time.Duration, onError func(err error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := s.ExpireAllOffers(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// expireOffers releases the expired offers of a flight; the caller holds the mutex
+func (s *WaitlistService) expireOffers(flightNumber string) ([]*domain.WaitlistEntry, error) {
+	entries, err := s.waitlistRepo.FindByFlightNumber(flightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expired []*domain.WaitlistEntry
+	for _, entry := range entries {
+		if entry.Status != domain.WaitlistOffered || now.Before(entry.OfferExpiresAt) {
+			continue
+		}
+
+		if err := s.releaseOffer(entry); err != nil {
+			return expired, err
+		}
+		expired = append(expired, entry)
+	}
+
+	if len(expired) > 0 {
+		if _, err := s.promote(flightNumber); err != nil {
+			return expired, err
+		}
+	}
+
+	return expired, nil
+}
+
+// HandleSeatReleased promotes waitlisted passengers when a SeatReleased event reports seats released on a flight
+func (s *WaitlistService) HandleSeatReleased(message *domain.EventMessage) error {
+	released, ok := message.Event.(*domain.SeatReleased)
+	if !ok {
+		return nil
+	}
+
+	_, err := s.Promote(released.FlightNumber)
+	return err
+}
+
+// releaseOffer cancels the pending reservation of an expired offer and returns its seat
+func (s *WaitlistService) releaseOffer(entry *domain.WaitlistEntry) error {
+	reservation, err := s.reservationRepo.FindByID(entry.ReservationID)
+	if err == nil && reservation.Status == domain.ReservationPending {
+		reservation.Status = domain.ReservationCancelled
+		if err := s.reservationRepo.Update(reservation); err != nil {
+			return fmt.Errorf("failed to update reservation: %w", err)
+		}
+
+		flight, err := s.flightRepo.FindByID(entry.FlightNumber)
+		if err != nil {
+			return fmt.Errorf("flight not found: %w", err)
+		}
+		flight.AvailableSeat++
+		if err := s.flightRepo.Update(flight); err != nil {
+			return fmt.Errorf("failed to update flight: %w", err)
+		}
+	}
+
+	entry.Status = domain.WaitlistExpired
+	if err := s.waitlistRepo.Save(entry); err != nil {
+		return fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+
+	return s.notifier.Notify(&domain.Notification{
+		Type:        domain.NotificationWaitlistExpired,
+		Name:        entry.Name,
+		PhoneNumber: entry.PhoneNumber,
+		Message:     fmt.Sprintf("Your held seat on flight %s was released because it was not confirmed in time.", entry.FlightNumber),
+		CreatedAt:   time.Now(),
+	})
+}
+
+// hasActiveReservation reports whether a passenger already holds a reservation that is not cancelled
+func hasActiveReservation(reservations []*domain.Reservation, identityCardNumber int64) bool {
+	for _, reservation := range reservations {
+		if reservation.IdentityCardNumber == identityCardNumber && !reservation.IsCancelled() {
+			return true
+		}
+	}
+	return false
+}
+
+// classSold returns how many of the reservations of a flight are in a class and not cancelled
+func classSold(reservations []*domain.Reservation, class string) int {
+	sold := 0
+	for _, reservation := range reservations {
+		reservationClass := reservation.Class
+		if reservationClass == "" {
+			reservationClass = domain.ClassEconomy
+		}
+		if reservationClass == class && !reservation.IsCancelled() {
+			sold++
+		}
+	}
+	return sold
+}
+
+// classAvailableSeats returns how many seats of a class are still unsold on a flight: the seats of its cabin
+// not taken by the reservations of that class, but no more than the unsold seats of the whole flight
+func classAvailableSeats(flight *domain.Flight, reservations []*domain.Reservation, class string) int {
+	if class == "" {
+		class = domain.ClassEconomy
+	}
+
+	available := flight.CabinCapacity(class)
+	if flight.BusinessRows > 0 {
+		available -= classSold(reservations, class)
+	}
+
+	if available > flight.AvailableSeat {
+		available = flight.AvailableSeat
+	}
+	return available
+}
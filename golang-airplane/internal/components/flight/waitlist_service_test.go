@@ -0,0 +1,57 @@
+package flight_test
+
+import (
+	"testing"
+	"time"
+
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/storage/json"
+)
+
+func TestExpireAllOffers(t *testing.T) {
+	f := newFixture(t, 1)
+	seated := f.book(t, "Ann Lee", domain.ClassEconomy)
+
+	// Offers made by this waitlist expire as soon as they are made
+	waitlist := flight.NewWaitlistService(f.flights, f.reservations, json.NewWaitlistRepository(f.storage),
+		json.NewNotificationLog(f.storage), -time.Second)
+	entry, err := waitlist.JoinWaitlist("Bob Tran", "2 Main Street", 900000001, 100000001, "F1000", "")
+	if err != nil {
+		t.Fatalf("JoinWaitlist: %v", err)
+	}
+
+	if err := f.service.CancelReservation(seated.ReservationID); err != nil {
+		t.Fatalf("CancelReservation: %v", err)
+	}
+	promoted, err := waitlist.Promote("F1000")
+	if err != nil {
+		t.Fatalf("Promote: %v", err)
+	}
+	if len(promoted) != 1 || promoted[0].ID != entry.ID {
+		t.Fatalf("Promote offered the seat to %v, want the waiting passenger", promoted)
+	}
+
+	expired, err := waitlist.ExpireAllOffers()
+	if err != nil {
+		t.Fatalf("ExpireAllOffers: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("ExpireAllOffers expired %d offers, want 1", expired)
+	}
+
+	offered, err := f.reservations.FindByID(promoted[0].ReservationID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if !offered.IsCancelled() {
+		t.Errorf("reservation of the expired offer is %s, want cancelled", offered.Status)
+	}
+	flight1000, err := f.flights.FindByID("F1000")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if flight1000.AvailableSeat != 1 {
+		t.Errorf("flight has %d available seats once the offer expired, want 1", flight1000.AvailableSeat)
+	}
+}
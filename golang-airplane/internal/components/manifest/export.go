@@ -0,0 +1,181 @@
+package manifest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"golang-airplane/internal/components/boardingpass"
+	"golang-airplane/internal/core/domain"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Export formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatAPIS = "apis"
+	FormatBags = "bags" // Baggage load list as CSV
+)
+
+// Write exports a manifest in the given format
+func Write(w io.Writer, manifest *domain.Manifest, format string) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, manifest)
+	case FormatJSON:
+		return WriteJSON(w, manifest)
+	case FormatAPIS:
+		return WriteAPIS(w, manifest)
+	case FormatBags:
+		return WriteLoadList(w, manifest)
+	}
+	return fmt.Errorf("unknown manifest format %q", format)
+}
+
+// WriteCSV exports a manifest as CSV, passengers first and then crew
+func WriteCSV(w io.Writer, manifest *domain.Manifest) error {
+	writer := csv.NewWriter(w)
+
+	records := [][]string{
+		{"type", "reservation_id", "name", "id_card_number", "class", "seat", "status", "checked_in", "boarded", "boarded_at", "special_services"},
+	}
+	for _, passenger := range manifest.Passengers {
+		boardedAt := ""
+		if passenger.BoardedAt != nil {
+			boardedAt = passenger.BoardedAt.Format("2006-01-02T15:04:05Z07:00")
+		}
+		records = append(records, []string{
+			"passenger",
+			passenger.ReservationID,
+			passenger.Name,
+			strconv.FormatInt(passenger.IdentityCardNumber, 10),
+			passenger.Class,
+			passenger.Seat,
+			passenger.Status,
+			strconv.FormatBool(passenger.CheckedIn),
+			strconv.FormatBool(passenger.Boarded),
+			boardedAt,
+			strings.Join(passenger.SpecialServices, " "),
+		})
+	}
+	for _, crew := range manifest.Crew {
+		records = append(records, []string{"crew", crew.ID, crew.Name, "", crew.Position, "", "", "", "", "", ""})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// WriteLoadList exports the baggage load list of a manifest as CSV
+func WriteLoadList(w io.Writer, manifest *domain.Manifest) error {
+	writer := csv.NewWriter(w)
+
+	records := [][]string{{"tag_number", "reservation_id", "name", "weight_kg", "status", "offload"}}
+	for _, bag := range manifest.Bags {
+		records = append(records, []string{
+			bag.TagNumber,
+			bag.ReservationID,
+			bag.Name,
+			strconv.FormatFloat(bag.WeightKg, 'f', 1, 64),
+			bag.Status,
+			strconv.FormatBool(bag.Offload),
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write load list: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON exports a manifest as indented JSON
+func WriteJSON(w io.Writer, manifest *domain.Manifest) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(manifest)
+}
+
+// apisField is one fixed-width column of an APIS record
+type apisField struct {
+	width int
+	value string
+}
+
+// apisRecord formats fixed-width fields into one line, in capitals without diacritics, truncating values
+// that do not fit; widths count characters, so a character left outside ASCII is never cut
+func apisRecord(fields ...apisField) string {
+	var sb strings.Builder
+	for _, field := range fields {
+		value := []rune(boardingpass.FoldName(field.value))
+		if len(value) > field.width {
+			value = value[:field.width]
+		}
+		sb.WriteString(string(value))
+		sb.WriteString(strings.Repeat(" ", field.width-len(value)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// WriteAPIS exports a manifest in a fixed-width format modelled on advance passenger
+// information submissions: a header record, one record per traveller and a trailer
+// with the record counts
+func WriteAPIS(w io.Writer, manifest *domain.Manifest) error {
+	flag := func(value bool) string {
+		if value {
+			return "Y"
+		}
+		return "N"
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(apisRecord(
+		apisField{1, "H"},
+		apisField{8, manifest.FlightNumber},
+		apisField{3, boardingpass.AirportCode(manifest.DepartureCity)},
+		apisField{3, boardingpass.AirportCode(manifest.DestinationCity)},
+		apisField{8, manifest.DepartureTime.Format("20060102")},
+		apisField{4, manifest.DepartureTime.Format("1504")},
+		apisField{8, manifest.ArrivalTime.Format("20060102")},
+		apisField{4, manifest.ArrivalTime.Format("1504")},
+		apisField{10, manifest.AirplaneID},
+		apisField{1, flag(manifest.Final)},
+	))
+
+	for _, passenger := range manifest.Passengers {
+		sb.WriteString(apisRecord(
+			apisField{1, "P"},
+			apisField{40, boardingpass.SurnameFirst(passenger.Name)},
+			apisField{20, strconv.FormatInt(passenger.IdentityCardNumber, 10)},
+			apisField{10, passenger.ReservationID},
+			apisField{1, boardingpass.CompartmentCode(passenger.Class)},
+			apisField{4, passenger.Seat},
+			apisField{1, flag(passenger.CheckedIn)},
+			apisField{1, flag(passenger.Boarded)},
+			apisField{16, passenger.Status},
+		))
+	}
+
+	for _, crew := range manifest.Crew {
+		sb.WriteString(apisRecord(
+			apisField{1, "C"},
+			apisField{40, boardingpass.SurnameFirst(crew.Name)},
+			apisField{20, crew.ID},
+			apisField{20, crew.Position},
+		))
+	}
+
+	sb.WriteString(apisRecord(
+		apisField{1, "T"},
+		apisField{5, fmt.Sprintf("%05d", len(manifest.Passengers))},
+		apisField{5, fmt.Sprintf("%05d", len(manifest.Crew))},
+	))
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,45 @@
+package manifest_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"golang-airplane/internal/components/manifest"
+	"golang-airplane/internal/core/domain"
+)
+
+func TestAPISFieldsKeepWholeCharacters(t *testing.T) {
+	departure := time.Date(2026, 10, 20, 8, 30, 0, 0, time.UTC)
+	flightManifest := &domain.Manifest{
+		FlightNumber:  "F1000",
+		DepartureTime: departure,
+		ArrivalTime:   departure.Add(2 * time.Hour),
+		Passengers: []domain.ManifestPassenger{
+			{ReservationID: "R1", Name: "Nguyễn Văn Đức", IdentityCardNumber: 100000000, Class: domain.ClassEconomy},
+		},
+		// The position is cut inside a character outside ASCII, which folding leaves as it is
+		Crew: []domain.Crew{{ID: "C1", Name: "Trần Thị Hoa", Position: "Tiếp viên 乘务员乘务员乘务员乘务员"}},
+	}
+
+	var out bytes.Buffer
+	if err := manifest.WriteAPIS(&out, flightManifest); err != nil {
+		t.Fatalf("WriteAPIS: %v", err)
+	}
+	if !utf8.Valid(out.Bytes()) {
+		t.Fatalf("APIS export is not valid UTF-8:\n%s", out.String())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("APIS export has %d records, want 4:\n%s", len(lines), out.String())
+	}
+	if passenger := lines[1]; !strings.HasPrefix(passenger, "PDUC/NGUYEN VAN ") {
+		t.Errorf("passenger record %q does not start with the folded name", passenger)
+	}
+	if crew := lines[2]; utf8.RuneCountInString(crew) != 81 || !strings.HasPrefix(crew, "CHOA/TRAN THI ") {
+		t.Errorf("crew record %q is not 81 characters starting with the folded name", crew)
+	}
+}
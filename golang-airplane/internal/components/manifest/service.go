@@ -0,0 +1,147 @@
+package manifest
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sort"
+	"time"
+)
+
+// Service builds passenger and crew manifests for departure control
+type Service struct {
+	flights      ports.FlightService
+	reservations ports.ReservationService
+	flightRepo   ports.FlightRepository
+	boardingRepo ports.BoardingSessionRepository
+	manifestRepo ports.ManifestRepository
+}
+
+// NewService creates a new manifest Service instance
+func NewService(flights ports.FlightService, reservations ports.ReservationService, flightRepo ports.FlightRepository,
+	boardingRepo ports.BoardingSessionRepository, manifestRepo ports.ManifestRepository) *Service {
+	return &Service{
+		flights:      flights,
+		reservations: reservations,
+		flightRepo:   flightRepo,
+		boardingRepo: boardingRepo,
+		manifestRepo: manifestRepo,
+	}
+}
+
+// Generate returns the manifest of a flight: the frozen one once the flight is closed,
+// otherwise one built from the current reservations and crew
+func (s *Service) Generate(flightNumber string) (*domain.Manifest, error) {
+	flight, err := s.flights.GetFlight(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	if flight.IsClosed() {
+		if frozen, err := s.manifestRepo.FindByFlightNumber(flightNumber); err == nil {
+			return frozen, nil
+		}
+	}
+
+	return s.build(flight)
+}
+
+// CloseFlight closes a flight to passenger changes and freezes its manifest. Boarding,
+// if it was opened, must be closed first.
+func (s *Service) CloseFlight(flightNumber string) (*domain.Manifest, error) {
+	flight, err := s.flightRepo.FindByID(flightNumber)
+	if err != nil {
+		return nil, fmt.Errorf("flight not found: %w", err)
+	}
+
+	if flight.IsClosed() {
+		return nil, fmt.Errorf("flight %s is already %s", flightNumber, flight.CurrentStatus())
+	}
+
+	if session, err := s.boardingRepo.FindByFlightNumber(flightNumber); err == nil && session.IsOpen() {
+		return nil, fmt.Errorf("boarding of flight %s is still open", flightNumber)
+	}
+
+	manifest, err := s.build(flight)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Final = true
+
+	err = s.manifestRepo.Save(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save manifest: %w", err)
+	}
+
+	flight.Status = domain.FlightClosed
+	err = s.flightRepo.Update(flight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update flight: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// build assembles the manifest of a flight from its reservations and crew
+func (s *Service) build(flight *domain.Flight) (*domain.Manifest, error) {
+	reservations, err := s.reservations.GetReservationsForFlight(flight.FlightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &domain.Manifest{
+		FlightNumber:    flight.FlightNumber,
+		DepartureCity:   flight.DepartureCity,
+		DestinationCity: flight.DestinationCity,
+		DepartureTime:   flight.DepartureTime,
+		ArrivalTime:     flight.ArrivalTime,
+		AirplaneID:      flight.AirplaneID,
+		Gate:            flight.Gate,
+		Passengers:      []domain.ManifestPassenger{},
+		Crew:            append([]domain.Crew{}, flight.CrewMembers...),
+		GeneratedAt:     time.Now(),
+	}
+
+	// Only passengers holding a seat travel; cancelled and pending reservations are left out
+	for _, reservation := range reservations {
+		// Bags of passengers who are not travelling stay on the load list to be offloaded
+		for _, bag := range reservation.Bags {
+			manifest.Bags = append(manifest.Bags, domain.ManifestBag{
+				TagNumber:     bag.TagNumber,
+				ReservationID: reservation.ReservationID,
+				Name:          reservation.Name,
+				WeightKg:      bag.WeightKg,
+				Status:        bag.Status,
+				Offload:       !reservation.IsConfirmed(),
+			})
+		}
+
+		if !reservation.IsConfirmed() && reservation.Status != domain.ReservationOffloaded {
+			continue
+		}
+
+		status := reservation.Status
+		if status == "" {
+			status = domain.ReservationConfirmed
+		}
+
+		manifest.Passengers = append(manifest.Passengers, domain.ManifestPassenger{
+			ReservationID:      reservation.ReservationID,
+			Name:               reservation.Name,
+			IdentityCardNumber: reservation.IdentityCardNumber,
+			Class:              reservation.Class,
+			Seat:               reservation.SeatLocation,
+			Status:             status,
+			CheckedIn:          reservation.CheckedIn,
+			Boarded:            reservation.IsBoarded(),
+			BoardedAt:          reservation.BoardedAt,
+			SpecialServices:    reservation.SpecialServiceCodes(),
+		})
+	}
+
+	sort.SliceStable(manifest.Passengers, func(i, j int) bool {
+		return manifest.Passengers[i].Name < manifest.Passengers[j].Name
+	})
+
+	return manifest, nil
+}
@@ -0,0 +1,156 @@
+// Package seatmap draws domain seat maps for the terminal, as JSON and as SVG
+package seatmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"io"
+	"strings"
+)
+
+// Output formats
+const (
+	FormatTerminal = "terminal"
+	FormatJSON     = "json"
+	FormatSVG      = "svg"
+)
+
+// Write renders a seat map in the given format; the terminal format is written without colour
+func Write(w io.Writer, seatMap *domain.SeatMap, format string) error {
+	switch format {
+	case FormatTerminal:
+		return WriteTerminal(w, seatMap, false)
+	case FormatJSON:
+		return WriteJSON(w, seatMap)
+	case FormatSVG:
+		return WriteSVG(w, seatMap)
+	}
+	return fmt.Errorf("unknown seat map format %q", format)
+}
+
+// WriteJSON writes a seat map as indented JSON
+func WriteJSON(w io.Writer, seatMap *domain.SeatMap) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(seatMap)
+}
+
+// ANSI colours of the seat states
+var stateColours = map[string]string{
+	domain.SeatStateAvailable: "\x1b[30;42m",
+	domain.SeatStateOccupied:  "\x1b[37;41m",
+	domain.SeatStateHeld:      "\x1b[30;43m",
+	domain.SeatStateBlocked:   "\x1b[37;100m",
+	domain.SeatStateSelected:  "\x1b[97;44m",
+}
+
+const ansiReset = "\x1b[0m"
+
+// cellWidth is the width of a seat on the terminal
+const cellWidth = 5
+
+// WriteTerminal writes a seat map as text, one line per row with the aisle between the columns;
+// colour adds ANSI backgrounds for the seat states
+func WriteTerminal(w io.Writer, seatMap *domain.SeatMap, colour bool) error {
+	var sb strings.Builder
+	width := len(seatMap.Columns)*cellWidth + len(seatMap.AisleAfter)*3
+
+	sb.WriteString(fmt.Sprintf("Seat map of flight %s\n", seatMap.FlightNumber))
+
+	// Column letters
+	sb.WriteString("      ")
+	for i, column := range seatMap.Columns {
+		sb.WriteString(center(column, cellWidth))
+		if seatMap.HasAisleAfter(i) {
+			sb.WriteString("   ")
+		}
+	}
+	sb.WriteString("\n")
+
+	cabin := ""
+	for _, row := range seatMap.Rows {
+		// Divider at the start of every cabin
+		if row.Cabin != cabin {
+			cabin = row.Cabin
+			label := " " + cabin + " "
+			sb.WriteString("     +" + label + strings.Repeat("-", width-len(label)) + "+\n")
+		}
+
+		exit := " "
+		if row.ExitRow {
+			exit = "<"
+		}
+		sb.WriteString(fmt.Sprintf("%4d %s", row.Number, exit))
+
+		for i, seat := range row.Seats {
+			sb.WriteString(cell(seat, colour))
+			if seatMap.HasAisleAfter(i) {
+				sb.WriteString(" | ")
+			}
+		}
+
+		if row.ExitRow {
+			sb.WriteString("> EXIT")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("     +" + strings.Repeat("-", width) + "+\n")
+
+	sb.WriteString("Legend: ")
+	legend := []struct {
+		state string
+		label string
+	}{
+		{domain.SeatStateAvailable, "available"},
+		{domain.SeatStateOccupied, "occupied"},
+		{domain.SeatStateHeld, "held"},
+		{domain.SeatStateBlocked, "blocked"},
+		{domain.SeatStateSelected, "selected"},
+	}
+	for _, entry := range legend {
+		sb.WriteString(cell(domain.SeatMapSeat{Number: "1A", State: entry.state}, colour))
+		sb.WriteString(" " + entry.label + "  ")
+	}
+	sb.WriteString("$ paid seat\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// cell returns the fixed-width text of a seat on the terminal
+func cell(seat domain.SeatMapSeat, colour bool) string {
+	label := ""
+	switch seat.State {
+	case "":
+		return strings.Repeat(" ", cellWidth)
+	case domain.SeatStateAvailable:
+		label = seat.Number
+		if seat.Price > 0 {
+			label += "$"
+		}
+	case domain.SeatStateOccupied:
+		label = "X"
+	case domain.SeatStateHeld:
+		label = "H"
+	case domain.SeatStateBlocked:
+		label = "#"
+	case domain.SeatStateSelected:
+		label = "*" + seat.Number
+	}
+
+	text := center(label, cellWidth)
+	if colour {
+		return stateColours[seat.State] + text + ansiReset
+	}
+	return text
+}
+
+// center pads text with spaces to a width, centring it
+func center(text string, width int) string {
+	if len(text) >= width {
+		return text
+	}
+	left := (width - len(text)) / 2
+	return strings.Repeat(" ", left) + text + strings.Repeat(" ", width-len(text)-left)
+}
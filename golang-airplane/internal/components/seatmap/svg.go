@@ -0,0 +1,148 @@
+package seatmap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"io"
+	"strings"
+)
+
+// SVG layout, in pixels
+const (
+	svgSeat     = 28  // Seat size
+	svgGap      = 6   // Space between seats
+	svgAisle    = 26  // Width of an aisle
+	svgMargin   = 48  // Space beside the seats for row numbers and exit markers
+	svgTop      = 70  // Space above the first row for the title and column letters
+	svgDivider  = 22  // Height of a cabin divider
+	svgLegend   = 40  // Height of the legend below the seats
+	svgMinWidth = 360 // Narrowest image, which still fits the legend
+)
+
+// Fill colours of the seat states
+var stateFills = map[string]string{
+	domain.SeatStateAvailable: "#4caf50",
+	domain.SeatStateOccupied:  "#c62828",
+	domain.SeatStateHeld:      "#f9a825",
+	domain.SeatStateBlocked:   "#9e9e9e",
+	domain.SeatStateSelected:  "#1565c0",
+}
+
+// WriteSVG draws a seat map as an SVG image, nose at the top
+func WriteSVG(w io.Writer, seatMap *domain.SeatMap) error {
+	// Horizontal position of every column, centred when the legend is wider than the cabin
+	cabinWidth := len(seatMap.Columns)*(svgSeat+svgGap) - svgGap + len(seatMap.AisleAfter)*svgAisle
+	width := cabinWidth + 2*svgMargin
+	if width < svgMinWidth {
+		width = svgMinWidth
+	}
+	left := (width - cabinWidth) / 2
+
+	columnX := make([]int, len(seatMap.Columns))
+	x := left
+	for i := range seatMap.Columns {
+		columnX[i] = x
+		x += svgSeat + svgGap
+		if seatMap.HasAisleAfter(i) {
+			x += svgAisle
+		}
+	}
+
+	// Vertical position of every row, leaving room for the cabin dividers
+	rowY := make([]int, len(seatMap.Rows))
+	dividers := []struct {
+		y     int
+		cabin string
+	}{}
+	y := svgTop
+	cabin := ""
+	for i, row := range seatMap.Rows {
+		if row.Cabin != cabin {
+			cabin = row.Cabin
+			dividers = append(dividers, struct {
+				y     int
+				cabin string
+			}{y, cabin})
+			y += svgDivider
+		}
+		rowY[i] = y
+		y += svgSeat + svgGap
+	}
+	height := y + svgLegend
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		width, height, width, height))
+	sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="40" fill="#f5f5f5" stroke="#607d8b" stroke-width="2"/>`+"\n",
+		left-svgMargin/2-8, 8, cabinWidth+svgMargin+16, height-svgLegend))
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="34" font-size="14" font-weight="bold" text-anchor="middle">Flight %s</text>`+"\n",
+		width/2, escape(seatMap.FlightNumber)))
+
+	for i, column := range seatMap.Columns {
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="12" text-anchor="middle">%s</text>`+"\n",
+			columnX[i]+svgSeat/2, svgTop-8, escape(column)))
+	}
+
+	for _, divider := range dividers {
+		lineY := divider.y + svgDivider/2
+		sb.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#607d8b" stroke-dasharray="4 3"/>`+"\n",
+			left, lineY, left+cabinWidth, lineY))
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="10" text-anchor="middle" fill="#37474f">%s</text>`+"\n",
+			left+cabinWidth/2, lineY-3, escape(divider.cabin)))
+	}
+
+	for i, row := range seatMap.Rows {
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="11" text-anchor="end">%d</text>`+"\n",
+			left-6, rowY[i]+svgSeat/2+4, row.Number))
+		if row.ExitRow {
+			sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="9" fill="#c62828" text-anchor="start">EXIT</text>`+"\n",
+				left+cabinWidth+6, rowY[i]+svgSeat/2+3))
+		}
+
+		for j, seat := range row.Seats {
+			if seat.Number == "" {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf(`<g><title>%s</title>`, escape(seatTitle(seat))))
+			sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="5" fill="%s"/>`,
+				columnX[j], rowY[i], svgSeat, svgSeat, stateFills[seat.State]))
+			sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="9" fill="#ffffff" text-anchor="middle">%s</text></g>`+"\n",
+				columnX[j]+svgSeat/2, rowY[i]+svgSeat/2+3, escape(seat.Number)))
+		}
+	}
+
+	// Legend
+	legendX := 10
+	legendY := height - svgLegend + 16
+	for _, state := range []string{domain.SeatStateAvailable, domain.SeatStateOccupied, domain.SeatStateHeld,
+		domain.SeatStateBlocked, domain.SeatStateSelected} {
+		sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="10" height="10" rx="2" fill="%s"/>`, legendX, legendY, stateFills[state]))
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="9">%s</text>`+"\n", legendX+13, legendY+9, state))
+		legendX += 13 + 6*len(state) + 10
+	}
+
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// seatTitle returns the tooltip of a seat
+func seatTitle(seat domain.SeatMapSeat) string {
+	title := seat.Number + " " + seat.State
+	if len(seat.Attributes) > 0 {
+		title += ": " + domain.FormatSeatAttributes(seat.Attributes)
+	}
+	if seat.Price > 0 {
+		title += fmt.Sprintf(" (price %d)", seat.Price)
+	}
+	return title
+}
+
+// escape escapes text for use in SVG markup
+func escape(text string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(text))
+	return sb.String()
+}
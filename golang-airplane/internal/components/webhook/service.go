@@ -0,0 +1,425 @@
+// Package webhook sends domain events to the URLs partners subscribe for them. Payloads are signed with HMAC-SHA256,
+// failed deliveries are retried with exponential backoff until they are given up on into a dead-letter queue, and every
+// attempt is recorded in a delivery log.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SubscriberName is the name the service subscribes to the event bus under
+const SubscriberName = "webhooks"
+
+// DefaultCheckInterval is how often the dispatcher looks for retries that have come due
+const DefaultCheckInterval = 5 * time.Second
+
+// RetryPolicy sets how often and how long a failed delivery is retried
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts before the delivery goes to the dead-letter queue
+	BaseDelay   time.Duration // Wait after the first failure, doubled after each further one
+	MaxDelay    time.Duration // Longest wait between two attempts
+}
+
+// DefaultRetryPolicy tries a delivery 8 times over about an hour
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 8, BaseDelay: 30 * time.Second, MaxDelay: 30 * time.Minute}
+
+// Delay returns how long to wait after a number of failed attempts before the next one
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// payload is the body of a webhook request
+type payload struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Service manages webhook subscriptions and delivers the events queued for them
+type Service struct {
+	subscriptions ports.WebhookSubscriptionRepository
+	deliveries    ports.WebhookDeliveryRepository
+	attempts      ports.WebhookAttemptRepository
+	client        *http.Client
+	policy        RetryPolicy
+	mutex         sync.Mutex // Serialises changes to the queue of deliveries
+	sending       sync.Mutex // Held while due deliveries are sent, so each is sent once per pass
+	wake          chan struct{}
+}
+
+// NewService creates a webhook service; client may be nil to send with a ten second timeout
+func NewService(subscriptions ports.WebhookSubscriptionRepository, deliveries ports.WebhookDeliveryRepository,
+	attempts ports.WebhookAttemptRepository, client *http.Client, policy RetryPolicy) *Service {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Service{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		attempts:      attempts,
+		client:        client,
+		policy:        policy,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Subscribe registers a URL to be sent the events of some types; without a secret one is generated. The secret is
+// returned with the subscription, for the partner to check the signatures with.
+func (s *Service) Subscribe(target string, eventTypes []string, secret, createdBy string) (*domain.WebhookSubscription, error) {
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("the webhook URL must be an absolute http or https URL")
+	}
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("at least one event type is required")
+	}
+
+	subscription := &domain.WebhookSubscription{URL: target, Secret: secret, CreatedBy: createdBy, CreatedAt: time.Now().UTC()}
+	for _, eventType := range eventTypes {
+		if _, err := domain.NewEvent(eventType); err != nil {
+			return nil, err
+		}
+		if !subscription.Wants(eventType) {
+			subscription.EventTypes = append(subscription.EventTypes, eventType)
+		}
+	}
+
+	if subscription.ID, err = randomID("wh_", 8); err != nil {
+		return nil, err
+	}
+	if subscription.Secret == "" {
+		if subscription.Secret, err = randomID("whsec_", 24); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.subscriptions.Save(subscription); err != nil {
+		return nil, fmt.Errorf("failed to save webhook: %w", err)
+	}
+	return subscription, nil
+}
+
+// Unsubscribe removes a subscription and the deliveries still pending for it; those in the dead-letter queue are kept
+func (s *Service) Unsubscribe(id string) error {
+	if err := s.subscriptions.Delete(id); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	deliveries, err := s.deliveries.FindAll()
+	if err != nil {
+		return err
+	}
+	for _, delivery := range deliveries {
+		if delivery.SubscriptionID == id && delivery.Status == domain.WebhookPending {
+			if err := s.deliveries.Delete(delivery.ID); err != nil {
+				return fmt.Errorf("failed to drop delivery %s: %w", delivery.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Subscriptions returns all subscriptions
+func (s *Service) Subscriptions() ([]*domain.WebhookSubscription, error) {
+	return s.subscriptions.FindAll()
+}
+
+// HandleEvent queues an event for the subscriptions that want it; the event bus calls it asynchronously, and may call
+// it again with an event already queued
+func (s *Service) HandleEvent(message *domain.EventMessage) error {
+	subscriptions, err := s.subscriptions.FindAll()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload{ID: message.ID, Type: message.Type, OccurredAt: message.OccurredAt, Data: message.Payload})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	queued := false
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(message.Type) {
+			continue
+		}
+
+		id := subscription.ID + "." + message.ID
+		if _, err := s.deliveries.FindByID(id); err == nil {
+			continue
+		} else if !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+
+		now := time.Now().UTC()
+		err = s.deliveries.Save(&domain.WebhookDelivery{
+			ID:             id,
+			SubscriptionID: subscription.ID,
+			EventID:        message.ID,
+			EventType:      message.Type,
+			Body:           body,
+			Status:         domain.WebhookPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+		queued = true
+	}
+
+	if queued {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// StartDispatcher sends deliveries as soon as they are queued, and checks for retries that have come due every
+// interval, until the context is cancelled
+func (s *Service) StartDispatcher(ctx context.Context, interval time.Duration, onError func(err error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.SendDue(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// SendDue makes an attempt at every pending delivery whose time has come, in the order they were queued. A partner
+// refusing a delivery is not an error, only failing to read or update the queue is.
+func (s *Service) SendDue(ctx context.Context) error {
+	s.sending.Lock()
+	defer s.sending.Unlock()
+
+	deliveries, err := s.deliveries.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to read webhook deliveries: %w", err)
+	}
+
+	now := time.Now()
+	for _, delivery := range deliveries {
+		if delivery.Status != domain.WebhookPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := s.send(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send makes one attempt at a delivery, logs it and removes the delivery once accepted; a refused delivery is
+// scheduled again after the backoff, or put in the dead-letter queue after the last attempt
+func (s *Service) send(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	subscription, err := s.subscriptions.FindByID(delivery.SubscriptionID)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Unsubscribed since the event was queued
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return s.deliveries.Delete(delivery.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	attempt := &domain.WebhookAttempt{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: subscription.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		URL:            subscription.URL,
+		Attempt:        delivery.Attempts + 1,
+		At:             time.Now().UTC(),
+	}
+	attempt.StatusCode, err = s.post(ctx, subscription, delivery)
+	attempt.Duration = time.Since(attempt.At)
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	if err := s.attempts.Append(attempt); err != nil {
+		return fmt.Errorf("failed to log webhook attempt: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// The queue may have changed while the request was out, so the delivery is read again rather than saved as it
+	// was; one dropped by unsubscribing meanwhile stays dropped
+	current, err := s.deliveries.FindByID(delivery.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if attempt.Succeeded() {
+		return s.deliveries.Delete(current.ID)
+	}
+
+	current.Attempts = attempt.Attempt
+	current.LastError = attempt.Error
+	if current.Attempts >= s.policy.MaxAttempts {
+		current.Status = domain.WebhookDead
+	} else {
+		current.NextAttemptAt = time.Now().UTC().Add(s.policy.Delay(current.Attempts))
+	}
+	return s.deliveries.Save(current)
+}
+
+// post sends a signed delivery to the subscription's URL and returns the status code of the response; any status
+// other than 2xx is an error
+func (s *Service) post(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	// The body is stored indented with the queue, and sent compact
+	var body bytes.Buffer
+	if err := json.Compact(&body, delivery.Body); err != nil {
+		return 0, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "golang-airplane-webhooks")
+	request.Header.Set(EventHeader, delivery.EventType)
+	request.Header.Set(DeliveryHeader, delivery.ID)
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, body.Bytes()))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("the endpoint answered %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// Pending returns the deliveries waiting for their next attempt
+func (s *Service) Pending() ([]*domain.WebhookDelivery, error) {
+	return s.withStatus(domain.WebhookPending)
+}
+
+// DeadLetters returns the deliveries given up on after their last attempt
+func (s *Service) DeadLetters() ([]*domain.WebhookDelivery, error) {
+	return s.withStatus(domain.WebhookDead)
+}
+
+// withStatus returns the deliveries in a status
+func (s *Service) withStatus(status string) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := s.deliveries.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []*domain.WebhookDelivery{}
+	for _, delivery := range deliveries {
+		if delivery.Status == status {
+			selected = append(selected, delivery)
+		}
+	}
+	return selected, nil
+}
+
+// Redrive takes a delivery out of the dead-letter queue for a new series of attempts, starting now
+func (s *Service) Redrive(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delivery, err := s.deliveries.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if delivery.Status != domain.WebhookDead {
+		return fmt.Errorf("webhook delivery %s is not in the dead-letter queue", id)
+	}
+
+	delivery.Status = domain.WebhookPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now().UTC()
+	if err := s.deliveries.Save(delivery); err != nil {
+		return err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Attempts returns the delivery log, restricted to a subscription and an event when they are not empty
+func (s *Service) Attempts(subscriptionID, eventID string) ([]*domain.WebhookAttempt, error) {
+	attempts, err := s.attempts.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []*domain.WebhookAttempt{}
+	for _, attempt := range attempts {
+		if (subscriptionID == "" || attempt.SubscriptionID == subscriptionID) && (eventID == "" || attempt.EventID == eventID) {
+			selected = append(selected, attempt)
+		}
+	}
+	return selected, nil
+}
+
+// randomID returns a prefix followed by random bytes in hex
+func randomID(prefix string, size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook ID: %w", err)
+	}
+	return prefix + hex.EncodeToString(buf), nil
+}
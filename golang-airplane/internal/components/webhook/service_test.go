@@ -0,0 +1,314 @@
+package webhook_test
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang-airplane/internal/components/webhook"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/storage/json"
+)
+
+// testSecret is the secret of every test subscription
+const testSecret = "whsec_test"
+
+// testPolicy retries once an hour at first, so retries only come due when a test says so
+var testPolicy = webhook.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: 90 * time.Minute}
+
+// received is a request a receiver was sent
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is a local partner endpoint answering with status and remembering what it was sent
+type receiver struct {
+	mutex    sync.Mutex
+	status   int
+	requests []received
+	onSend   func() // Called while a request is being answered, if set
+}
+
+// ServeHTTP records a request and answers it with the receiver's status
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mutex.Lock()
+	r.requests = append(r.requests, received{header: req.Header.Clone(), body: body})
+	status, onSend := r.status, r.onSend
+	r.mutex.Unlock()
+
+	if onSend != nil {
+		onSend()
+	}
+	w.WriteHeader(status)
+}
+
+// answer sets the status the receiver answers with
+func (r *receiver) answer(status int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.status = status
+}
+
+// sent returns the requests the receiver was sent
+func (r *receiver) sent() []received {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]received(nil), r.requests...)
+}
+
+// fixture is a webhook service over empty JSON storage subscribed to SeatReleased events by a local receiver
+type fixture struct {
+	service      *webhook.Service
+	deliveries   ports.WebhookDeliveryRepository
+	receiver     *receiver
+	subscription *domain.WebhookSubscription
+}
+
+// newFixture creates a fixture whose receiver answers with a status
+func newFixture(t *testing.T, status int) *fixture {
+	t.Helper()
+
+	storage := json.NewStorage(t.TempDir())
+	deliveries := json.NewWebhookDeliveryRepository(storage)
+	service := webhook.NewService(json.NewWebhookSubscriptionRepository(storage), deliveries,
+		json.NewWebhookAttemptRepository(storage), nil, testPolicy)
+
+	receiver := &receiver{status: status}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	subscription, err := service.Subscribe(server.URL, []string{domain.EventSeatReleased}, testSecret, "admin")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	return &fixture{service: service, deliveries: deliveries, receiver: receiver, subscription: subscription}
+}
+
+// publish hands the service an event as the event bus does
+func (f *fixture) publish(t *testing.T, id, eventType string) {
+	t.Helper()
+	message := &domain.EventMessage{
+		ID:         id,
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Payload:    stdjson.RawMessage(`{"flight_number":"AB123","seats":1}`),
+	}
+	if err := f.service.HandleEvent(message); err != nil {
+		t.Fatalf("HandleEvent: %v", err)
+	}
+}
+
+// sendDue sends the deliveries that are due
+func (f *fixture) sendDue(t *testing.T) {
+	t.Helper()
+	if err := f.service.SendDue(context.Background()); err != nil {
+		t.Fatalf("SendDue: %v", err)
+	}
+}
+
+// delivery returns the only delivery of the queue
+func (f *fixture) delivery(t *testing.T) *domain.WebhookDelivery {
+	t.Helper()
+	deliveries, err := f.deliveries.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("queue holds %d deliveries, want 1", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+// comeDue makes the retry of the only delivery due now
+func (f *fixture) comeDue(t *testing.T) {
+	t.Helper()
+	delivery := f.delivery(t)
+	delivery.NextAttemptAt = time.Now().UTC().Add(-time.Second)
+	if err := f.deliveries.Save(delivery); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+func TestSignedDelivery(t *testing.T) {
+	f := newFixture(t, http.StatusNoContent)
+	f.publish(t, "evt_1", domain.EventSeatReleased)
+	f.publish(t, "evt_2", domain.EventFlightCreated) // Not subscribed to
+	f.sendDue(t)
+
+	requests := f.receiver.sent()
+	if len(requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(requests))
+	}
+	request := requests[0]
+	if err := webhook.Verify(testSecret, request.header, request.body, time.Minute, time.Now()); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := webhook.Verify("another secret", request.header, request.body, time.Minute, time.Now()); !errors.Is(err, webhook.ErrBadSignature) {
+		t.Errorf("Verify with another secret: %v, want ErrBadSignature", err)
+	}
+	if got := request.header.Get(webhook.EventHeader); got != domain.EventSeatReleased {
+		t.Errorf("event header %q, want %q", got, domain.EventSeatReleased)
+	}
+	if got, want := request.header.Get(webhook.DeliveryHeader), f.subscription.ID+".evt_1"; got != want {
+		t.Errorf("delivery header %q, want %q", got, want)
+	}
+
+	var body struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			FlightNumber string `json:"flight_number"`
+		} `json:"data"`
+	}
+	if err := stdjson.Unmarshal(request.body, &body); err != nil {
+		t.Fatalf("body: %v", err)
+	}
+	if body.ID != "evt_1" || body.Type != domain.EventSeatReleased || body.Data.FlightNumber != "AB123" {
+		t.Errorf("body %s does not carry the event", request.body)
+	}
+
+	pending, err := f.service.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("%d deliveries pending once accepted", len(pending))
+	}
+	attempts, err := f.service.Attempts(f.subscription.ID, "evt_1")
+	if err != nil {
+		t.Fatalf("Attempts: %v", err)
+	}
+	if len(attempts) != 1 || !attempts[0].Succeeded() || attempts[0].StatusCode != http.StatusNoContent {
+		t.Errorf("delivery log %+v, want one accepted attempt", attempts)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := webhook.RetryPolicy{MaxAttempts: 8, BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute}
+	for attempts, want := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		4:  4 * time.Minute,
+		5:  5 * time.Minute,
+		20: 5 * time.Minute,
+	} {
+		if got := policy.Delay(attempts); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestBackoffAndDeadLetters(t *testing.T) {
+	f := newFixture(t, http.StatusInternalServerError)
+	f.publish(t, "evt_1", domain.EventSeatReleased)
+
+	f.sendDue(t)
+	delivery := f.delivery(t)
+	if delivery.Status != domain.WebhookPending || delivery.Attempts != 1 {
+		t.Fatalf("after a refusal the delivery is %s with %d attempts", delivery.Status, delivery.Attempts)
+	}
+	if wait := time.Until(delivery.NextAttemptAt); wait < 59*time.Minute || wait > time.Hour {
+		t.Errorf("first retry in %v, want an hour", wait)
+	}
+	if !strings.Contains(delivery.LastError, "500") {
+		t.Errorf("last error %q does not name the status", delivery.LastError)
+	}
+
+	// A retry that has not come due is not sent
+	f.sendDue(t)
+	if n := len(f.receiver.sent()); n != 1 {
+		t.Fatalf("receiver got %d requests before the retry came due, want 1", n)
+	}
+
+	f.comeDue(t)
+	f.sendDue(t)
+	delivery = f.delivery(t)
+	if wait := time.Until(delivery.NextAttemptAt); delivery.Attempts != 2 || wait < 89*time.Minute || wait > 90*time.Minute {
+		t.Errorf("after %d attempts the next is in %v, want the 90 minute cap", delivery.Attempts, wait)
+	}
+
+	f.comeDue(t)
+	f.sendDue(t)
+	dead, err := f.service.DeadLetters()
+	if err != nil {
+		t.Fatalf("DeadLetters: %v", err)
+	}
+	if len(dead) != 1 || dead[0].Attempts != testPolicy.MaxAttempts {
+		t.Fatalf("dead-letter queue %+v, want the delivery after its last attempt", dead)
+	}
+
+	// Dead letters are not retried
+	f.sendDue(t)
+	if n := len(f.receiver.sent()); n != testPolicy.MaxAttempts {
+		t.Errorf("receiver got %d requests, want %d", n, testPolicy.MaxAttempts)
+	}
+}
+
+func TestRedrive(t *testing.T) {
+	f := newFixture(t, http.StatusServiceUnavailable)
+	f.publish(t, "evt_1", domain.EventSeatReleased)
+	for i := 0; i < testPolicy.MaxAttempts; i++ {
+		if i > 0 {
+			f.comeDue(t)
+		}
+		f.sendDue(t)
+	}
+	id := f.delivery(t).ID
+
+	if err := f.service.Redrive("unknown"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Redrive of an unknown delivery: %v, want ErrNotFound", err)
+	}
+
+	f.receiver.answer(http.StatusOK)
+	if err := f.service.Redrive(id); err != nil {
+		t.Fatalf("Redrive: %v", err)
+	}
+	delivery := f.delivery(t)
+	if delivery.Status != domain.WebhookPending || delivery.Attempts != 0 {
+		t.Fatalf("redriven delivery is %s with %d attempts, want pending with none", delivery.Status, delivery.Attempts)
+	}
+	if err := f.service.Redrive(id); err == nil {
+		t.Error("Redrive of a pending delivery succeeded")
+	}
+
+	f.sendDue(t)
+	deliveries, err := f.deliveries.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if len(deliveries) != 0 {
+		t.Errorf("queue holds %d deliveries once the redriven one was accepted", len(deliveries))
+	}
+}
+
+func TestUnsubscribeWhileSending(t *testing.T) {
+	f := newFixture(t, http.StatusInternalServerError)
+	f.publish(t, "evt_1", domain.EventSeatReleased)
+
+	// The partner unsubscribes while its endpoint is failing the request
+	f.receiver.onSend = func() {
+		if err := f.service.Unsubscribe(f.subscription.ID); err != nil {
+			t.Errorf("Unsubscribe: %v", err)
+		}
+	}
+	f.sendDue(t)
+
+	deliveries, err := f.deliveries.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if len(deliveries) != 0 {
+		t.Errorf("the failed attempt brought back %d deliveries of a removed subscription", len(deliveries))
+	}
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of a webhook request
+const (
+	SignatureHeader = "X-Webhook-Signature" // sha256= and the hex HMAC-SHA256 of the timestamp, a dot and the body
+	TimestampHeader = "X-Webhook-Timestamp" // Unix time the request was signed at
+	EventHeader     = "X-Webhook-Event"     // Event type
+	DeliveryHeader  = "X-Webhook-Delivery"  // Delivery ID, the same on every attempt
+)
+
+// ErrBadSignature is returned by Verify for a request that was not signed with the secret, or too long ago
+var ErrBadSignature = errors.New("invalid webhook signature")
+
+// Sign returns the signature of a body sent at a Unix time, keyed by a subscription's secret. The timestamp is signed
+// too, so a captured request cannot be replayed later with a new one.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a webhook request received at a time, rejecting requests signed more than tolerance
+// before or after it; receivers written in Go can use it on the headers and body of the request
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	timestamp, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return ErrBadSignature
+	}
+
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > tolerance || -age > tolerance {
+		return ErrBadSignature
+	}
+
+	signature := header.Get(SignatureHeader)
+	if !strings.HasPrefix(signature, "sha256=") || !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return ErrBadSignature
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audited entity types
+const (
+	AuditFlight      = "flight"
+	AuditReservation = "reservation"
+	AuditAirplane    = "airplane"
+	AuditCrewMember  = "crew_member" // Crew registry entry; flight crews are part of their flight
+)
+
+// AuditEntityTypes lists the audited entity types
+var AuditEntityTypes = []string{AuditFlight, AuditReservation, AuditAirplane, AuditCrewMember}
+
+// Audit operations; records are never deleted, cancelling a reservation or flight is an update
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+)
+
+// FieldChange is the change of one field of an entity; nested fields are named by their path, such as seat_list.3B
+type FieldChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"` // JSON value, absent when the field was added
+	After  json.RawMessage `json:"after,omitempty"`  // JSON value, absent when the field was removed
+}
+
+// AuditEntry is one change in the audit log; each entry carries the hash of the one before, so editing or removing an
+// entry breaks the chain
+type AuditEntry struct {
+	Sequence   int           `json:"sequence"` // Position in the log, from 1
+	At         time.Time     `json:"at"`
+	Actor      string        `json:"actor"`
+	EntityType string        `json:"entity_type"`
+	EntityID   string        `json:"entity_id"`
+	Operation  string        `json:"operation"`
+	Changes    []FieldChange `json:"changes"`
+	PrevHash   string        `json:"prev_hash"` // Hash of the previous entry, empty for the first
+	Hash       string        `json:"hash"`      // SHA-256 of the entry without this field
+}
+
+// AuditFilter selects audit entries; empty fields match every entry
+type AuditFilter struct {
+	EntityType string
+	EntityID   string
+	Actor      string
+	From       time.Time // Inclusive
+	To         time.Time // Exclusive
+}
+
+// Matches reports whether an entry is selected by the filter
+func (f AuditFilter) Matches(entry *AuditEntry) bool {
+	switch {
+	case f.EntityType != "" && entry.EntityType != f.EntityType:
+		return false
+	case f.EntityID != "" && entry.EntityID != f.EntityID:
+		return false
+	case f.Actor != "" && entry.Actor != f.Actor:
+		return false
+	case !f.From.IsZero() && entry.At.Before(f.From):
+		return false
+	case !f.To.IsZero() && !entry.At.Before(f.To):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// Bag statuses, in the order a bag normally goes through them
+const (
+	BagChecked    = "checked"
+	BagLoaded     = "loaded"
+	BagArrived    = "arrived"
+	BagMishandled = "mishandled" // Delayed, damaged or lost
+)
+
+// bagTransitions lists the statuses a bag may move to from each status
+var bagTransitions = map[string][]string{
+	BagChecked:    {BagLoaded, BagMishandled},
+	BagLoaded:     {BagArrived, BagMishandled},
+	BagArrived:    {BagMishandled},
+	BagMishandled: {BagArrived},
+}
+
+// BagEvent records a status change of a bag
+type BagEvent struct {
+	Status   string    `json:"status"`
+	At       time.Time `json:"at"`
+	Location string    `json:"location,omitempty"`
+}
+
+// Bag is a checked bag of a reservation
+type Bag struct {
+	TagNumber string     `json:"tag_number"` // Ten-digit bag tag licence plate
+	WeightKg  float64    `json:"weight_kg"`
+	Status    string     `json:"status"`
+	Charge    int64      `json:"charge,omitempty"` // Excess baggage charge, in minor currency units
+	History   []BagEvent `json:"history"`
+}
+
+// CanMoveTo reports whether the bag may move to a status
+func (b *Bag) CanMoveTo(status string) bool {
+	for _, next := range bagTransitions[b.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveTo changes the status of the bag and records the change
+func (b *Bag) MoveTo(status, location string, at time.Time) {
+	b.Status = status
+	b.History = append(b.History, BagEvent{Status: status, At: at, Location: location})
+}
+
+// BaggageAllowance is the checked baggage included in a fare class
+type BaggageAllowance struct {
+	Pieces     int     `json:"pieces"`
+	MaxPieceKg float64 `json:"max_piece_kg"`
+}
+
+// FindBag returns the bag of the reservation with a tag number, or nil
+func (r *Reservation) FindBag(tagNumber string) *Bag {
+	for i := range r.Bags {
+		if r.Bags[i].TagNumber == tagNumber {
+			return &r.Bags[i]
+		}
+	}
+	return nil
+}
+
+// BaggageWeight returns the total weight of the reservation's checked bags
+func (r *Reservation) BaggageWeight() float64 {
+	total := 0.0
+	for _, bag := range r.Bags {
+		total += bag.WeightKg
+	}
+	return total
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// Boarding group schemes
+const (
+	BoardingByClass = "class" // Business boards first, then economy
+	BoardingByRows  = "rows"  // Business boards first, then economy from the back rows forward
+)
+
+// BoardingSession tracks the boarding of a flight at the gate
+type BoardingSession struct {
+	FlightNumber   string                  `json:"flight_number"`
+	Scheme         string                  `json:"scheme"`
+	EconomyGroups  int                     `json:"economy_groups"` // Number of economy groups in the rows scheme
+	CalledGroup    int                     `json:"called_group"`   // Highest group called to board so far
+	OpenedAt       time.Time               `json:"opened_at"`
+	ClosedAt       *time.Time              `json:"closed_at,omitempty"`
+	Reconciliation *BoardingReconciliation `json:"reconciliation,omitempty"`
+}
+
+// IsOpen reports whether passengers can still board
+func (s *BoardingSession) IsOpen() bool {
+	return s.ClosedAt == nil
+}
+
+// GroupCount returns the number of boarding groups of the session
+func (s *BoardingSession) GroupCount() int {
+	if s.Scheme == BoardingByRows && s.EconomyGroups > 0 {
+		return 1 + s.EconomyGroups
+	}
+	return 2
+}
+
+// GroupOf returns the boarding group of a checked-in passenger on a flight with the given
+// number of seat rows. Group 1 boards first.
+func (s *BoardingSession) GroupOf(reservation *Reservation, rows int) int {
+	if reservation.Class == ClassBusiness {
+		return 1
+	}
+	if s.Scheme != BoardingByRows || s.EconomyGroups <= 1 || rows <= 0 {
+		return 2
+	}
+
+	// Economy rows are split into equal blocks boarded from the back of the cabin
+	row := reservation.SeatRow()
+	if row < 1 {
+		row = 1
+	}
+	if row > rows {
+		row = rows
+	}
+	block := (row - 1) * s.EconomyGroups / rows
+	return 1 + s.EconomyGroups - block
+}
+
+// BoardingReconciliation compares checked-in and boarded passengers when boarding closes
+type BoardingReconciliation struct {
+	CheckedIn int       `json:"checked_in"`
+	Boarded   int       `json:"boarded"`
+	Offloaded []string  `json:"offloaded,omitempty"` // Reservations of checked-in passengers who did not board
+	ClosedAt  time.Time `json:"closed_at"`
+}
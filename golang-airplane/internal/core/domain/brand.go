@@ -0,0 +1,25 @@
+package domain
+
+// Brand holds the airline branding applied to printed documents. The footer fields
+// are text/template strings executed with the document being printed.
+type Brand struct {
+	ID                 string `json:"id"`
+	AirlineName        string `json:"airline_name"`
+	PrimaryColor       string `json:"primary_color"` // Hex colour such as #0B3D91
+	AccentColor        string `json:"accent_color"`
+	Font               string `json:"font"`                // UTF-8 font embedded in the renderer: DejaVu Sans
+	LogoPath           string `json:"logo_path,omitempty"` // PNG or JPEG printed in the header
+	BoardingPassFooter string `json:"boarding_pass_footer,omitempty"`
+	ReceiptFooter      string `json:"receipt_footer,omitempty"`
+}
+
+// DefaultBrand is used when no brand is configured
+var DefaultBrand = Brand{
+	ID:                 "default",
+	AirlineName:        "Golang Airlines",
+	PrimaryColor:       "#0B3D91",
+	AccentColor:        "#F2A900",
+	Font:               "DejaVu Sans",
+	BoardingPassFooter: "Gate closes 15 minutes before departure. Thank you for flying {{.Brand.AirlineName}}.",
+	ReceiptFooter:      "Booking {{.Reservation.ReservationID}} was issued on {{.Reservation.ReservationTime.Format \"02/01/2006\"}}. Thank you for choosing {{.Brand.AirlineName}}.",
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Travel document types
+const (
+	DocumentPassport = "passport"
+	DocumentVisa     = "visa"
+	DocumentIDCard   = "id_card"
+)
+
+// TravelDocument is an identity or travel document presented by a passenger
+type TravelDocument struct {
+	Type     string    `json:"type"`
+	Number   string    `json:"number"`
+	ExpiryAt time.Time `json:"expiry_at"`
+}
+
+// CheckInPolicy defines when check-in is open for the flights of a route and
+// which documents passengers must present
+type CheckInPolicy struct {
+	DepartureCity       string   `json:"departure_city"`
+	DestinationCity     string   `json:"destination_city"`
+	OpensMinutesBefore  int      `json:"opens_minutes_before"`
+	ClosesMinutesBefore int      `json:"closes_minutes_before"`
+	RequiredDocuments   []string `json:"required_documents,omitempty"`
+}
+
+// DefaultCheckInPolicy opens check-in 24 hours and closes it 45 minutes before departure
+var DefaultCheckInPolicy = CheckInPolicy{
+	OpensMinutesBefore:  24 * 60,
+	ClosesMinutesBefore: 45,
+}
+
+// AppliesTo reports whether the policy covers the route of a flight
+func (p *CheckInPolicy) AppliesTo(flight *Flight) bool {
+	return strings.EqualFold(p.DepartureCity, flight.DepartureCity) &&
+		strings.EqualFold(p.DestinationCity, flight.DestinationCity)
+}
+
+// Window returns when check-in opens and closes for a flight
+func (p *CheckInPolicy) Window(flight *Flight) (time.Time, time.Time) {
+	opens := flight.DepartureTime.Add(-time.Duration(p.OpensMinutesBefore) * time.Minute)
+	closes := flight.DepartureTime.Add(-time.Duration(p.ClosesMinutesBefore) * time.Minute)
+	return opens, closes
+}
+
+// CheckInRejectionReason identifies why a check-in was refused
+type CheckInRejectionReason string
+
+// Check-in rejection reasons
+const (
+	CheckInNotOpen          CheckInRejectionReason = "not_open"
+	CheckInClosed           CheckInRejectionReason = "closed"
+	CheckInFlightCancelled  CheckInRejectionReason = "flight_cancelled"
+	CheckInFlightDeparted   CheckInRejectionReason = "flight_departed"
+	CheckInAlreadyCheckedIn CheckInRejectionReason = "already_checked_in"
+	CheckInNotConfirmed     CheckInRejectionReason = "reservation_not_confirmed"
+	CheckInMissingDocument  CheckInRejectionReason = "missing_document"
+	CheckInDocumentExpired  CheckInRejectionReason = "document_expired"
+	CheckInSeatRestricted   CheckInRejectionReason = "seat_restricted"
+)
+
+// CheckInRejection is returned when a reservation is not eligible for check-in
+type CheckInRejection struct {
+	Reason        CheckInRejectionReason
+	ReservationID string
+	Message       string
+}
+
+// Error returns the rejection message
+func (e *CheckInRejection) Error() string {
+	return fmt.Sprintf("check-in rejected for reservation %s: %s", e.ReservationID, e.Message)
+}
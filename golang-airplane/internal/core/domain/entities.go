@@ -8,9 +8,10 @@ import (
 
 // Airplane represents an aircraft that can be assigned to flights
 type Airplane struct {
-	ID       string `json:"id"`
-	Model    string `json:"model"`
-	Capacity int    `json:"capacity"`
+	ID          string             `json:"id"`
+	Model       string             `json:"model"`
+	Capacity    int                `json:"capacity"`
+	Maintenance []MaintenanceEvent `json:"maintenance,omitempty"`
 }
 
 // NewAirplane creates a new Airplane instance
@@ -22,23 +23,134 @@ func NewAirplane(id string, model string, capacity int) *Airplane {
 	}
 }
 
+// Crew positions
+const (
+	PositionPilot       = "Pilot"
+	PositionAttendant   = "Attendant"
+	PositionGroundStaff = "Ground Staff"
+)
+
+// IsCrewPosition reports whether a position is one of the crew positions
+func IsCrewPosition(position string) bool {
+	switch position {
+	case PositionPilot, PositionAttendant, PositionGroundStaff:
+		return true
+	}
+	return false
+}
+
+// CheckCrewPosition rejects a crew member whose position is not one of the crew positions
+func CheckCrewPosition(member Crew) error {
+	if !IsCrewPosition(member.Position) {
+		return Rejectf("%s has unknown crew position %q; it must be %s, %s or %s", member.Name, member.Position,
+			PositionPilot, PositionAttendant, PositionGroundStaff)
+	}
+	return nil
+}
+
 // Crew represents a crew member for a flight
 type Crew struct {
+	ID       string `json:"id,omitempty"` // Registry ID, empty for crew entered by hand
+	Name     string `json:"name"`
+	Position string `json:"position"`
+}
+
+// Matches reports whether the crew entry is identified by the given registry ID or name
+func (c Crew) Matches(idOrName string) bool {
+	if c.ID != "" && c.ID == idOrName {
+		return true
+	}
+	return strings.EqualFold(c.Name, idOrName)
+}
+
+// ValidateCrew checks the position minimums of a flight crew: at least one
+// pilot, attendant and ground staff, and at most two pilots
+func ValidateCrew(crewMembers []Crew) error {
+	counts := make(map[string]int)
+	for _, crew := range crewMembers {
+		counts[crew.Position]++
+	}
+
+	if counts[PositionPilot] < 1 || counts[PositionAttendant] < 1 || counts[PositionGroundStaff] < 1 {
+		return Rejectf("crew must have at least one member for each position (Pilot, Attendant, Ground Staff)")
+	}
+	if counts[PositionPilot] > 2 {
+		return Rejectf("maximum 2 pilots allowed")
+	}
+
+	return nil
+}
+
+// Crew change actions
+const (
+	CrewChangeAdd    = "add"
+	CrewChangeRemove = "remove"
+	CrewChangeSwap   = "swap"
+)
+
+// CrewChange records a single edit to the crew of a flight
+type CrewChange struct {
+	FlightNumber string    `json:"flight_number"`
+	Action       string    `json:"action"`
+	Removed      *Crew     `json:"removed,omitempty"`
+	Added        *Crew     `json:"added,omitempty"`
+	ChangedBy    string    `json:"changed_by"`
+	ChangedAt    time.Time `json:"changed_at"`
+}
+
+// CrewMember represents a crew member registered for rostering
+type CrewMember struct {
+	ID       string `json:"id"`
 	Name     string `json:"name"`
 	Position string `json:"position"`
+	HomeBase string `json:"home_base"` // City the crew member is based in
 }
 
+// AsCrew returns the flight crew entry for the registered crew member
+func (m *CrewMember) AsCrew() Crew {
+	return Crew{
+		ID:       m.ID,
+		Name:     m.Name,
+		Position: m.Position,
+	}
+}
+
+// Flight statuses; flights stored before statuses existed have an empty status and are scheduled
+const (
+	FlightScheduled = "scheduled"
+	FlightDelayed   = "delayed"
+	FlightBoarding  = "boarding"
+	FlightClosed    = "closed" // Doors closed and manifest frozen
+	FlightCancelled = "cancelled"
+	FlightDeparted  = "departed"
+)
+
+// Capacity limits of commercial flights
+const (
+	MinFlightCapacity = 36
+	MaxFlightCapacity = 853
+)
+
 // Flight represents an airplane flight
 type Flight struct {
-	FlightNumber    string            `json:"flight_number"`
-	DepartureCity   string            `json:"departure_city"`
-	DestinationCity string            `json:"destination_city"`
-	DepartureTime   time.Time         `json:"departure_time"`
-	ArrivalTime     time.Time         `json:"arrival_time"`
-	FlightCapacity  int               `json:"flight_capacity"` // Total capacity of the flight
-	AvailableSeat   int               `json:"available_seat"`  // Available seats
-	CrewMembers     []Crew            `json:"crew_members"`
-	SeatList        map[string]bool   `json:"seat_list"` // key=seat number, value=available(true)/occupied(false)
+	FlightNumber    string              `json:"flight_number"`
+	DepartureCity   string              `json:"departure_city"`
+	DestinationCity string              `json:"destination_city"`
+	DepartureTime   time.Time           `json:"departure_time"`
+	ArrivalTime     time.Time           `json:"arrival_time"`
+	FlightCapacity  int                 `json:"flight_capacity"` // Total capacity of the flight
+	AvailableSeat   int                 `json:"available_seat"`  // Available seats
+	CrewMembers     []Crew              `json:"crew_members"`
+	AirplaneID      string              `json:"airplane_id,omitempty"` // Airplane operating the flight
+	Status          string              `json:"status,omitempty"`
+	Gate            string              `json:"gate,omitempty"`
+	Fares           map[string]int64    `json:"fares,omitempty"` // Base fare per class, in minor currency units
+	ExitRows        []int               `json:"exit_rows,omitempty"`
+	BusinessRows    int                 `json:"business_rows,omitempty"`   // Number of front rows forming the business cabin
+	SSRLimits       map[string]int      `json:"ssr_limits,omitempty"`      // Per-flight overrides of special service limits
+	SeatAttributes  map[string][]string `json:"seat_attributes,omitempty"` // Configured attributes per seat number
+	SeatPrices      map[string]int64    `json:"seat_prices,omitempty"`     // Price of choosing a seat per attribute; DefaultSeatPrices when unset
+	SeatList        map[string]bool     `json:"seat_list"`                 // key=seat number, value=available(true)/occupied(false)
 }
 
 // NewFlight creates a new Flight instance
@@ -52,6 +164,7 @@ func NewFlight(flightNumber, departureCity, destinationCity string, departureTim
 		FlightCapacity:  availableSeat,
 		AvailableSeat:   availableSeat,
 		CrewMembers:     []Crew{},
+		Status:          FlightScheduled,
 		SeatList:        make(map[string]bool),
 	}
 	flight.generateSeatList()
@@ -61,7 +174,7 @@ func NewFlight(flightNumber, departureCity, destinationCity string, departureTim
 // GenerateSeatList creates the initial seat map for the flight
 func (f *Flight) generateSeatList() {
 	row := 1
-	seatLetters := []rune{'A', 'B', 'C', 'D'} 
+	seatLetters := []rune{'A', 'B', 'C', 'D'}
 	seatIndex := 0
 
 	for i := 0; i < f.FlightCapacity; i++ {
@@ -76,11 +189,91 @@ func (f *Flight) generateSeatList() {
 	}
 }
 
+// CurrentStatus returns the status of the flight, treating an empty status as scheduled
+func (f *Flight) CurrentStatus() string {
+	if f.Status == "" {
+		return FlightScheduled
+	}
+	return f.Status
+}
+
+// BoardingLead is how long before departure boarding starts
+const BoardingLead = 40 * time.Minute
+
+// BoardingTime returns when boarding of the flight starts
+func (f *Flight) BoardingTime() time.Time {
+	return f.DepartureTime.Add(-BoardingLead)
+}
+
+// IsClosed reports whether the flight no longer accepts passenger changes
+func (f *Flight) IsClosed() bool {
+	status := f.CurrentStatus()
+	return status == FlightClosed || status == FlightDeparted || status == FlightCancelled
+}
+
+// HasFreeSeat reports whether any seat of the flight that is neither blocked nor among the held seats
+// is still available; held may be nil
+func (f *Flight) HasFreeSeat(held map[string]bool) bool {
+	for seat, available := range f.SeatList {
+		if available && !f.IsSeatBlocked(seat) && !held[seat] {
+			return true
+		}
+	}
+	return false
+}
+
+// SeatRows returns the number of seat rows of the flight
+func (f *Flight) SeatRows() int {
+	return (f.FlightCapacity + 3) / 4
+}
+
+// Resize changes the capacity of the flight, adding seats at the back or removing
+// the last seats, which must not be occupied
+func (f *Flight) Resize(capacity int) error {
+	sold := f.FlightCapacity - f.AvailableSeat
+	if capacity < sold {
+		return Rejectf("capacity %d is below the %d seats already sold", capacity, sold)
+	}
+
+	seatLetters := []rune{'A', 'B', 'C', 'D'}
+	seatNumber := func(i int) string {
+		return fmt.Sprintf("%d%c", i/len(seatLetters)+1, seatLetters[i%len(seatLetters)])
+	}
+
+	for i := capacity; i < f.FlightCapacity; i++ {
+		if available, exists := f.SeatList[seatNumber(i)]; exists && !available {
+			return Rejectf("seat %s is occupied and cannot be removed", seatNumber(i))
+		}
+	}
+
+	for i := capacity; i < f.FlightCapacity; i++ {
+		delete(f.SeatList, seatNumber(i))
+		delete(f.SeatAttributes, seatNumber(i))
+	}
+	for i := f.FlightCapacity; i < capacity; i++ {
+		f.SeatList[seatNumber(i)] = true
+	}
+
+	f.AvailableSeat += capacity - f.FlightCapacity
+	f.FlightCapacity = capacity
+	return nil
+}
+
 // AddCrewMember adds a new crew member to the flight
 func (f *Flight) AddCrewMember(crew Crew) {
 	f.CrewMembers = append(f.CrewMembers, crew)
 }
 
+// FindCrewMember returns the index of the crew member with the given registry ID or name, or -1
+func (f *Flight) FindCrewMember(idOrName string) int {
+	for i, crew := range f.CrewMembers {
+		if crew.Matches(idOrName) {
+			return i
+		}
+	}
+	return -1
+}
+
 // AssignCrew assigns multiple crew members to the flight
 func (f *Flight) AssignCrew(crewMembers []Crew) {
 	f.CrewMembers = crewMembers
@@ -107,6 +300,9 @@ func (f *Flight) String() string {
 	sb.WriteString(fmt.Sprintf("| Arrival Time              | %-27s       |\n", f.ArrivalTime.Format("02/01/2006-15:04")))
 	sb.WriteString(fmt.Sprintf("| Available Seat            | %-27d       |\n", f.AvailableSeat))
 	sb.WriteString(fmt.Sprintf("| Flight duration           | %-27s       |\n", f.GetDuration()))
+	if f.Gate != "" {
+		sb.WriteString(fmt.Sprintf("| Gate                      | %-27s       |\n", f.Gate))
+	}
 	sb.WriteString("+---------------------------+-----------------------------------+\n")
 
 	// Crew Information
@@ -115,7 +311,7 @@ func (f *Flight) String() string {
 		sb.WriteString("+-------------------+-------------------+\n")
 		sb.WriteString("|        Name       |     Position      |\n")
 		sb.WriteString("+-------------------+-------------------+\n")
-		
+
 		for _, crew := range f.CrewMembers {
 			sb.WriteString(fmt.Sprintf("|%-18s |%-18s |\n", crew.Name, crew.Position))
 			sb.WriteString("+-------------------+-------------------+\n")
@@ -128,17 +324,51 @@ func (f *Flight) String() string {
 	return sb.String()
 }
 
+// Cabin classes
+const (
+	ClassEconomy  = "Economy"
+	ClassBusiness = "Business"
+)
+
+// CheckClass rejects a cabin class other than Economy and Business
+func CheckClass(class string) error {
+	if class != ClassEconomy && class != ClassBusiness {
+		return Rejectf("class must be %s or %s", ClassEconomy, ClassBusiness)
+	}
+	return nil
+}
+
+// Reservation statuses; reservations stored before statuses existed have an empty status and are confirmed
+const (
+	ReservationConfirmed = "confirmed"
+	ReservationPending   = "pending" // Offered from the waitlist, waiting for the passenger to confirm
+	ReservationCancelled = "cancelled"
+	ReservationDenied    = "denied_boarding" // Offloaded from an oversold flight
+	ReservationOffloaded = "offloaded"       // Checked in but did not board before boarding closed, a no-show
+)
+
 // Reservation represents a flight booking
 type Reservation struct {
-	ReservationID           string    `json:"reservation_id"`
-	Name                    string    `json:"name"`
-	Address                 string    `json:"address"`
-	PhoneNumber             int64     `json:"phone_number"`
-	IdentityCardNumber      int64     `json:"identity_card_number"`
-	ReservationFlightNumber string    `json:"reservation_flight_number"`
-	SeatLocation            string    `json:"seat_location"`
-	CheckedIn               bool      `json:"checked_in"`
-	ReservationTime         time.Time `json:"reservation_time"`
+	ReservationID           string                  `json:"reservation_id"`
+	Name                    string                  `json:"name"`
+	Address                 string                  `json:"address"`
+	PhoneNumber             int64                   `json:"phone_number"`
+	IdentityCardNumber      int64                   `json:"identity_card_number"`
+	ReservationFlightNumber string                  `json:"reservation_flight_number"`
+	Class                   string                  `json:"class,omitempty"`
+	Status                  string                  `json:"status,omitempty"`
+	SeatLocation            string                  `json:"seat_location"`
+	CheckedIn               bool                    `json:"checked_in"`
+	CheckInSequence         int                     `json:"check_in_sequence,omitempty"` // Order of check-in on the flight
+	BoardedAt               *time.Time              `json:"boarded_at,omitempty"`
+	Documents               []TravelDocument        `json:"documents,omitempty"`
+	Fare                    *Fare                   `json:"fare,omitempty"`
+	Bags                    []Bag                   `json:"bags,omitempty"`
+	SpecialServices         []SpecialServiceRequest `json:"special_services,omitempty"`
+	SeatPreference          *SeatPreference         `json:"seat_preference,omitempty"`
+	GroupID                 string                  `json:"group_id,omitempty"`  // Reservations travelling together share a group
+	Volunteer               bool                    `json:"volunteer,omitempty"` // Willing to give up their seat on an oversold flight
+	ReservationTime         time.Time               `json:"reservation_time"`
 }
 
 // NewReservation creates a new Reservation
@@ -150,6 +380,8 @@ func NewReservation(name, address string, phoneNumber, identityCardNumber int64,
 		PhoneNumber:             phoneNumber,
 		IdentityCardNumber:      identityCardNumber,
 		ReservationFlightNumber: flightNumber,
+		Class:                   ClassEconomy,
+		Status:                  ReservationConfirmed,
 		CheckedIn:               false,
 		ReservationTime:         time.Now(),
 	}
@@ -164,11 +396,38 @@ func generateReservationID() string {
 	return fmt.Sprintf("R%04d", reservationIDCounter)
 }
 
+// IsConfirmed reports whether the reservation holds a confirmed seat
+func (r *Reservation) IsConfirmed() bool {
+	return r.Status == "" || r.Status == ReservationConfirmed
+}
+
+// IsCancelled reports whether the reservation has been cancelled
+func (r *Reservation) IsCancelled() bool {
+	return r.Status == ReservationCancelled
+}
+
 // CheckIn marks the reservation as checked in
 func (r *Reservation) CheckIn() {
 	r.CheckedIn = true
 }
 
+// IsBoarded reports whether the passenger has boarded the flight
+func (r *Reservation) IsBoarded() bool {
+	return r.BoardedAt != nil
+}
+
+// SeatRow returns the row number of the reservation's seat, or 0 without a seat
+func (r *Reservation) SeatRow() int {
+	row := 0
+	for _, c := range r.SeatLocation {
+		if c < '0' || c > '9' {
+			break
+		}
+		row = row*10 + int(c-'0')
+	}
+	return row
+}
+
 // String returns a string representation of the reservation
 func (r *Reservation) String() string {
 	var sb strings.Builder
@@ -191,6 +450,12 @@ func (r *Reservation) String() string {
 		checkInStatus = "Yes"
 	}
 	sb.WriteString(fmt.Sprintf("| Checked In              | %-30s |\n", checkInStatus))
+	if r.Fare != nil {
+		sb.WriteString(fmt.Sprintf("| Fare                    | %-30s |\n", FormatAmount(r.Fare.Total(), r.Fare.Currency)))
+	}
+	if len(r.SpecialServices) > 0 {
+		sb.WriteString(fmt.Sprintf("| Special Services        | %-30s |\n", strings.Join(r.SpecialServiceCodes(), " ")))
+	}
 	sb.WriteString("+-------------------------+----------------------------------+\n")
 	return sb.String()
 }
@@ -212,4 +477,4 @@ func (r *Reservation) BoardingPassToString(flight *Flight) string {
 	sb.WriteString("|                 THANK YOU FOR FLYING WITH US                 |\n")
 	sb.WriteString("+---------------------------------------------------------------+\n")
 	return sb.String()
-}
\ No newline at end of file
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors wrapped by repositories and services so callers can tell failures apart
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrUnauthorized  = errors.New("invalid username or password")
+	ErrForbidden     = errors.New("permission denied")
+	ErrConflict      = errors.New("changed concurrently")
+)
+
+// Rejection is returned by services for a request that breaks a business rule, as opposed to a failure
+// of the storage; its message is meant for the caller
+type Rejection struct {
+	Message string
+}
+
+// Error returns the rejection message
+func (e *Rejection) Error() string {
+	return e.Message
+}
+
+// Rejectf formats the Rejection of a request
+func Rejectf(format string, args ...interface{}) error {
+	return &Rejection{Message: fmt.Sprintf(format, args...)}
+}
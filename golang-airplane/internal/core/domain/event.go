@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Domain event types
+const (
+	EventFlightCreated       = "flight.created"
+	EventFlightStatusChanged = "flight.status_changed"
+	EventReservationBooked   = "reservation.booked"
+	EventCheckedIn           = "reservation.checked_in"
+	EventCrewAssigned        = "flight.crew_assigned"
+	EventSeatReleased        = "flight.seat_released"
+)
+
+// EventTypes lists the domain event types
+var EventTypes = []string{EventFlightCreated, EventFlightStatusChanged, EventReservationBooked, EventCheckedIn,
+	EventCrewAssigned, EventSeatReleased}
+
+// Event is something that happened to flights or reservations, published by the services once it is stored
+type Event interface {
+	// EventType returns the type of the event, one of EventTypes
+	EventType() string
+}
+
+// FlightCreated is published when a flight is added
+type FlightCreated struct {
+	FlightNumber    string    `json:"flight_number"`
+	DepartureCity   string    `json:"departure_city"`
+	DestinationCity string    `json:"destination_city"`
+	DepartureTime   time.Time `json:"departure_time"`
+	ArrivalTime     time.Time `json:"arrival_time"`
+	Capacity        int       `json:"capacity"`
+}
+
+// EventType returns EventFlightCreated
+func (e *FlightCreated) EventType() string { return EventFlightCreated }
+
+// FlightStatusChanged is published when the operational status of a flight changes, such as when it is delayed
+type FlightStatusChanged struct {
+	FlightNumber   string `json:"flight_number"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+}
+
+// EventType returns EventFlightStatusChanged
+func (e *FlightStatusChanged) EventType() string { return EventFlightStatusChanged }
+
+// ReservationBooked is published when a flight is booked
+type ReservationBooked struct {
+	ReservationID string `json:"reservation_id"`
+	FlightNumber  string `json:"flight_number"`
+	Name          string `json:"name"`
+	Class         string `json:"class"`
+}
+
+// EventType returns EventReservationBooked
+func (e *ReservationBooked) EventType() string { return EventReservationBooked }
+
+// CheckedIn is published when a passenger checks in on a seat
+type CheckedIn struct {
+	ReservationID string `json:"reservation_id"`
+	FlightNumber  string `json:"flight_number"`
+	Seat          string `json:"seat"`
+	Sequence      int    `json:"sequence"`
+}
+
+// EventType returns EventCheckedIn
+func (e *CheckedIn) EventType() string { return EventCheckedIn }
+
+// CrewAssigned is published when the crew of a flight is assigned or edited, with the crew as it now stands
+type CrewAssigned struct {
+	FlightNumber string `json:"flight_number"`
+	Action       string `json:"action"` // CrewAssign, or the CrewChange action of an edit
+	Crew         []Crew `json:"crew"`
+	ChangedBy    string `json:"changed_by,omitempty"`
+}
+
+// EventType returns EventCrewAssigned
+func (e *CrewAssigned) EventType() string { return EventCrewAssigned }
+
+// CrewAssign is the action of a CrewAssigned event for a first assignment
+const CrewAssign = "assign"
+
+// SeatReleased is published when seats of a flight become available again, by a cancellation or a capacity increase
+type SeatReleased struct {
+	FlightNumber  string `json:"flight_number"`
+	Seats         int    `json:"seats"`                    // Number of seats released
+	ReservationID string `json:"reservation_id,omitempty"` // Cancelled reservation, if any
+	Seat          string `json:"seat,omitempty"`           // Seat the cancelled reservation occupied, if any
+}
+
+// EventType returns EventSeatReleased
+func (e *SeatReleased) EventType() string { return EventSeatReleased }
+
+// EventMessage is a published event as kept in the outbox
+type EventMessage struct {
+	ID          string               `json:"id"`
+	Type        string               `json:"type"`
+	OccurredAt  time.Time            `json:"occurred_at"`
+	Payload     json.RawMessage      `json:"payload"`
+	Subscribers []string             `json:"subscribers,omitempty"` // Asynchronous subscribers owed the event
+	Delivered   map[string]time.Time `json:"delivered,omitempty"`   // When each of them handled it
+	Event       Event                `json:"-"`                     // Decoded payload
+}
+
+// IsPending reports whether an asynchronous subscriber is still owed the event
+func (m *EventMessage) IsPending(subscriber string) bool {
+	if _, delivered := m.Delivered[subscriber]; delivered {
+		return false
+	}
+	for _, name := range m.Subscribers {
+		if name == subscriber {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPending reports whether some asynchronous subscriber is still owed the event
+func (m *EventMessage) HasPending() bool {
+	for _, name := range m.Subscribers {
+		if m.IsPending(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewEvent returns an empty event of a type, to decode a payload into
+func NewEvent(eventType string) (Event, error) {
+	switch eventType {
+	case EventFlightCreated:
+		return &FlightCreated{}, nil
+	case EventFlightStatusChanged:
+		return &FlightStatusChanged{}, nil
+	case EventReservationBooked:
+		return &ReservationBooked{}, nil
+	case EventCheckedIn:
+		return &CheckedIn{}, nil
+	case EventCrewAssigned:
+		return &CrewAssigned{}, nil
+	case EventSeatReleased:
+		return &SeatReleased{}, nil
+	}
+	return nil, fmt.Errorf("unknown event type %q", eventType)
+}
+
+// EventFilter selects outbox messages; empty fields match every message
+type EventFilter struct {
+	Type string
+	From time.Time // Inclusive
+	To   time.Time // Exclusive
+}
+
+// Matches reports whether a message is selected by the filter
+func (f EventFilter) Matches(message *EventMessage) bool {
+	switch {
+	case f.Type != "" && message.Type != f.Type:
+		return false
+	case !f.From.IsZero() && message.OccurredAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !message.OccurredAt.Before(f.To):
+		return false
+	}
+	return true
+}
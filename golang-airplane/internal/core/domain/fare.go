@@ -0,0 +1,40 @@
+package domain
+
+import "fmt"
+
+// FareComponent is a tax or fee charged on top of the base fare, in minor currency units
+type FareComponent struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Amount      int64  `json:"amount"`
+}
+
+// Fare is the price paid for a reservation, in minor currency units
+type Fare struct {
+	Currency string          `json:"currency"`
+	Base     int64           `json:"base"`
+	Taxes    []FareComponent `json:"taxes,omitempty"`
+	Fees     []FareComponent `json:"fees,omitempty"`
+}
+
+// Total returns the base fare plus all taxes and fees
+func (f *Fare) Total() int64 {
+	total := f.Base
+	for _, tax := range f.Taxes {
+		total += tax.Amount
+	}
+	for _, fee := range f.Fees {
+		total += fee.Amount
+	}
+	return total
+}
+
+// FormatAmount formats an amount in minor units of a currency with two decimals
+func FormatAmount(amount int64, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%s %d.%02d", sign, currency, amount/100, amount%100)
+}
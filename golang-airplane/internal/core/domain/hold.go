@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// SeatHold temporarily reserves inventory on a flight, or a specific seat, for a session
+type SeatHold struct {
+	ID           string    `json:"id"`
+	SessionToken string    `json:"session_token"`
+	FlightNumber string    `json:"flight_number"`
+	Seat         string    `json:"seat,omitempty"` // Empty for a hold on unassigned inventory
+	Quantity     int       `json:"quantity"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// IsActive reports whether the hold is still in force at the given time
+func (h *SeatHold) IsActive(now time.Time) bool {
+	return now.Before(h.ExpiresAt)
+}
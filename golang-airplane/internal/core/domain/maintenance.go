@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// Maintenance event types
+const (
+	MaintenanceACheck = "A-check"
+	MaintenanceCCheck = "C-check"
+	MaintenanceAOG    = "AOG" // Aircraft on ground, unscheduled
+)
+
+// MaintenanceEvent represents a period during which an airplane is unavailable
+type MaintenanceEvent struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Location string    `json:"location"`
+}
+
+// Overlaps reports whether the maintenance event overlaps the period from start to end
+func (m *MaintenanceEvent) Overlaps(start, end time.Time) bool {
+	return m.Start.Before(end) && start.Before(m.End)
+}
+
+// MaintenanceDuring returns the first maintenance event overlapping the period from start to end, or nil
+func (a *Airplane) MaintenanceDuring(start, end time.Time) *MaintenanceEvent {
+	for i := range a.Maintenance {
+		if a.Maintenance[i].Overlaps(start, end) {
+			return &a.Maintenance[i]
+		}
+	}
+	return nil
+}
+
+// CheckInterval defines how often a scheduled check is due, whichever limit is reached first
+type CheckInterval struct {
+	Type        string
+	FlightHours float64
+	Cycles      int
+}
+
+// DefaultMaintenanceProgram lists the scheduled checks from lightest to heaviest;
+// completing a check also counts as completing every lighter one
+var DefaultMaintenanceProgram = []CheckInterval{
+	{Type: MaintenanceACheck, FlightHours: 600, Cycles: 400},
+	{Type: MaintenanceCCheck, FlightHours: 6000, Cycles: 4000},
+}
+
+// CheckStatus shows how close an airplane is to its next scheduled check
+type CheckStatus struct {
+	AirplaneID      string            `json:"airplane_id"`
+	Model           string            `json:"model"`
+	CheckType       string            `json:"check_type"`
+	LastDone        time.Time         `json:"last_done"` // Zero if the check was never done
+	HoursSince      float64           `json:"hours_since"`
+	CyclesSince     int               `json:"cycles_since"`
+	HoursRemaining  float64           `json:"hours_remaining"`
+	CyclesRemaining int               `json:"cycles_remaining"`
+	Scheduled       *MaintenanceEvent `json:"scheduled,omitempty"` // Next planned check of this type
+	DueSoon         bool              `json:"due_soon"`            // Less than a tenth of the interval is left
+}
+
+// Overdue reports whether either limit of the check has been exceeded
+func (c *CheckStatus) Overdue() bool {
+	return c.HoursRemaining < 0 || c.CyclesRemaining < 0
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// ManifestPassenger is one passenger line of a flight manifest
+type ManifestPassenger struct {
+	ReservationID      string     `json:"reservation_id"`
+	Name               string     `json:"name"`
+	IdentityCardNumber int64      `json:"identity_card_number"`
+	Class              string     `json:"class"`
+	Seat               string     `json:"seat,omitempty"`
+	Status             string     `json:"status"`
+	CheckedIn          bool       `json:"checked_in"`
+	Boarded            bool       `json:"boarded"`
+	BoardedAt          *time.Time `json:"boarded_at,omitempty"`
+	SpecialServices    []string   `json:"special_services,omitempty"`
+}
+
+// ManifestBag is one line of the baggage load list of a flight
+type ManifestBag struct {
+	TagNumber     string  `json:"tag_number"`
+	ReservationID string  `json:"reservation_id"`
+	Name          string  `json:"name"`
+	WeightKg      float64 `json:"weight_kg"`
+	Status        string  `json:"status"`
+	Offload       bool    `json:"offload"` // The passenger is not travelling, so the bag must not fly
+}
+
+// Manifest lists the passengers and crew of a flight for departure control
+type Manifest struct {
+	FlightNumber    string              `json:"flight_number"`
+	DepartureCity   string              `json:"departure_city"`
+	DestinationCity string              `json:"destination_city"`
+	DepartureTime   time.Time           `json:"departure_time"`
+	ArrivalTime     time.Time           `json:"arrival_time"`
+	AirplaneID      string              `json:"airplane_id,omitempty"`
+	Gate            string              `json:"gate,omitempty"`
+	Passengers      []ManifestPassenger `json:"passengers"`
+	Crew            []Crew              `json:"crew"`
+	Bags            []ManifestBag       `json:"bags,omitempty"` // Baggage load list
+	GeneratedAt     time.Time           `json:"generated_at"`
+	Final           bool                `json:"final"` // Frozen when the flight was closed
+}
+
+// BaggageWeight returns the total weight of the bags to load
+func (m *Manifest) BaggageWeight() float64 {
+	total := 0.0
+	for _, bag := range m.Bags {
+		if !bag.Offload {
+			total += bag.WeightKg
+		}
+	}
+	return total
+}
+
+// Boarded returns the number of passengers who boarded
+func (m *Manifest) Boarded() int {
+	boarded := 0
+	for _, passenger := range m.Passengers {
+		if passenger.Boarded {
+			boarded++
+		}
+	}
+	return boarded
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "strings"
+
+// OverbookingPolicy authorizes selling seats above the capacity of the flights on a route
+type OverbookingPolicy struct {
+	DepartureCity   string `json:"departure_city"`
+	DestinationCity string `json:"destination_city"`
+	Limit           int    `json:"limit"`        // Seats that may be sold above capacity, or the cap on the derived allowance
+	FromHistory     bool   `json:"from_history"` // Derive the allowance from the no-show rate of the route
+}
+
+// AppliesTo reports whether the policy covers the route of a flight
+func (p *OverbookingPolicy) AppliesTo(flight *Flight) bool {
+	return strings.EqualFold(p.DepartureCity, flight.DepartureCity) &&
+		strings.EqualFold(p.DestinationCity, flight.DestinationCity)
+}
+
+// NoShowStats summarises how many passengers of departed flights never checked in
+type NoShowStats struct {
+	Flights      int     `json:"flights"`
+	Reservations int     `json:"reservations"`
+	NoShows      int     `json:"no_shows"`
+	Rate         float64 `json:"rate"`
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// Seat attributes
+const (
+	SeatWindow       = "window"
+	SeatAisle        = "aisle"
+	SeatMiddle       = "middle"
+	SeatExitRow      = "exit_row"
+	SeatExtraLegroom = "extra_legroom"
+	SeatBassinet     = "bassinet" // Bulkhead seat with a bassinet mount for an infant
+	SeatBlocked      = "blocked"  // Not assigned to passengers, for example because it is broken or kept for crew
+)
+
+// SeatAttributeNames lists every seat attribute
+var SeatAttributeNames = []string{SeatWindow, SeatAisle, SeatMiddle, SeatExitRow, SeatExtraLegroom, SeatBassinet, SeatBlocked}
+
+// ConfigurableSeatAttributes are the attributes set per seat; the others follow from the cabin layout
+var ConfigurableSeatAttributes = []string{SeatMiddle, SeatExtraLegroom, SeatBassinet, SeatBlocked}
+
+// seatLetters are the seats of a row from left to right, with the aisle between B and C
+var seatLetters = []rune{'A', 'B', 'C', 'D'}
+
+// DefaultSeatPrices charges for choosing a seat with more legroom; other seats are free to choose
+var DefaultSeatPrices = map[string]int64{
+	SeatExtraLegroom: 3500,
+	SeatExitRow:      2500,
+}
+
+// SeatPreference describes the seat a passenger would like to be given
+type SeatPreference struct {
+	Position     string `json:"position,omitempty"` // SeatWindow, SeatAisle or empty for no preference
+	ExtraLegroom bool   `json:"extra_legroom,omitempty"`
+	Bassinet     bool   `json:"bassinet,omitempty"` // Travelling with an infant
+}
+
+// SeatLetter returns the letter of a seat number
+func SeatLetter(seat string) rune {
+	letter := rune(0)
+	for _, c := range seat {
+		letter = c
+	}
+	return letter
+}
+
+// SeatNumbers returns the seats of the flight ordered by row and letter
+func (f *Flight) SeatNumbers() []string {
+	seats := make([]string, 0, len(f.SeatList))
+	for seat := range f.SeatList {
+		seats = append(seats, seat)
+	}
+	sort.Slice(seats, func(i, j int) bool {
+		rowI := (&Reservation{SeatLocation: seats[i]}).SeatRow()
+		rowJ := (&Reservation{SeatLocation: seats[j]}).SeatRow()
+		if rowI != rowJ {
+			return rowI < rowJ
+		}
+		return seats[i] < seats[j]
+	})
+	return seats
+}
+
+// AttributesOf returns the attributes of a seat of the flight, from its position in the row,
+// the exit rows and the attributes configured for the seat
+func (f *Flight) AttributesOf(seat string) []string {
+	attributes := []string{}
+	switch letter := SeatLetter(seat); {
+	case letter == seatLetters[0] || letter == seatLetters[len(seatLetters)-1]:
+		attributes = append(attributes, SeatWindow)
+	case letter == seatLetters[1] || letter == seatLetters[2]:
+		attributes = append(attributes, SeatAisle)
+	}
+	if f.IsExitRow(seat) {
+		attributes = append(attributes, SeatExitRow)
+	}
+	for _, attribute := range f.SeatAttributes[seat] {
+		if !containsString(attributes, attribute) {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// HasSeatAttribute reports whether a seat of the flight has an attribute
+func (f *Flight) HasSeatAttribute(seat, attribute string) bool {
+	return containsString(f.AttributesOf(seat), attribute)
+}
+
+// IsSeatBlocked reports whether a seat of the flight is blocked from assignment
+func (f *Flight) IsSeatBlocked(seat string) bool {
+	return containsString(f.SeatAttributes[seat], SeatBlocked)
+}
+
+// SeatPrice returns the price of choosing a seat of the flight: the highest price of its attributes,
+// using the flight's seat prices or the default ones
+func (f *Flight) SeatPrice(seat string) int64 {
+	prices := f.SeatPrices
+	if prices == nil {
+		prices = DefaultSeatPrices
+	}
+
+	price := int64(0)
+	for _, attribute := range f.AttributesOf(seat) {
+		if prices[attribute] > price {
+			price = prices[attribute]
+		}
+	}
+	return price
+}
+
+// FormatSeatAttributes returns the attributes of a seat as a readable list
+func FormatSeatAttributes(attributes []string) string {
+	names := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		names[i] = strings.ReplaceAll(attribute, "_", " ")
+	}
+	return strings.Join(names, ", ")
+}
+
+// containsString reports whether a list contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package domain
+
+import "fmt"
+
+// Seat states shown on a seat map
+const (
+	SeatStateAvailable = "available"
+	SeatStateOccupied  = "occupied"
+	SeatStateHeld      = "held" // Held by another booking session
+	SeatStateBlocked   = "blocked"
+	SeatStateSelected  = "selected" // The seat being chosen by the viewer
+)
+
+// SeatMapSeat is one seat position of a seat map row; positions without a seat have an empty number
+type SeatMapSeat struct {
+	Number     string   `json:"number,omitempty"`
+	Column     string   `json:"column"`
+	State      string   `json:"state,omitempty"`
+	Attributes []string `json:"attributes,omitempty"`
+	Price      int64    `json:"price,omitempty"` // Price of choosing the seat, in minor currency units
+}
+
+// SeatMapRow is one row of a seat map
+type SeatMapRow struct {
+	Number  int           `json:"number"`
+	Cabin   string        `json:"cabin"`
+	ExitRow bool          `json:"exit_row"`
+	Seats   []SeatMapSeat `json:"seats"` // One position per column
+}
+
+// SeatMap is the cabin layout of a flight with the state of every seat, in row order
+type SeatMap struct {
+	FlightNumber string       `json:"flight_number"`
+	Columns      []string     `json:"columns"`
+	AisleAfter   []int        `json:"aisle_after"` // Indexes of the columns followed by an aisle
+	Rows         []SeatMapRow `json:"rows"`
+}
+
+// NewSeatMap builds the seat map of a flight; held lists the seats held by other sessions and
+// selected, which may be empty, is the seat being chosen
+func NewSeatMap(flight *Flight, held map[string]bool, selected string) *SeatMap {
+	seatMap := &SeatMap{
+		FlightNumber: flight.FlightNumber,
+		AisleAfter:   []int{1},
+		Rows:         []SeatMapRow{},
+	}
+	for _, letter := range seatLetters {
+		seatMap.Columns = append(seatMap.Columns, string(letter))
+	}
+
+	for row := 1; row <= flight.SeatRows(); row++ {
+		mapRow := SeatMapRow{
+			Number: row,
+			Cabin:  flight.CabinOf(row),
+		}
+
+		for _, letter := range seatLetters {
+			number := fmt.Sprintf("%d%c", row, letter)
+			seat := SeatMapSeat{Column: string(letter)}
+
+			if available, exists := flight.SeatList[number]; exists {
+				seat.Number = number
+				seat.Attributes = flight.AttributesOf(number)
+				seat.Price = flight.SeatPrice(number)
+				if flight.IsExitRow(number) {
+					mapRow.ExitRow = true
+				}
+
+				switch {
+				case number == selected:
+					seat.State = SeatStateSelected
+				case !available:
+					seat.State = SeatStateOccupied
+				case flight.IsSeatBlocked(number):
+					seat.State = SeatStateBlocked
+				case held[number]:
+					seat.State = SeatStateHeld
+				default:
+					seat.State = SeatStateAvailable
+				}
+			}
+
+			mapRow.Seats = append(mapRow.Seats, seat)
+		}
+
+		seatMap.Rows = append(seatMap.Rows, mapRow)
+	}
+
+	return seatMap
+}
+
+// HasAisleAfter reports whether an aisle follows a column of the seat map
+func (m *SeatMap) HasAisleAfter(column int) bool {
+	for _, index := range m.AisleAfter {
+		if index == column {
+			return true
+		}
+	}
+	return false
+}
+
+// InCabinOf reports whether a seat of the flight is in the cabin of the reservation's class; without a
+// business cabin every class shares the whole flight
+func (f *Flight) InCabinOf(seat string, reservation *Reservation) bool {
+	if f.BusinessRows == 0 {
+		return true
+	}
+	class := reservation.Class
+	if class == "" {
+		class = ClassEconomy
+	}
+	return f.CabinOf((&Reservation{SeatLocation: seat}).SeatRow()) == class
+}
+
+// CabinOf returns the cabin of a row of the flight; the first BusinessRows rows form the business cabin
+func (f *Flight) CabinOf(row int) string {
+	if row <= f.BusinessRows {
+		return ClassBusiness
+	}
+	return ClassEconomy
+}
+
+// CabinCapacity returns the number of seats of the flight in the cabin of a class; without a business
+// cabin every class shares the whole flight
+func (f *Flight) CabinCapacity(class string) int {
+	if f.BusinessRows == 0 {
+		return f.FlightCapacity
+	}
+	if class == "" {
+		class = ClassEconomy
+	}
+
+	seats := 0
+	for seat := range f.SeatList {
+		if f.CabinOf((&Reservation{SeatLocation: seat}).SeatRow()) == class {
+			seats++
+		}
+	}
+	return seats
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Special service request categories, which decide who needs to know about a request
+const (
+	SSRCategoryAssistance = "assistance"
+	SSRCategoryMeal       = "meal"
+	SSRCategoryMinor      = "minor"
+	SSRCategoryAnimal     = "animal"
+)
+
+// SpecialService describes a special service request code
+type SpecialService struct {
+	Code              string
+	Description       string
+	Category          string
+	FlightLimit       int  // Most requests of this code on one flight; 0 for no limit
+	ExitRowRestricted bool // Passengers with this request may not sit in an exit row
+}
+
+// SpecialServices is the catalogue of supported special service request codes
+var SpecialServices = map[string]SpecialService{
+	"WCHR": {Code: "WCHR", Description: "Wheelchair, can climb steps and walk to seat", Category: SSRCategoryAssistance, ExitRowRestricted: true},
+	"WCHS": {Code: "WCHS", Description: "Wheelchair, cannot climb steps", Category: SSRCategoryAssistance, ExitRowRestricted: true},
+	"WCHC": {Code: "WCHC", Description: "Wheelchair, completely immobile", Category: SSRCategoryAssistance, FlightLimit: 2, ExitRowRestricted: true},
+	"BLND": {Code: "BLND", Description: "Blind passenger", Category: SSRCategoryAssistance, ExitRowRestricted: true},
+	"DEAF": {Code: "DEAF", Description: "Deaf passenger", Category: SSRCategoryAssistance, ExitRowRestricted: true},
+	"VGML": {Code: "VGML", Description: "Vegetarian meal", Category: SSRCategoryMeal},
+	"MOML": {Code: "MOML", Description: "Muslim meal", Category: SSRCategoryMeal},
+	"KSML": {Code: "KSML", Description: "Kosher meal", Category: SSRCategoryMeal},
+	"DBML": {Code: "DBML", Description: "Diabetic meal", Category: SSRCategoryMeal},
+	"CHML": {Code: "CHML", Description: "Child meal", Category: SSRCategoryMeal},
+	"UMNR": {Code: "UMNR", Description: "Unaccompanied minor", Category: SSRCategoryMinor, FlightLimit: 4, ExitRowRestricted: true},
+	"PETC": {Code: "PETC", Description: "Pet in cabin", Category: SSRCategoryAnimal, FlightLimit: 2, ExitRowRestricted: true},
+	"AVIH": {Code: "AVIH", Description: "Animal in hold", Category: SSRCategoryAnimal, FlightLimit: 2},
+}
+
+// SpecialServiceCodes returns the supported codes in alphabetical order
+func SpecialServiceCodes() []string {
+	codes := make([]string, 0, len(SpecialServices))
+	for code := range SpecialServices {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// SpecialServiceRequest is a special service requested for a reservation
+type SpecialServiceRequest struct {
+	Code      string    `json:"code"`
+	Text      string    `json:"text,omitempty"` // Free-text details, such as the minor's age or the pet's breed
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HasSpecialService reports whether the reservation has a request of a code
+func (r *Reservation) HasSpecialService(code string) bool {
+	for _, request := range r.SpecialServices {
+		if request.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// SpecialServiceCodes returns the codes of the special service requests of the reservation
+func (r *Reservation) SpecialServiceCodes() []string {
+	codes := make([]string, 0, len(r.SpecialServices))
+	for _, request := range r.SpecialServices {
+		codes = append(codes, request.Code)
+	}
+	return codes
+}
+
+// ExitRowRestricted reports whether any request of the reservation keeps it out of exit rows
+func (r *Reservation) ExitRowRestricted() bool {
+	for _, request := range r.SpecialServices {
+		if SpecialServices[request.Code].ExitRowRestricted {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExitRow reports whether a seat of the flight is in an exit row
+func (f *Flight) IsExitRow(seat string) bool {
+	row := (&Reservation{SeatLocation: seat}).SeatRow()
+	for _, exitRow := range f.ExitRows {
+		if exitRow == row {
+			return true
+		}
+	}
+	return false
+}
+
+// SpecialServiceLimit returns how many requests of a code the flight accepts; 0 means no limit
+func (f *Flight) SpecialServiceLimit(code string) int {
+	if limit, ok := f.SSRLimits[code]; ok {
+		return limit
+	}
+	return SpecialServices[code].FlightLimit
+}
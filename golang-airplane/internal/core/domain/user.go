@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// User roles; an administrator may do everything the other roles may
+const (
+	RoleAdmin        = "admin"
+	RoleScheduler    = "scheduler"     // Plans flights, airplanes, gates and fares
+	RoleSalesAgent   = "sales_agent"   // Books and cancels reservations
+	RoleCheckInAgent = "checkin_agent" // Checks passengers in
+	RoleCrewPlanner  = "crew_planner"  // Assigns and edits flight crews
+)
+
+// Roles lists every user role
+var Roles = []string{RoleAdmin, RoleScheduler, RoleSalesAgent, RoleCheckInAgent, RoleCrewPlanner}
+
+// IsRole reports whether a name is a known user role
+func IsRole(name string) bool {
+	for _, role := range Roles {
+		if role == name {
+			return true
+		}
+	}
+	return false
+}
+
+// User is a member of staff allowed to use the system
+type User struct {
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"password_hash"` // bcrypt hash of the password
+	Roles        []string  `json:"roles"`
+	Disabled     bool      `json:"disabled,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// HasRole reports whether the user has a role, which administrators always do
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role || r == RoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Activity records a change made by a user
+type Activity struct {
+	Actor  string    `json:"actor"`
+	Action string    `json:"action"` // Operation performed, such as "add flight"
+	Target string    `json:"target"` // Flight number, reservation ID, airplane ID or username changed
+	At     time.Time `json:"at"`
+}
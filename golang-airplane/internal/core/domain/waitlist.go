@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// Waitlist entry statuses
+const (
+	WaitlistWaiting   = "waiting"
+	WaitlistOffered   = "offered"   // A seat is held for the passenger until the offer expires
+	WaitlistConfirmed = "confirmed" // The passenger accepted the offered seat
+	WaitlistExpired   = "expired"   // The offer was not confirmed in time
+	WaitlistCancelled = "cancelled"
+)
+
+// WaitlistEntry represents a passenger waiting for a seat on a sold-out flight
+type WaitlistEntry struct {
+	ID                 string    `json:"id"`
+	FlightNumber       string    `json:"flight_number"`
+	Class              string    `json:"class"`
+	Name               string    `json:"name"`
+	Address            string    `json:"address"`
+	PhoneNumber        int64     `json:"phone_number"`
+	IdentityCardNumber int64     `json:"identity_card_number"`
+	Status             string    `json:"status"`
+	RequestedAt        time.Time `json:"requested_at"`
+	ReservationID      string    `json:"reservation_id,omitempty"` // Reservation held for the passenger once promoted
+	OfferExpiresAt     time.Time `json:"offer_expires_at,omitempty"`
+}
+
+// IsActive reports whether the entry is still waiting for or holding an offer
+func (w *WaitlistEntry) IsActive() bool {
+	return w.Status == WaitlistWaiting || w.Status == WaitlistOffered
+}
+
+// Notification types
+const (
+	NotificationWaitlistOffer   = "waitlist_offer"
+	NotificationWaitlistExpired = "waitlist_expired"
+)
+
+// Notification is a message sent to a passenger
+type Notification struct {
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	PhoneNumber int64     `json:"phone_number"`
+	Message     string    `json:"message"`
+	CreatedAt   time.Time `json:"created_at"`
+}
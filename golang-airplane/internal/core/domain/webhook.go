@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription registers a partner URL to be sent the domain events of some types
+type WebhookSubscription struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret"` // Key of the HMAC signature of the payloads
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Wants reports whether the subscription is for an event type
+func (s *WebhookSubscription) Wants(eventType string) bool {
+	for _, wanted := range s.EventTypes {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook delivery statuses
+const (
+	WebhookPending = "pending" // Waiting for its next attempt
+	WebhookDead    = "dead"    // Given up on after the last attempt, in the dead-letter queue
+)
+
+// WebhookDelivery is an event still to be sent to a subscription, or given up on
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Body           json.RawMessage `json:"body"` // Payload sent, the same on every attempt
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// WebhookAttempt is one attempt to send an event to a subscription, as recorded in the delivery log
+type WebhookAttempt struct {
+	DeliveryID     string        `json:"delivery_id"`
+	SubscriptionID string        `json:"subscription_id"`
+	EventID        string        `json:"event_id"`
+	EventType      string        `json:"event_type"`
+	URL            string        `json:"url"`
+	Attempt        int           `json:"attempt"` // From 1
+	At             time.Time     `json:"at"`
+	StatusCode     int           `json:"status_code,omitempty"` // Absent when no response came back
+	Error          string        `json:"error,omitempty"`       // Empty when the partner accepted the event
+	Duration       time.Duration `json:"duration"`
+}
+
+// Succeeded reports whether the partner accepted the event
+func (a *WebhookAttempt) Succeeded() bool {
+	return a.Error == ""
+}
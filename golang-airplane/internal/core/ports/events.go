@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"golang-airplane/internal/core/domain"
+)
+
+// EventPublisher defines the interface the services publish domain events through
+type EventPublisher interface {
+	// Atomically makes a change and records the events it publishes in one step of the storage, so the events are
+	// stored if and only if the change is
+	Atomically(change func() error) error
+
+	// Publish records an event in the outbox and hands it to the subscribers
+	Publish(event domain.Event) error
+}
+
+// EventWatcher defines the interface streams follow the published domain events through
+type EventWatcher interface {
+	// Watch returns a channel receiving the events of the given types, or of every type, published until stop is called
+	Watch(types ...string) (events <-chan *domain.EventMessage, stop func())
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"golang-airplane/internal/core/domain"
+)
+
+// Notifier defines the interface for sending notifications to passengers
+type Notifier interface {
+	// Notify sends a notification
+	Notify(notification *domain.Notification) error
+}
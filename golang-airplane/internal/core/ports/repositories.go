@@ -2,6 +2,7 @@ package ports
 
 import (
 	"golang-airplane/internal/core/domain"
+	"time"
 )
 
 type AirplaneRepository interface {
@@ -44,4 +45,190 @@ type ReservationRepository interface {
 	
 	// Update updates an existing reservation in the repository
 	Update(reservation *domain.Reservation) error
-}
\ No newline at end of file
+}
+// CrewRepository defines the interface for crew registry data operations
+type CrewRepository interface {
+	// FindAll returns all registered crew members
+	FindAll() ([]*domain.CrewMember, error)
+
+	// FindByID finds a crew member by their ID
+	FindByID(id string) (*domain.CrewMember, error)
+
+	// Save stores a crew member in the repository
+	Save(member *domain.CrewMember) error
+}
+
+// CrewChangeRepository defines the interface for the crew change audit trail
+type CrewChangeRepository interface {
+	// Append records a crew change
+	Append(change *domain.CrewChange) error
+
+	// FindByFlightNumber finds all crew changes for a specific flight in the order they were made
+	FindByFlightNumber(flightNumber string) ([]*domain.CrewChange, error)
+}
+
+// WaitlistRepository defines the interface for waitlist data operations
+type WaitlistRepository interface {
+	// FindAll returns all waitlist entries
+	FindAll() ([]*domain.WaitlistEntry, error)
+
+	// FindByID finds a waitlist entry by its ID
+	FindByID(id string) (*domain.WaitlistEntry, error)
+
+	// FindByFlightNumber finds the waitlist entries of a flight in the order they were requested
+	FindByFlightNumber(flightNumber string) ([]*domain.WaitlistEntry, error)
+
+	// Save stores a waitlist entry in the repository
+	Save(entry *domain.WaitlistEntry) error
+}
+
+// OverbookingPolicyRepository defines the interface for overbooking policy data operations
+type OverbookingPolicyRepository interface {
+	// FindAll returns all overbooking policies
+	FindAll() ([]*domain.OverbookingPolicy, error)
+
+	// Save stores the overbooking policy of a route, replacing any previous one
+	Save(policy *domain.OverbookingPolicy) error
+}
+
+// SeatHoldRepository defines the interface for seat hold data operations
+type SeatHoldRepository interface {
+	// FindAll returns all seat holds, including expired ones not yet removed
+	FindAll() ([]*domain.SeatHold, error)
+
+	// Save stores a seat hold in the repository
+	Save(hold *domain.SeatHold) error
+
+	// Delete removes the seat holds with the given IDs
+	Delete(ids ...string) error
+}
+
+// BrandRepository defines the interface for airline brand data operations
+type BrandRepository interface {
+	// FindAll returns all brands
+	FindAll() ([]*domain.Brand, error)
+
+	// FindByID finds a brand by its ID
+	FindByID(id string) (*domain.Brand, error)
+
+	// Save stores a brand in the repository
+	Save(brand *domain.Brand) error
+}
+
+// BoardingSessionRepository defines the interface for boarding session data operations
+type BoardingSessionRepository interface {
+	// FindByFlightNumber finds the boarding session of a flight
+	FindByFlightNumber(flightNumber string) (*domain.BoardingSession, error)
+
+	// Save stores the boarding session of a flight, replacing any previous one
+	Save(session *domain.BoardingSession) error
+}
+
+// ManifestRepository defines the interface for frozen flight manifest data operations
+type ManifestRepository interface {
+	// FindByFlightNumber finds the frozen manifest of a flight
+	FindByFlightNumber(flightNumber string) (*domain.Manifest, error)
+
+	// Save stores the manifest of a flight, replacing any previous one
+	Save(manifest *domain.Manifest) error
+}
+
+// CheckInPolicyRepository defines the interface for check-in policy data operations
+type CheckInPolicyRepository interface {
+	// FindAll returns all check-in policies
+	FindAll() ([]*domain.CheckInPolicy, error)
+
+	// Save stores the check-in policy of a route, replacing any previous one
+	Save(policy *domain.CheckInPolicy) error
+}
+
+// UserRepository defines the interface for user account data operations
+type UserRepository interface {
+	// FindAll returns all users
+	FindAll() ([]*domain.User, error)
+
+	// FindByUsername finds a user by their username
+	FindByUsername(username string) (*domain.User, error)
+
+	// Save stores a user in the repository, replacing any previous version
+	Save(user *domain.User) error
+}
+
+// ActivityRepository defines the interface for the log of changes made by users
+type ActivityRepository interface {
+	// Append records an activity
+	Append(activity *domain.Activity) error
+
+	// FindAll returns all activities in the order they were recorded
+	FindAll() ([]*domain.Activity, error)
+}
+
+// Transactor defines the interface for making several changes to the storage in one step
+type Transactor interface {
+	// Atomically makes the changes of change in one step: all are stored if it succeeds, none if it fails, and a
+	// step cut short by a crash is finished or dropped whole
+	Atomically(change func() error) error
+}
+
+// AuditRepository defines the interface for the append-only audit log
+type AuditRepository interface {
+	// Append adds an entry to the end of the log, failing with ErrConflict unless it follows the last entry
+	Append(entry *domain.AuditEntry) error
+
+	// FindAll returns all entries in the order they were appended
+	FindAll() ([]*domain.AuditEntry, error)
+}
+
+// OutboxRepository defines the interface for the outbox of published domain events
+type OutboxRepository interface {
+	// Append adds a message to the end of the outbox
+	Append(message *domain.EventMessage) error
+
+	// FindAll returns all messages in the order they were published
+	FindAll() ([]*domain.EventMessage, error)
+
+	// MarkDelivered records that a subscriber has handled a message
+	MarkDelivered(id, subscriber string, at time.Time) error
+
+	// Prune removes the messages published before a time that no subscriber is still owed, and returns how many
+	Prune(before time.Time) (int, error)
+}
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription data operations
+type WebhookSubscriptionRepository interface {
+	// FindAll returns all subscriptions
+	FindAll() ([]*domain.WebhookSubscription, error)
+
+	// FindByID finds a subscription by its ID
+	FindByID(id string) (*domain.WebhookSubscription, error)
+
+	// Save stores a new or updated subscription
+	Save(subscription *domain.WebhookSubscription) error
+
+	// Delete removes a subscription
+	Delete(id string) error
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook deliveries still to be sent, and those given up on
+type WebhookDeliveryRepository interface {
+	// FindAll returns all deliveries in the order they were queued
+	FindAll() ([]*domain.WebhookDelivery, error)
+
+	// FindByID finds a delivery by its ID
+	FindByID(id string) (*domain.WebhookDelivery, error)
+
+	// Save stores a new or updated delivery
+	Save(delivery *domain.WebhookDelivery) error
+
+	// Delete removes a delivery once it has been sent
+	Delete(id string) error
+}
+
+// WebhookAttemptRepository defines the interface for the log of webhook delivery attempts
+type WebhookAttemptRepository interface {
+	// Append records an attempt
+	Append(attempt *domain.WebhookAttempt) error
+
+	// FindAll returns all attempts in the order they were made
+	FindAll() ([]*domain.WebhookAttempt, error)
+}
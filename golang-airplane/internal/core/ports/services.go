@@ -15,6 +15,12 @@ type AirplaneService interface {
 	
 	// GetAirplaneByID retrieves an airplane by its ID
 	GetAirplaneByID(id string) (domain.Airplane, error)
+	
+	// ScheduleMaintenance books a maintenance event for an airplane and returns the flights it affects
+	ScheduleMaintenance(airplaneID, eventType string, start, end time.Time, location string) (*domain.MaintenanceEvent, []*domain.Flight, error)
+	
+	// MaintenanceReport lists the checks of every airplane with the hours and cycles flown since they were last done
+	MaintenanceReport() ([]domain.CheckStatus, error)
 }
 
 type FlightService interface {
@@ -30,22 +36,62 @@ type FlightService interface {
 	// AssignCrew assigns crew members to a flight
 	AssignCrew(flightNumber string, crewMembers []domain.Crew) error
 	
+	// AddCrewMember adds a crew member to a flight that already has a crew
+	AddCrewMember(flightNumber string, member domain.Crew, changedBy string) error
+	
+	// RemoveCrewMember removes a crew member, identified by registry ID or name, from a flight
+	RemoveCrewMember(flightNumber, idOrName, changedBy string) error
+	
+	// SwapCrewMember replaces a crew member of a flight with another one
+	SwapCrewMember(flightNumber, idOrName string, replacement domain.Crew, changedBy string) error
+	
+	// GetCrewChanges retrieves the crew change audit trail of a flight
+	GetCrewChanges(flightNumber string) ([]*domain.CrewChange, error)
+	
+	// AssignAirplane assigns an airplane to a flight
+	AssignAirplane(flightNumber, airplaneID string) error
+	
+	// SetFare sets the base fare of a class on a flight, in minor currency units
+	SetFare(flightNumber, class string, amount int64) error
+	
+	// SetGate sets the departure gate of a flight
+	SetGate(flightNumber, gate string) error
+	
+	// UpdateStatus changes the operational status of a flight
+	UpdateStatus(flightNumber, status string) error
+	
+	// ChangeCapacity changes the number of seats of a flight
+	ChangeCapacity(flightNumber string, capacity int) error
+	
 	// ListAllFlights retrieves all flights sorted by departure time (descending)
 	ListAllFlights() ([]*domain.Flight, error)
 }
 
 type ReservationService interface {
 	// BookFlight creates a new reservation for a flight
-	BookFlight(name, address string, phoneNumber, identityCardNumber int64, flightNumber string) (*domain.Reservation, error)
+	BookFlight(name, address string, phoneNumber, identityCardNumber int64, flightNumber, class, sessionToken string) (*domain.Reservation, error)
+	
+	// HoldInventory reserves seats of a flight for a booking session until they are booked or the hold expires
+	HoldInventory(sessionToken, flightNumber string, quantity int) (*domain.SeatHold, error)
+	
+	// BookableSeats returns how many more reservations a flight accepts, including the authorized overbooking
+	BookableSeats(flightNumber string) (int, error)
 	
 	// GetReservation retrieves a reservation by its ID
 	GetReservation(reservationID string) (*domain.Reservation, error)
 	
-	// CheckIn performs the check-in process for a reservation and assigns a seat
-	CheckIn(reservationID string, seatNumber string) error
+	// CheckIn performs the check-in process for a reservation and assigns a seat;
+	// an empty seat number assigns the seat that suits the passenger best
+	CheckIn(reservationID, seatNumber, sessionToken string) error
 	
 	// GetReservationsForFlight retrieves all reservations for a specific flight
 	GetReservationsForFlight(flightNumber string) ([]*domain.Reservation, error)
+	
+	// AddTravelDocument records a travel document presented by the passenger of a reservation
+	AddTravelDocument(reservationID string, document domain.TravelDocument) error
+	
+	// CancelReservation cancels a reservation and releases its seat
+	CancelReservation(reservationID string) error
 }
 
 type ValidationService interface {
@@ -57,4 +103,27 @@ type ValidationService interface {
 	
 	// CheckYesOrNo prompts the user with a yes/no question and returns the result
 	CheckYesOrNo(prompt string) bool
-}
\ No newline at end of file
+}
+type AccessControl interface {
+	// Authenticate returns the enabled user with a username and password, or ErrUnauthorized
+	Authenticate(username, password string) (*domain.User, error)
+	
+	// GuardFlights returns the flight service as a user may use it, recording the changes they make
+	GuardFlights(inner FlightService, user *domain.User) FlightService
+	
+	// GuardReservations returns the reservation service as a user may use it, recording the changes they make
+	GuardReservations(inner ReservationService, user *domain.User) ReservationService
+	
+	// GuardAirplanes returns the airplane service as a user may use it, recording the changes they make
+	GuardAirplanes(inner AirplaneService, user *domain.User) AirplaneService
+}
+
+// Services are the services an API acts through on behalf of one actor
+type Services struct {
+	Flights      FlightService
+	Reservations ReservationService
+	Airplanes    AirplaneService
+}
+
+// ServiceFactory returns the services whose changes are recorded as made by an actor
+type ServiceFactory func(actor string) Services
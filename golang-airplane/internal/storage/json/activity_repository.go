@@ -0,0 +1,41 @@
+package json
+
+import (
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// ActivityRepositoryJSON implements the ActivityRepository interface using JSON files
+type ActivityRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewActivityRepository creates a new ActivityRepositoryJSON instance
+func NewActivityRepository(storage *Storage) ports.ActivityRepository {
+	return &ActivityRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// Append records an activity
+func (r *ActivityRepositoryJSON) Append(activity *domain.Activity) error {
+	activities, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	activities = append(activities, activity)
+
+	return r.storage.Save("activity.json", activities)
+}
+
+// FindAll returns all activities in the order they were recorded
+func (r *ActivityRepositoryJSON) FindAll() ([]*domain.Activity, error) {
+	var activities []*domain.Activity
+	err := r.storage.Load("activity.json", &activities)
+	if err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,50 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// AuditRepositoryJSON implements the AuditRepository interface using JSON files
+type AuditRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewAuditRepository creates a new AuditRepositoryJSON instance
+func NewAuditRepository(storage *Storage) ports.AuditRepository {
+	return &AuditRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// Append adds an entry to the end of the log, failing with ErrConflict unless it follows the last entry
+func (r *AuditRepositoryJSON) Append(entry *domain.AuditEntry) error {
+	entries, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	last := ""
+	if len(entries) > 0 {
+		last = entries[len(entries)-1].Hash
+	}
+	if entry.Sequence != len(entries)+1 || entry.PrevHash != last {
+		return fmt.Errorf("audit entry %d does not follow the last of %d: %w", entry.Sequence, len(entries), domain.ErrConflict)
+	}
+
+	entries = append(entries, entry)
+
+	return r.storage.Save("audit_log.json", entries)
+}
+
+// FindAll returns all entries in the order they were appended
+func (r *AuditRepositoryJSON) FindAll() ([]*domain.AuditEntry, error) {
+	var entries []*domain.AuditEntry
+	err := r.storage.Load("audit_log.json", &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
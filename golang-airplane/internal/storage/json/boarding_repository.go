@@ -0,0 +1,69 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// BoardingSessionRepositoryJSON implements the BoardingSessionRepository interface using JSON files
+type BoardingSessionRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewBoardingSessionRepository creates a new BoardingSessionRepositoryJSON instance
+func NewBoardingSessionRepository(storage *Storage) ports.BoardingSessionRepository {
+	return &BoardingSessionRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// findAll returns all boarding sessions
+func (r *BoardingSessionRepositoryJSON) findAll() ([]*domain.BoardingSession, error) {
+	var sessions []*domain.BoardingSession
+	err := r.storage.Load("boarding.json", &sessions)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// FindByFlightNumber finds the boarding session of a flight
+func (r *BoardingSessionRepositoryJSON) FindByFlightNumber(flightNumber string) (*domain.BoardingSession, error) {
+	sessions, err := r.findAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		if session.FlightNumber == flightNumber {
+			return session, nil
+		}
+	}
+
+	return nil, fmt.Errorf("boarding of flight %s has not been opened: %w", flightNumber, domain.ErrNotFound)
+}
+
+// Save stores the boarding session of a flight, replacing any previous one
+func (r *BoardingSessionRepositoryJSON) Save(session *domain.BoardingSession) error {
+	sessions, err := r.findAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existingSession := range sessions {
+		if existingSession.FlightNumber == session.FlightNumber {
+			sessions[i] = session
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		sessions = append(sessions, session)
+	}
+
+	return r.storage.Save("boarding.json", sessions)
+}
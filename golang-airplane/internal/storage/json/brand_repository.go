@@ -0,0 +1,70 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// BrandRepositoryJSON implements the BrandRepository interface using JSON files
+type BrandRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewBrandRepository creates a new BrandRepositoryJSON instance
+func NewBrandRepository(storage *Storage) ports.BrandRepository {
+	return &BrandRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all brands
+func (r *BrandRepositoryJSON) FindAll() ([]*domain.Brand, error) {
+	var brands []*domain.Brand
+	err := r.storage.Load("brands.json", &brands)
+	if err != nil {
+		return nil, err
+	}
+
+	return brands, nil
+}
+
+// FindByID finds a brand by its ID
+func (r *BrandRepositoryJSON) FindByID(id string) (*domain.Brand, error) {
+	brands, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, brand := range brands {
+		if brand.ID == id {
+			return brand, nil
+		}
+	}
+
+	return nil, fmt.Errorf("brand with ID %s %w", id, domain.ErrNotFound)
+}
+
+// Save stores a brand in the repository
+func (r *BrandRepositoryJSON) Save(brand *domain.Brand) error {
+	brands, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	// Replace the brand if already stored
+	found := false
+	for i, existingBrand := range brands {
+		if existingBrand.ID == brand.ID {
+			brands[i] = brand
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		brands = append(brands, brand)
+	}
+
+	return r.storage.Save("brands.json", brands)
+}
@@ -0,0 +1,54 @@
+package json
+
+import (
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"strings"
+)
+
+// CheckInPolicyRepositoryJSON implements the CheckInPolicyRepository interface using JSON files
+type CheckInPolicyRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewCheckInPolicyRepository creates a new CheckInPolicyRepositoryJSON instance
+func NewCheckInPolicyRepository(storage *Storage) ports.CheckInPolicyRepository {
+	return &CheckInPolicyRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all check-in policies
+func (r *CheckInPolicyRepositoryJSON) FindAll() ([]*domain.CheckInPolicy, error) {
+	var policies []*domain.CheckInPolicy
+	err := r.storage.Load("checkin_policies.json", &policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// Save stores the check-in policy of a route, replacing any previous one
+func (r *CheckInPolicyRepositoryJSON) Save(policy *domain.CheckInPolicy) error {
+	policies, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existingPolicy := range policies {
+		if strings.EqualFold(existingPolicy.DepartureCity, policy.DepartureCity) &&
+			strings.EqualFold(existingPolicy.DestinationCity, policy.DestinationCity) {
+			policies[i] = policy
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		policies = append(policies, policy)
+	}
+
+	return r.storage.Save("checkin_policies.json", policies)
+}
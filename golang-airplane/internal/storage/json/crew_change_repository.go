@@ -0,0 +1,49 @@
+package json
+
+import (
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// CrewChangeRepositoryJSON implements the CrewChangeRepository interface using JSON files
+type CrewChangeRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewCrewChangeRepository creates a new CrewChangeRepositoryJSON instance
+func NewCrewChangeRepository(storage *Storage) ports.CrewChangeRepository {
+	return &CrewChangeRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// Append records a crew change
+func (r *CrewChangeRepositoryJSON) Append(change *domain.CrewChange) error {
+	var changes []*domain.CrewChange
+	err := r.storage.Load("crew_changes.json", &changes)
+	if err != nil {
+		return err
+	}
+
+	changes = append(changes, change)
+
+	return r.storage.Save("crew_changes.json", changes)
+}
+
+// FindByFlightNumber finds all crew changes for a specific flight in the order they were made
+func (r *CrewChangeRepositoryJSON) FindByFlightNumber(flightNumber string) ([]*domain.CrewChange, error) {
+	var changes []*domain.CrewChange
+	err := r.storage.Load("crew_changes.json", &changes)
+	if err != nil {
+		return nil, err
+	}
+
+	var flightChanges []*domain.CrewChange
+	for _, change := range changes {
+		if change.FlightNumber == flightNumber {
+			flightChanges = append(flightChanges, change)
+		}
+	}
+
+	return flightChanges, nil
+}
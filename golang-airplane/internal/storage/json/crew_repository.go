@@ -0,0 +1,70 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// CrewRepositoryJSON implements the CrewRepository interface using JSON files
+type CrewRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewCrewRepository creates a new CrewRepositoryJSON instance
+func NewCrewRepository(storage *Storage) ports.CrewRepository {
+	return &CrewRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all registered crew members
+func (r *CrewRepositoryJSON) FindAll() ([]*domain.CrewMember, error) {
+	var members []*domain.CrewMember
+	err := r.storage.Load("crew.json", &members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// FindByID finds a crew member by their ID
+func (r *CrewRepositoryJSON) FindByID(id string) (*domain.CrewMember, error) {
+	members, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.ID == id {
+			return member, nil
+		}
+	}
+
+	return nil, fmt.Errorf("crew member with ID %s %w", id, domain.ErrNotFound)
+}
+
+// Save stores a crew member in the repository
+func (r *CrewRepositoryJSON) Save(member *domain.CrewMember) error {
+	members, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	// Replace the crew member if already registered
+	found := false
+	for i, existingMember := range members {
+		if existingMember.ID == member.ID {
+			members[i] = member
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		members = append(members, member)
+	}
+
+	return r.storage.Save("crew.json", members)
+}
@@ -110,7 +110,7 @@ func (r *FlightRepositoryJSON) FindByID(flightNumber string) (*domain.Flight, er
 		}
 	}
 	
-	return nil, fmt.Errorf("flight with number %s not found", flightNumber)
+	return nil, fmt.Errorf("flight with number %s %w", flightNumber, domain.ErrNotFound)
 }
 
 // SearchFlights searches for flights by location (departure or destination) and date
@@ -190,7 +190,7 @@ func (r *FlightRepositoryJSON) Update(flight *domain.Flight) error {
 	}
 	
 	if !found {
-		return fmt.Errorf("flight with number %s not found", flight.FlightNumber)
+		return fmt.Errorf("flight with number %s %w", flight.FlightNumber, domain.ErrNotFound)
 	}
 	
 	// Save updated flights list
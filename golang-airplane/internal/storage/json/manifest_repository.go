@@ -0,0 +1,69 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// ManifestRepositoryJSON implements the ManifestRepository interface using JSON files
+type ManifestRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewManifestRepository creates a new ManifestRepositoryJSON instance
+func NewManifestRepository(storage *Storage) ports.ManifestRepository {
+	return &ManifestRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// findAll returns all manifests
+func (r *ManifestRepositoryJSON) findAll() ([]*domain.Manifest, error) {
+	var manifests []*domain.Manifest
+	err := r.storage.Load("manifests.json", &manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifests, nil
+}
+
+// FindByFlightNumber finds the frozen manifest of a flight
+func (r *ManifestRepositoryJSON) FindByFlightNumber(flightNumber string) (*domain.Manifest, error) {
+	manifests, err := r.findAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, manifest := range manifests {
+		if manifest.FlightNumber == flightNumber {
+			return manifest, nil
+		}
+	}
+
+	return nil, fmt.Errorf("flight %s has no frozen manifest: %w", flightNumber, domain.ErrNotFound)
+}
+
+// Save stores the manifest of a flight, replacing any previous one
+func (r *ManifestRepositoryJSON) Save(manifest *domain.Manifest) error {
+	manifests, err := r.findAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existingSession := range manifests {
+		if existingSession.FlightNumber == manifest.FlightNumber {
+			manifests[i] = manifest
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		manifests = append(manifests, manifest)
+	}
+
+	return r.storage.Save("manifests.json", manifests)
+}
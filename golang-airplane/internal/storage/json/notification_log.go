@@ -0,0 +1,41 @@
+package json
+
+import (
+	"golang-airplane/internal/core/domain"
+)
+
+// NotificationLog implements the Notifier interface by recording notifications in a JSON file
+// from which they are picked up for delivery
+type NotificationLog struct {
+	storage *Storage
+}
+
+// NewNotificationLog creates a new NotificationLog instance
+func NewNotificationLog(storage *Storage) *NotificationLog {
+	return &NotificationLog{
+		storage: storage,
+	}
+}
+
+// Notify records a notification
+func (l *NotificationLog) Notify(notification *domain.Notification) error {
+	notifications, err := l.FindAll()
+	if err != nil {
+		return err
+	}
+
+	notifications = append(notifications, notification)
+
+	return l.storage.Save("notifications.json", notifications)
+}
+
+// FindAll returns all recorded notifications
+func (l *NotificationLog) FindAll() ([]*domain.Notification, error) {
+	var notifications []*domain.Notification
+	err := l.storage.Load("notifications.json", &notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,88 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"time"
+)
+
+// OutboxRepositoryJSON implements the OutboxRepository interface using JSON files
+type OutboxRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewOutboxRepository creates a new OutboxRepositoryJSON instance
+func NewOutboxRepository(storage *Storage) ports.OutboxRepository {
+	return &OutboxRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// Append adds a message to the end of the outbox
+func (r *OutboxRepositoryJSON) Append(message *domain.EventMessage) error {
+	messages, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	messages = append(messages, message)
+
+	return r.storage.Save("outbox.json", messages)
+}
+
+// FindAll returns all messages in the order they were published
+func (r *OutboxRepositoryJSON) FindAll() ([]*domain.EventMessage, error) {
+	var messages []*domain.EventMessage
+	err := r.storage.Load("outbox.json", &messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// MarkDelivered records that a subscriber has handled a message
+func (r *OutboxRepositoryJSON) MarkDelivered(id, subscriber string, at time.Time) error {
+	messages, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if message.ID == id {
+			if message.Delivered == nil {
+				message.Delivered = make(map[string]time.Time)
+			}
+			message.Delivered[subscriber] = at
+			return r.storage.Save("outbox.json", messages)
+		}
+	}
+
+	return fmt.Errorf("event %s %w", id, domain.ErrNotFound)
+}
+
+// Prune removes the messages published before a time that no subscriber is still owed, and returns how many
+func (r *OutboxRepositoryJSON) Prune(before time.Time) (int, error) {
+	messages, err := r.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	kept := []*domain.EventMessage{}
+	for _, message := range messages {
+		if message.OccurredAt.Before(before) && !message.HasPending() {
+			continue
+		}
+		kept = append(kept, message)
+	}
+
+	pruned := len(messages) - len(kept)
+	if pruned == 0 {
+		return 0, nil
+	}
+	if err := r.storage.Save("outbox.json", kept); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
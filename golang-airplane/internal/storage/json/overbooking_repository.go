@@ -0,0 +1,54 @@
+package json
+
+import (
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"strings"
+)
+
+// OverbookingPolicyRepositoryJSON implements the OverbookingPolicyRepository interface using JSON files
+type OverbookingPolicyRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewOverbookingPolicyRepository creates a new OverbookingPolicyRepositoryJSON instance
+func NewOverbookingPolicyRepository(storage *Storage) ports.OverbookingPolicyRepository {
+	return &OverbookingPolicyRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all overbooking policies
+func (r *OverbookingPolicyRepositoryJSON) FindAll() ([]*domain.OverbookingPolicy, error) {
+	var policies []*domain.OverbookingPolicy
+	err := r.storage.Load("overbooking_policies.json", &policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// Save stores the overbooking policy of a route, replacing any previous one
+func (r *OverbookingPolicyRepositoryJSON) Save(policy *domain.OverbookingPolicy) error {
+	policies, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existingPolicy := range policies {
+		if strings.EqualFold(existingPolicy.DepartureCity, policy.DepartureCity) &&
+			strings.EqualFold(existingPolicy.DestinationCity, policy.DestinationCity) {
+			policies[i] = policy
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		policies = append(policies, policy)
+	}
+
+	return r.storage.Save("overbooking_policies.json", policies)
+}
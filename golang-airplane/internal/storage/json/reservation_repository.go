@@ -107,7 +107,7 @@ func (r *ReservationRepositoryJSON) FindByID(reservationID string) (*domain.Rese
 		}
 	}
 	
-	return nil, fmt.Errorf("reservation with ID %s not found", reservationID)
+	return nil, fmt.Errorf("reservation with ID %s %w", reservationID, domain.ErrNotFound)
 }
 
 // FindByFlightNumber finds all reservations for a specific flight
@@ -174,7 +174,7 @@ func (r *ReservationRepositoryJSON) Update(reservation *domain.Reservation) erro
 	}
 	
 	if !found {
-		return fmt.Errorf("reservation with ID %s not found", reservation.ReservationID)
+		return fmt.Errorf("reservation with ID %s %w", reservation.ReservationID, domain.ErrNotFound)
 	}
 	
 	// Save updated reservations list
@@ -0,0 +1,74 @@
+package json
+
+import (
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// SeatHoldRepositoryJSON implements the SeatHoldRepository interface using JSON files
+type SeatHoldRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewSeatHoldRepository creates a new SeatHoldRepositoryJSON instance
+func NewSeatHoldRepository(storage *Storage) ports.SeatHoldRepository {
+	return &SeatHoldRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all seat holds, including expired ones not yet removed
+func (r *SeatHoldRepositoryJSON) FindAll() ([]*domain.SeatHold, error) {
+	var holds []*domain.SeatHold
+	err := r.storage.Load("holds.json", &holds)
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// Save stores a seat hold in the repository
+func (r *SeatHoldRepositoryJSON) Save(hold *domain.SeatHold) error {
+	holds, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existingHold := range holds {
+		if existingHold.ID == hold.ID {
+			holds[i] = hold
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		holds = append(holds, hold)
+	}
+
+	return r.storage.Save("holds.json", holds)
+}
+
+// Delete removes the seat holds with the given IDs
+func (r *SeatHoldRepositoryJSON) Delete(ids ...string) error {
+	holds, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	remove := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+
+	kept := make([]*domain.SeatHold, 0, len(holds))
+	for _, hold := range holds {
+		if !remove[hold.ID] {
+			kept = append(kept, hold)
+		}
+	}
+
+	return r.storage.Save("holds.json", kept)
+}
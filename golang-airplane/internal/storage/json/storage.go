@@ -8,10 +8,15 @@ import (
 	"sync"
 )
 
+// journalFile holds the files of a step while they are written, so a step cut short is finished at the next start
+const journalFile = "step_journal.json"
+
 // Storage provides a JSON-based storage implementation
 type Storage struct {
 	dataPath string
 	mutex    sync.RWMutex
+	step     sync.Mutex        // Held for the whole of a step, so steps run one at a time
+	pending  map[string][]byte // Files saved during the open step, nil outside steps
 }
 
 // NewStorage creates a new Storage instance
@@ -23,10 +28,14 @@ func NewStorage(dataPath string) *Storage {
 			panic(fmt.Sprintf("Failed to create data directory: %v", err))
 		}
 	}
-	return &Storage{
+	s := &Storage{
 		dataPath: dataPath,
 		mutex:    sync.RWMutex{},
 	}
+	if err := s.finishStep(); err != nil {
+		panic(fmt.Sprintf("Failed to finish the last step: %v", err))
+	}
+	return s
 }
 
 // Save stores data to a JSON file
@@ -42,6 +51,12 @@ func (s *Storage) Save(filename string, data interface{}) error {
 		return fmt.Errorf("failed to marshal data: %w", err)
 	}
 	
+	// Files saved during a step are written when it ends
+	if s.pending != nil {
+		s.pending[filename] = jsonData
+		return nil
+	}
+	
 	// Write the data to the file
 	err = os.WriteFile(filePath, jsonData, 0644)
 	if err != nil {
@@ -56,6 +71,14 @@ func (s *Storage) Load(filename string, target interface{}) error {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	// A step reads the files it has saved
+	if data, ok := s.pending[filename]; ok {
+		if err := json.Unmarshal(data, target); err != nil {
+			return fmt.Errorf("failed to unmarshal data: %w", err)
+		}
+		return nil
+	}
+	
 	filePath := filepath.Join(s.dataPath, filename)
 	
 	// Check if the file exists
@@ -76,4 +99,105 @@ func (s *Storage) Load(filename string, target interface{}) error {
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// Atomically makes the saves of change in one step: they are kept in memory until change returns, and are then all
+// written, or dropped if it fails. The files are written to a journal first, so a crash while they are written is
+// finished by the next NewStorage. Steps run one at a time and must not be nested; saves made by other goroutines
+// while a step is open join it.
+func (s *Storage) Atomically(change func() error) error {
+	s.step.Lock()
+	defer s.step.Unlock()
+
+	s.mutex.Lock()
+	s.pending = make(map[string][]byte)
+	s.mutex.Unlock()
+	defer s.drop()
+
+	if err := change(); err != nil {
+		return err
+	}
+	return s.commit()
+}
+
+// drop ends a step without writing what it saved, if it was not committed
+func (s *Storage) drop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pending = nil
+}
+
+// commit ends a step by writing the files it saved: all of them to the journal, then each to its file
+func (s *Storage) commit() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files := make(map[string]json.RawMessage, len(s.pending))
+	for filename, data := range s.pending {
+		files[filename] = data
+	}
+	s.pending = nil
+	if len(files) == 0 {
+		return nil
+	}
+
+	journal, err := json.Marshal(files)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the step journal: %w", err)
+	}
+	if err := s.write(journalFile, journal); err != nil {
+		return fmt.Errorf("failed to write the step journal: %w", err)
+	}
+	return s.replay(files)
+}
+
+// finishStep finishes writing the files of a step the journal holds, left by a process stopped while committing it
+func (s *Storage) finishStep() error {
+	data, err := os.ReadFile(filepath.Join(s.dataPath, journalFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the step journal: %w", err)
+	}
+
+	// The journal is renamed into place whole, so it is complete if it exists
+	var files map[string]json.RawMessage
+	if err := json.Unmarshal(data, &files); err != nil {
+		return fmt.Errorf("failed to unmarshal the step journal: %w", err)
+	}
+	return s.replay(files)
+}
+
+// replay writes the files of a step, then removes the journal holding them
+func (s *Storage) replay(files map[string]json.RawMessage) error {
+	for filename, data := range files {
+		if err := s.write(filename, data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+	}
+	return os.Remove(filepath.Join(s.dataPath, journalFile))
+}
+
+// write replaces a file by way of a synced temporary file, so it holds either its old or its new content
+func (s *Storage) write(filename string, data []byte) error {
+	filePath := filepath.Join(s.dataPath, filename)
+	temp, err := os.CreateTemp(s.dataPath, filename+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), filePath)
+}
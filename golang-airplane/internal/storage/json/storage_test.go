@@ -0,0 +1,71 @@
+package json_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang-airplane/internal/storage/json"
+)
+
+// load reads a file of a storage into a map
+func load(t *testing.T, storage *json.Storage, filename string) map[string]int {
+	t.Helper()
+	var data map[string]int
+	if err := storage.Load(filename, &data); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return data
+}
+
+func TestAtomically(t *testing.T) {
+	storage := json.NewStorage(t.TempDir())
+
+	err := storage.Atomically(func() error {
+		if err := storage.Save("a.json", map[string]int{"a": 1}); err != nil {
+			return err
+		}
+		if got := load(t, storage, "a.json"); got["a"] != 1 {
+			t.Errorf("step read %v, want what it saved", got)
+		}
+		return storage.Save("b.json", map[string]int{"b": 1})
+	})
+	if err != nil {
+		t.Fatalf("Atomically: %v", err)
+	}
+
+	err = storage.Atomically(func() error {
+		if err := storage.Save("a.json", map[string]int{"a": 2}); err != nil {
+			return err
+		}
+		return errors.New("change failed")
+	})
+	if err == nil {
+		t.Fatal("Atomically succeeded with a failing change")
+	}
+
+	if a, b := load(t, storage, "a.json"), load(t, storage, "b.json"); a["a"] != 1 || b["b"] != 1 {
+		t.Errorf("storage holds %v and %v, want the first step only", a, b)
+	}
+}
+
+func TestStepFinishedAtStart(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"a": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A process stopped while writing the files of a step leaves its journal behind
+	journal := []byte(`{"a.json": {"a": 2}, "b.json": {"b": 2}}`)
+	if err := os.WriteFile(filepath.Join(dir, "step_journal.json"), journal, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	storage := json.NewStorage(dir)
+	if a, b := load(t, storage, "a.json"), load(t, storage, "b.json"); a["a"] != 2 || b["b"] != 2 {
+		t.Errorf("storage holds %v and %v, want the whole step", a, b)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "step_journal.json")); !os.IsNotExist(err) {
+		t.Errorf("journal still there once the step was finished: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// UserRepositoryJSON implements the UserRepository interface using JSON files
+type UserRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewUserRepository creates a new UserRepositoryJSON instance
+func NewUserRepository(storage *Storage) ports.UserRepository {
+	return &UserRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all users
+func (r *UserRepositoryJSON) FindAll() ([]*domain.User, error) {
+	var users []*domain.User
+	err := r.storage.Load("users.json", &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// FindByUsername finds a user by their username
+func (r *UserRepositoryJSON) FindByUsername(username string) (*domain.User, error) {
+	users, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user %s %w", username, domain.ErrNotFound)
+}
+
+// Save stores a user in the repository, replacing any previous version
+func (r *UserRepositoryJSON) Save(user *domain.User) error {
+	users, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existingUser := range users {
+		if existingUser.Username == user.Username {
+			users[i] = user
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		users = append(users, user)
+	}
+
+	return r.storage.Save("users.json", users)
+}
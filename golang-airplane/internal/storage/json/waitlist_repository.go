@@ -0,0 +1,91 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"sort"
+)
+
+// WaitlistRepositoryJSON implements the WaitlistRepository interface using JSON files
+type WaitlistRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewWaitlistRepository creates a new WaitlistRepositoryJSON instance
+func NewWaitlistRepository(storage *Storage) ports.WaitlistRepository {
+	return &WaitlistRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all waitlist entries
+func (r *WaitlistRepositoryJSON) FindAll() ([]*domain.WaitlistEntry, error) {
+	var entries []*domain.WaitlistEntry
+	err := r.storage.Load("waitlist.json", &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// FindByID finds a waitlist entry by its ID
+func (r *WaitlistRepositoryJSON) FindByID(id string) (*domain.WaitlistEntry, error) {
+	entries, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+
+	return nil, fmt.Errorf("waitlist entry with ID %s %w", id, domain.ErrNotFound)
+}
+
+// FindByFlightNumber finds the waitlist entries of a flight in the order they were requested
+func (r *WaitlistRepositoryJSON) FindByFlightNumber(flightNumber string) ([]*domain.WaitlistEntry, error) {
+	entries, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var flightEntries []*domain.WaitlistEntry
+	for _, entry := range entries {
+		if entry.FlightNumber == flightNumber {
+			flightEntries = append(flightEntries, entry)
+		}
+	}
+
+	sort.SliceStable(flightEntries, func(i, j int) bool {
+		return flightEntries[i].RequestedAt.Before(flightEntries[j].RequestedAt)
+	})
+
+	return flightEntries, nil
+}
+
+// Save stores a waitlist entry in the repository
+func (r *WaitlistRepositoryJSON) Save(entry *domain.WaitlistEntry) error {
+	entries, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existingEntry := range entries {
+		if existingEntry.ID == entry.ID {
+			entries[i] = entry
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		entries = append(entries, entry)
+	}
+
+	return r.storage.Save("waitlist.json", entries)
+}
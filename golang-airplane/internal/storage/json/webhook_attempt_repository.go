@@ -0,0 +1,41 @@
+package json
+
+import (
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// WebhookAttemptRepositoryJSON implements the WebhookAttemptRepository interface using JSON files
+type WebhookAttemptRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewWebhookAttemptRepository creates a new WebhookAttemptRepositoryJSON instance
+func NewWebhookAttemptRepository(storage *Storage) ports.WebhookAttemptRepository {
+	return &WebhookAttemptRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// Append records an attempt
+func (r *WebhookAttemptRepositoryJSON) Append(attempt *domain.WebhookAttempt) error {
+	attempts, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	attempts = append(attempts, attempt)
+
+	return r.storage.Save("webhook_log.json", attempts)
+}
+
+// FindAll returns all attempts in the order they were made
+func (r *WebhookAttemptRepositoryJSON) FindAll() ([]*domain.WebhookAttempt, error) {
+	var attempts []*domain.WebhookAttempt
+	err := r.storage.Load("webhook_log.json", &attempts)
+	if err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,86 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// WebhookDeliveryRepositoryJSON implements the WebhookDeliveryRepository interface using JSON files
+type WebhookDeliveryRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewWebhookDeliveryRepository creates a new WebhookDeliveryRepositoryJSON instance
+func NewWebhookDeliveryRepository(storage *Storage) ports.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all deliveries in the order they were queued
+func (r *WebhookDeliveryRepositoryJSON) FindAll() ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := r.storage.Load("webhook_deliveries.json", &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// FindByID finds a delivery by its ID
+func (r *WebhookDeliveryRepositoryJSON) FindByID(id string) (*domain.WebhookDelivery, error) {
+	deliveries, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.ID == id {
+			return delivery, nil
+		}
+	}
+
+	return nil, fmt.Errorf("webhook delivery %s %w", id, domain.ErrNotFound)
+}
+
+// Save stores a delivery in the repository, replacing any previous version
+func (r *WebhookDeliveryRepositoryJSON) Save(delivery *domain.WebhookDelivery) error {
+	deliveries, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existing := range deliveries {
+		if existing.ID == delivery.ID {
+			deliveries[i] = delivery
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		deliveries = append(deliveries, delivery)
+	}
+
+	return r.storage.Save("webhook_deliveries.json", deliveries)
+}
+
+// Delete removes a delivery once it has been sent
+func (r *WebhookDeliveryRepositoryJSON) Delete(id string) error {
+	deliveries, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for i, delivery := range deliveries {
+		if delivery.ID == id {
+			deliveries = append(deliveries[:i], deliveries[i+1:]...)
+			return r.storage.Save("webhook_deliveries.json", deliveries)
+		}
+	}
+
+	return fmt.Errorf("webhook delivery %s %w", id, domain.ErrNotFound)
+}
@@ -0,0 +1,86 @@
+package json
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+)
+
+// WebhookSubscriptionRepositoryJSON implements the WebhookSubscriptionRepository interface using JSON files
+type WebhookSubscriptionRepositoryJSON struct {
+	storage *Storage
+}
+
+// NewWebhookSubscriptionRepository creates a new WebhookSubscriptionRepositoryJSON instance
+func NewWebhookSubscriptionRepository(storage *Storage) ports.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepositoryJSON{
+		storage: storage,
+	}
+}
+
+// FindAll returns all subscriptions
+func (r *WebhookSubscriptionRepositoryJSON) FindAll() ([]*domain.WebhookSubscription, error) {
+	var subscriptions []*domain.WebhookSubscription
+	err := r.storage.Load("webhooks.json", &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// FindByID finds a subscription by its ID
+func (r *WebhookSubscriptionRepositoryJSON) FindByID(id string) (*domain.WebhookSubscription, error) {
+	subscriptions, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.ID == id {
+			return subscription, nil
+		}
+	}
+
+	return nil, fmt.Errorf("webhook %s %w", id, domain.ErrNotFound)
+}
+
+// Save stores a subscription in the repository, replacing any previous version
+func (r *WebhookSubscriptionRepositoryJSON) Save(subscription *domain.WebhookSubscription) error {
+	subscriptions, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existing := range subscriptions {
+		if existing.ID == subscription.ID {
+			subscriptions[i] = subscription
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return r.storage.Save("webhooks.json", subscriptions)
+}
+
+// Delete removes a subscription
+func (r *WebhookSubscriptionRepositoryJSON) Delete(id string) error {
+	subscriptions, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for i, subscription := range subscriptions {
+		if subscription.ID == id {
+			subscriptions = append(subscriptions[:i], subscriptions[i+1:]...)
+			return r.storage.Save("webhooks.json", subscriptions)
+		}
+	}
+
+	return fmt.Errorf("webhook %s %w", id, domain.ErrNotFound)
+}
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Styles of the interface, as ANSI attributes
+const (
+	styleNormal    = ""
+	styleBold      = "1"
+	styleDim       = "2"
+	styleReverse   = "7"
+	styleTitle     = "1;97;44"
+	styleError     = "1;31"
+	styleSuccess   = "1;32"
+	styleHeader    = "1;4"
+	styleFocus     = "30;46"
+	styleAvailable = "30;42"
+	styleOccupied  = "37;41"
+	styleHeld      = "30;43"
+	styleBlocked   = "37;100"
+	styleCursor    = "97;44;1"
+)
+
+// cell is one character position of the screen
+type cell struct {
+	r     rune
+	style string
+}
+
+// canvas is a frame drawn in memory and then written to the terminal at once
+type canvas struct {
+	width, height int
+	cells         []cell
+}
+
+// newCanvas creates a blank canvas
+func newCanvas(width, height int) *canvas {
+	c := &canvas{width: width, height: height, cells: make([]cell, width*height)}
+	for i := range c.cells {
+		c.cells[i] = cell{r: ' '}
+	}
+	return c
+}
+
+// text draws text from a position, cut at the right edge, and returns the column after it
+func (c *canvas) text(x, y int, s, style string) int {
+	if y < 0 || y >= c.height {
+		return x
+	}
+	for _, r := range s {
+		if x >= c.width {
+			break
+		}
+		if x >= 0 {
+			c.cells[y*c.width+x] = cell{r: r, style: style}
+		}
+		x++
+	}
+	return x
+}
+
+// textf draws formatted text
+func (c *canvas) textf(x, y int, style, format string, args ...interface{}) int {
+	return c.text(x, y, fmt.Sprintf(format, args...), style)
+}
+
+// fill fills a rectangle with a style, clearing its text
+func (c *canvas) fill(x, y, width, height int, style string) {
+	for row := y; row < y+height && row < c.height; row++ {
+		for col := x; col < x+width && col < c.width; col++ {
+			if row >= 0 && col >= 0 {
+				c.cells[row*c.width+col] = cell{r: ' ', style: style}
+			}
+		}
+	}
+}
+
+// line draws text padded or cut to a width, so a styled line covers the whole width
+func (c *canvas) line(x, y, width int, s, style string) {
+	c.fill(x, y, width, 1, style)
+	runes := []rune(s)
+	if len(runes) > width {
+		if width < 1 {
+			return
+		}
+		runes = append(runes[:width-1], '…')
+	}
+	c.text(x, y, string(runes), style)
+}
+
+// box draws a frame with a title
+func (c *canvas) box(x, y, width, height int, title string, focused bool) {
+	style := styleDim
+	if focused {
+		style = styleBold
+	}
+	c.text(x, y, "┌"+strings.Repeat("─", width-2)+"┐", style)
+	for row := y + 1; row < y+height-1; row++ {
+		c.text(x, row, "│", style)
+		c.text(x+width-1, row, "│", style)
+	}
+	c.text(x, y+height-1, "└"+strings.Repeat("─", width-2)+"┘", style)
+	if title != "" {
+		c.text(x+2, y, " "+title+" ", style)
+	}
+}
+
+// render returns the ANSI sequences drawing the canvas over the whole screen
+func (c *canvas) render() string {
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for y := 0; y < c.height; y++ {
+		fmt.Fprintf(&sb, "\x1b[%d;1H", y+1)
+		style := styleNormal
+		sb.WriteString("\x1b[0m")
+		for x := 0; x < c.width; x++ {
+			current := c.cells[y*c.width+x]
+			if current.style != style {
+				style = current.style
+				sb.WriteString("\x1b[0m")
+				if style != styleNormal {
+					sb.WriteString("\x1b[" + style + "m")
+				}
+			}
+			sb.WriteRune(current.r)
+		}
+	}
+	sb.WriteString("\x1b[0m")
+	return sb.String()
+}
@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"fmt"
+	"golang-airplane/internal/core/domain"
+	"strconv"
+	"strings"
+)
+
+// formField is one input of the booking form
+type formField struct {
+	label    string
+	value    string
+	touched  bool     // Edited, so its error is shown
+	choices  []string // Values cycled with the arrow keys instead of typed, if any
+	validate func(value string) string
+}
+
+// bookingForm books the selected flight for a passenger, checking each field as it is typed
+type bookingForm struct {
+	ui        *UI
+	flight    *domain.Flight
+	bookable  int
+	fields    []*formField
+	focus     int // Index of the focused field; len(fields) is the Book button
+	submitted bool
+	err       string
+}
+
+// Indexes of the booking form fields
+const (
+	fieldName = iota
+	fieldAddress
+	fieldPhone
+	fieldIdentityCard
+	fieldClass
+)
+
+// newBookingForm creates the booking form of a flight
+func newBookingForm(ui *UI, f *domain.Flight) *bookingForm {
+	form := &bookingForm{
+		ui:       ui,
+		flight:   f,
+		bookable: -1,
+		fields: []*formField{
+			{label: "Name", validate: required("the name")},
+			{label: "Address", validate: required("the address")},
+			{label: "Phone number", validate: positiveNumber("the phone number")},
+			{label: "ID card number", validate: positiveNumber("the identity card number")},
+			{label: "Class", value: domain.ClassEconomy, choices: []string{domain.ClassEconomy, domain.ClassBusiness}},
+		},
+	}
+	if bookable, err := ui.reservations.BookableSeats(f.FlightNumber); err == nil {
+		form.bookable = bookable
+	}
+	return form
+}
+
+// required checks that a value is not blank
+func required(what string) func(string) string {
+	return func(value string) string {
+		if strings.TrimSpace(value) == "" {
+			return what + " is required"
+		}
+		return ""
+	}
+}
+
+// positiveNumber checks that a value is a positive whole number
+func positiveNumber(what string) func(string) string {
+	return func(value string) string {
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || n <= 0 {
+			return what + " must be a positive number"
+		}
+		return ""
+	}
+}
+
+// fieldError returns the error of a field, or an empty string when it is valid
+func (f *formField) fieldError() string {
+	if f.validate == nil {
+		return ""
+	}
+	return f.validate(f.value)
+}
+
+// handle handles a key press in the form
+func (form *bookingForm) handle(k key) {
+	buttonFocused := form.focus == len(form.fields)
+
+	switch k.code {
+	case keyEscape:
+		form.ui.form = nil
+	case keyUp, keyBackTab:
+		form.focus = (form.focus + len(form.fields)) % (len(form.fields) + 1)
+	case keyDown, keyTab:
+		form.focus = (form.focus + 1) % (len(form.fields) + 1)
+	case keyEnter:
+		if buttonFocused {
+			form.submit()
+		} else {
+			form.focus++
+		}
+	case keyLeft, keyRight:
+		if !buttonFocused {
+			form.fields[form.focus].cycle(k.code == keyRight)
+		}
+	case keyBackspace:
+		if !buttonFocused {
+			field := form.fields[form.focus]
+			if runes := []rune(field.value); field.choices == nil && len(runes) > 0 {
+				field.value = string(runes[:len(runes)-1])
+				field.touched = true
+			}
+		}
+	case keyRune:
+		if buttonFocused {
+			return
+		}
+		field := form.fields[form.focus]
+		if field.choices != nil {
+			field.cycle(true)
+			return
+		}
+		field.value += string(k.r)
+		field.touched = true
+	}
+}
+
+// cycle moves a choice field to the next or previous choice
+func (f *formField) cycle(forward bool) {
+	if f.choices == nil {
+		return
+	}
+	for i, choice := range f.choices {
+		if choice == f.value {
+			step := len(f.choices) - 1
+			if forward {
+				step = 1
+			}
+			f.value = f.choices[(i+step)%len(f.choices)]
+			return
+		}
+	}
+}
+
+// submit books the flight when every field is valid
+func (form *bookingForm) submit() {
+	form.submitted = true
+	for i, field := range form.fields {
+		if field.fieldError() != "" {
+			form.focus = i
+			return
+		}
+	}
+
+	phone, _ := strconv.ParseInt(strings.TrimSpace(form.fields[fieldPhone].value), 10, 64)
+	identityCard, _ := strconv.ParseInt(strings.TrimSpace(form.fields[fieldIdentityCard].value), 10, 64)
+	reservation, err := form.ui.reservations.BookFlight(strings.TrimSpace(form.fields[fieldName].value),
+		strings.TrimSpace(form.fields[fieldAddress].value), phone, identityCard, form.flight.FlightNumber,
+		form.fields[fieldClass].value, form.ui.session)
+	if err != nil {
+		form.err = err.Error()
+		return
+	}
+
+	form.ui.form = nil
+	form.ui.setMessage(styleSuccess, "Booked reservation %s for %s on flight %s", reservation.ReservationID, reservation.Name, reservation.ReservationFlightNumber)
+	form.ui.refresh()
+}
+
+// draw draws the form over the board
+func (form *bookingForm) draw(c *canvas) {
+	width, height := 64, len(form.fields)*2+9
+	x, y := (c.width-width)/2, (c.height-height)/2
+
+	c.fill(x, y, width, height, styleNormal)
+	c.box(x, y, width, height, "Book flight "+form.flight.FlightNumber, true)
+
+	route := fmt.Sprintf("%s → %s, departing %s", form.flight.DepartureCity, form.flight.DestinationCity,
+		form.flight.DepartureTime.Format("02/01/2006 15:04"))
+	c.line(x+2, y+1, width-4, route, styleNormal)
+	if form.bookable >= 0 {
+		c.line(x+2, y+2, width-4, fmt.Sprintf("%d seat(s) can still be booked", form.bookable), styleDim)
+	}
+
+	for i, field := range form.fields {
+		row := y + 4 + i*2
+		c.text(x+2, row, fmt.Sprintf("%-15s", field.label), styleBold)
+
+		value := field.value
+		if field.choices != nil {
+			value = "◀ " + value + " ▶"
+		} else if i == form.focus {
+			value += "█"
+		}
+		style := styleReverse
+		if i == form.focus {
+			style = styleFocus
+		}
+		c.line(x+18, row, width-20, " "+value, style)
+
+		if message := field.fieldError(); message != "" && (field.touched || form.submitted) {
+			c.line(x+18, row+1, width-20, " ✗ "+message, styleError)
+		}
+	}
+
+	buttonRow := y + height - 3
+	button := "[ Book ]"
+	style := styleBold
+	if form.focus == len(form.fields) {
+		style = styleFocus
+	}
+	c.text(x+(width-len(button))/2, buttonRow, button, style)
+	if form.err != "" {
+		c.line(x+2, buttonRow+1, width-4, form.err, styleError)
+	} else {
+		c.line(x+2, buttonRow+1, width-4, "Tab/↑↓ move  ←→ class  Enter next/book  Esc cancel", styleDim)
+	}
+}
@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"fmt"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+)
+
+// Width of a seat and of the aisle in the seat picker
+const (
+	seatWidth  = 5
+	aisleWidth = 3
+)
+
+// seatStyles are the colours of the seat states
+var seatStyles = map[string]string{
+	domain.SeatStateAvailable: styleAvailable,
+	domain.SeatStateOccupied:  styleOccupied,
+	domain.SeatStateHeld:      styleHeld,
+	domain.SeatStateBlocked:   styleBlocked,
+}
+
+// seatPicker checks a reservation in on a seat chosen on the seat map with the arrow keys
+type seatPicker struct {
+	ui          *UI
+	reservation *domain.Reservation
+	flight      *domain.Flight
+	seatMap     *domain.SeatMap
+	row, column int // Position of the cursor in the seat map
+	offset      int // First row shown
+	err         string
+}
+
+// newSeatPicker opens the seat picker of a reservation, with the cursor on the first free seat of
+// the passenger's cabin
+func newSeatPicker(ui *UI, reservation *domain.Reservation) *seatPicker {
+	picker := &seatPicker{ui: ui, reservation: reservation}
+	picker.reload()
+
+	if picker.seatMap != nil {
+	search:
+		for i, row := range picker.seatMap.Rows {
+			for j, seat := range row.Seats {
+				if seat.State == domain.SeatStateAvailable && picker.flight.InCabinOf(seat.Number, reservation) {
+					picker.row, picker.column = i, j
+					break search
+				}
+			}
+		}
+	}
+	return picker
+}
+
+// reload reloads the flight and its seat map, so seats taken meanwhile show as taken
+func (p *seatPicker) reload() {
+	f, err := p.ui.flights.GetFlight(p.reservation.ReservationFlightNumber)
+	if err != nil {
+		p.err = err.Error()
+		return
+	}
+	seatMap, err := p.ui.seats.SeatMap(f.FlightNumber, p.ui.session, "")
+	if err != nil {
+		p.err = err.Error()
+		return
+	}
+	p.flight, p.seatMap = f, seatMap
+}
+
+// current returns the seat under the cursor, which has no number at a position without a seat
+func (p *seatPicker) current() domain.SeatMapSeat {
+	if p.seatMap == nil || p.row >= len(p.seatMap.Rows) || p.column >= len(p.seatMap.Rows[p.row].Seats) {
+		return domain.SeatMapSeat{}
+	}
+	return p.seatMap.Rows[p.row].Seats[p.column]
+}
+
+// handle handles a key press in the seat picker
+func (p *seatPicker) handle(k key) {
+	switch k.code {
+	case keyEscape:
+		p.ui.picker = nil
+	case keyUp:
+		p.moveTo(p.row-1, p.column, -1, 0)
+	case keyDown:
+		p.moveTo(p.row+1, p.column, 1, 0)
+	case keyLeft:
+		p.moveTo(p.row, p.column-1, 0, -1)
+	case keyRight:
+		p.moveTo(p.row, p.column+1, 0, 1)
+	case keyPageUp:
+		p.moveTo(p.row-10, p.column, -1, 0)
+	case keyPageDown:
+		p.moveTo(p.row+10, p.column, 1, 0)
+	case keyEnter:
+		seat := p.current()
+		if seat.Number == "" {
+			return
+		}
+		if seat.State != domain.SeatStateAvailable {
+			p.err = fmt.Sprintf("Seat %s is %s", seat.Number, seat.State)
+			return
+		}
+		p.checkIn(seat.Number)
+	case keyRune:
+		if k.r == 'a' {
+			p.checkIn("")
+		}
+	}
+}
+
+// moveTo moves the cursor to a position, stepping further in the direction of the move past
+// positions without a seat
+func (p *seatPicker) moveTo(row, column, rowStep, columnStep int) {
+	if p.seatMap == nil {
+		return
+	}
+	rows := len(p.seatMap.Rows)
+	if row < 0 {
+		row = 0
+	}
+	if row >= rows {
+		row = rows - 1
+	}
+
+	for row >= 0 && row < rows && column >= 0 && column < len(p.seatMap.Columns) {
+		if p.seatMap.Rows[row].Seats[column].Number != "" {
+			p.row, p.column = row, column
+			p.err = ""
+			return
+		}
+		if rowStep == 0 && columnStep == 0 {
+			return
+		}
+		row, column = row+rowStep, column+columnStep
+	}
+}
+
+// checkIn checks the reservation in on a seat, or on an assigned one when seat is empty
+func (p *seatPicker) checkIn(seat string) {
+	if err := p.ui.reservations.CheckIn(p.reservation.ReservationID, seat, p.ui.session); err != nil {
+		p.err = err.Error()
+		p.reload()
+		return
+	}
+
+	checkedIn, err := p.ui.reservations.GetReservation(p.reservation.ReservationID)
+	if err == nil {
+		seat = checkedIn.SeatLocation
+	}
+	p.ui.picker = nil
+	p.ui.setMessage(styleSuccess, "Checked in %s (%s) on seat %s", p.reservation.Name, p.reservation.ReservationID, seat)
+	p.ui.refresh()
+}
+
+// draw draws the seat map with the cursor, the details of the seat under it and a legend
+func (p *seatPicker) draw(c *canvas) {
+	c.line(0, 0, c.width, fmt.Sprintf(" Check in %s (%s, %s) on flight %s", p.reservation.Name, p.reservation.ReservationID,
+		p.reservation.Class, p.reservation.ReservationFlightNumber), styleTitle)
+	c.line(0, c.height-1, c.width, " ←↑↓→ move  Enter check in on seat  a assign best seat  Esc back", styleReverse)
+	if p.err != "" {
+		c.line(0, c.height-2, c.width, " "+p.err, styleError)
+	}
+	if p.seatMap == nil {
+		return
+	}
+
+	// Keep the cursor row in view
+	visibleRows := c.height - 6
+	if p.row < p.offset {
+		p.offset = p.row
+	}
+	if p.row >= p.offset+visibleRows {
+		p.offset = p.row - visibleRows + 1
+	}
+
+	// Column letters
+	x := 2
+	c.text(x, 2, "    ", styleNormal)
+	left := x + 5
+	col := left
+	for i, column := range p.seatMap.Columns {
+		c.textf(col, 2, styleBold, "  %s  ", column)
+		col += seatWidth
+		if p.seatMap.HasAisleAfter(i) {
+			col += aisleWidth
+		}
+	}
+	mapWidth := col - x
+
+	for i := 0; i < visibleRows && p.offset+i < len(p.seatMap.Rows); i++ {
+		index := p.offset + i
+		row := p.seatMap.Rows[index]
+		y := 3 + i
+
+		label := fmt.Sprintf("%3d ", row.Number)
+		labelStyle := styleDim
+		if row.Cabin == domain.ClassBusiness {
+			labelStyle = styleBold
+		}
+		c.text(x, y, label, labelStyle)
+
+		col := left
+		for j, seat := range row.Seats {
+			if seat.Number != "" {
+				style := seatStyles[seat.State]
+				text := fmt.Sprintf(" %-3s ", seat.Number)
+				if index == p.row && j == p.column {
+					style = styleCursor
+					text = fmt.Sprintf("[%-3s]", seat.Number)
+				}
+				c.text(col, y, text, style)
+			}
+			col += seatWidth
+			if p.seatMap.HasAisleAfter(j) {
+				col += aisleWidth
+			}
+		}
+		if row.ExitRow {
+			c.text(col, y, " EXIT", styleBold)
+		}
+	}
+
+	// Details of the seat under the cursor
+	infoX := x + mapWidth + 8
+	seat := p.current()
+	c.text(infoX, 2, "Seat "+seat.Number, styleHeader)
+	c.textf(infoX, 4, styleNormal, "State:      %s", seat.State)
+	c.textf(infoX, 5, styleNormal, "Cabin:      %s", p.flight.CabinOf(p.seatMap.Rows[p.row].Number))
+	attributes := domain.FormatSeatAttributes(seat.Attributes)
+	if attributes == "" {
+		attributes = "-"
+	}
+	c.textf(infoX, 6, styleNormal, "Attributes: %s", attributes)
+
+	price := "free"
+	if charge, err := p.ui.seats.SeatCharge(p.reservation.ReservationID, seat.Number); err == nil && charge > 0 {
+		price = domain.FormatAmount(charge, flight.DefaultFareRules.Currency)
+	}
+	c.textf(infoX, 7, styleNormal, "Price:      %s", price)
+	if !p.flight.InCabinOf(seat.Number, p.reservation) {
+		c.text(infoX, 8, "Outside the passenger's cabin", styleError)
+	}
+
+	// Legend
+	legendY := 10
+	c.text(infoX, legendY, "Legend", styleHeader)
+	for i, state := range []string{domain.SeatStateAvailable, domain.SeatStateOccupied, domain.SeatStateHeld, domain.SeatStateBlocked} {
+		c.text(infoX, legendY+2+i, "    ", seatStyles[state])
+		c.text(infoX+5, legendY+2+i, state, styleNormal)
+	}
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"errors"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ANSI sequences controlling the terminal
+const (
+	enterAltScreen = "\x1b[?1049h"
+	leaveAltScreen = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	clearScreen    = "\x1b[2J"
+)
+
+// keyCode identifies a key that does not type a character
+type keyCode int
+
+// Keys read from the terminal
+const (
+	keyRune keyCode = iota // A typed character
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyTab
+	keyBackTab
+	keyBackspace
+	keyDelete
+	keyEscape
+	keyCtrlC
+)
+
+// key is one key press
+type key struct {
+	code keyCode
+	r    rune // The character of a keyRune
+}
+
+// escapeSequences maps the sequences sent after ESC to keys
+var escapeSequences = map[string]keyCode{
+	"[A": keyUp, "[B": keyDown, "[C": keyRight, "[D": keyLeft,
+	"OA": keyUp, "OB": keyDown, "OC": keyRight, "OD": keyLeft,
+	"[5~": keyPageUp, "[6~": keyPageDown,
+	"[H": keyHome, "[F": keyEnd, "OH": keyHome, "OF": keyEnd, "[1~": keyHome, "[4~": keyEnd,
+	"[3~": keyDelete, "[Z": keyBackTab,
+}
+
+// terminal is the terminal in raw mode, drawn on an alternate screen
+type terminal struct {
+	fd    int
+	out   io.Writer
+	state *term.State
+}
+
+// openTerminal puts the terminal of in into raw mode and switches out to the alternate screen
+func openTerminal(in *os.File, out io.Writer) (*terminal, error) {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, errors.New("the terminal interface needs a terminal")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	io.WriteString(out, enterAltScreen+hideCursor+clearScreen)
+	return &terminal{fd: fd, out: out, state: state}, nil
+}
+
+// size returns the width and height of the terminal
+func (t *terminal) size() (int, int) {
+	width, height, err := term.GetSize(t.fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// close leaves the alternate screen and restores the terminal
+func (t *terminal) close() {
+	io.WriteString(t.out, showCursor+leaveAltScreen)
+	term.Restore(t.fd, t.state)
+}
+
+// readKeys reads key presses from in until it fails, sending them on keys
+func readKeys(in io.Reader, keys chan<- key) {
+	buf := make([]byte, 256)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		for _, k := range parseKeys(buf[:n]) {
+			keys <- k
+		}
+	}
+}
+
+// parseKeys splits the bytes of one read into key presses; an ESC alone in a read is the Escape key
+func parseKeys(data []byte) []key {
+	var keys []key
+	for len(data) > 0 {
+		switch b := data[0]; {
+		case b == 0x1b:
+			if len(data) == 1 {
+				return append(keys, key{code: keyEscape})
+			}
+			code, size := parseEscape(data[1:])
+			if size == 0 {
+				keys = append(keys, key{code: keyEscape})
+				data = data[1:]
+				continue
+			}
+			if code != keyRune {
+				keys = append(keys, key{code: code})
+			}
+			data = data[1+size:]
+		case b == '\r' || b == '\n':
+			keys = append(keys, key{code: keyEnter})
+			data = data[1:]
+		case b == '\t':
+			keys = append(keys, key{code: keyTab})
+			data = data[1:]
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, key{code: keyBackspace})
+			data = data[1:]
+		case b == 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+			data = data[1:]
+		case b < 0x20:
+			data = data[1:] // Other control keys are ignored
+		default:
+			r, size := utf8.DecodeRune(data)
+			keys = append(keys, key{code: keyRune, r: r})
+			data = data[size:]
+		}
+	}
+	return keys
+}
+
+// parseEscape reads the escape sequence at the start of data, which follows an ESC, returning its
+// key and length; unknown sequences are skipped as keyRune, and a length of 0 means there was none
+func parseEscape(data []byte) (keyCode, int) {
+	if data[0] != '[' && data[0] != 'O' {
+		return keyRune, 0
+	}
+	for i := 1; i < len(data); i++ {
+		// A sequence ends with a letter or a tilde
+		if c := data[i]; c == '~' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+			if code, known := escapeSequences[string(data[:i+1])]; known {
+				return code, i + 1
+			}
+			return keyRune, i + 1
+		}
+	}
+	return keyRune, len(data)
+}
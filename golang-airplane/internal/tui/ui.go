@@ -0,0 +1,408 @@
+// Package tui is a full-screen terminal interface for agents: a flight board that can be scrolled
+// and filtered, a live panel of the reservations of the selected flight, a booking form and a seat
+// picker for check-in
+package tui
+
+import (
+	"fmt"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultRefreshInterval is how often the open views reload their data
+const DefaultRefreshInterval = 2 * time.Second
+
+// Minimum size of the terminal
+const (
+	minWidth  = 80
+	minHeight = 20
+)
+
+// panel identifies the panel of the board that has the focus
+type panel int
+
+const (
+	flightsPanel panel = iota
+	reservationsPanel
+)
+
+// UI is the terminal interface, driven by the same services as the menu of the application
+type UI struct {
+	flights         ports.FlightService
+	reservations    ports.ReservationService
+	seats           *flight.SeatService
+	session         string // Booking session tying seat holds to the agent
+	refreshInterval time.Duration
+
+	allFlights []*domain.Flight
+	visible    []*domain.Flight // The flights matching the filter
+	filter     string
+	filtering  bool // The filter is being typed
+	focus      panel
+	flightList list
+
+	passengers    []*domain.Reservation // Reservations of the selected flight
+	passengerList list
+	message       string
+	messageStyle  string
+	form          *bookingForm // Open booking form, if any
+	picker        *seatPicker  // Open seat picker, if any
+	quit          bool
+}
+
+// list is the cursor and scroll position of a scrollable list
+type list struct {
+	cursor, offset int
+	height         int // Rows shown at the last draw
+}
+
+// move moves the cursor of a list of n items by delta and keeps it in view
+func (l *list) move(delta, n int) {
+	l.cursor += delta
+	if l.cursor >= n {
+		l.cursor = n - 1
+	}
+	if l.cursor < 0 {
+		l.cursor = 0
+	}
+	l.scroll()
+}
+
+// scroll keeps the cursor in view
+func (l *list) scroll() {
+	if l.cursor < l.offset {
+		l.offset = l.cursor
+	}
+	if l.height > 0 && l.cursor >= l.offset+l.height {
+		l.offset = l.cursor - l.height + 1
+	}
+}
+
+// New creates the terminal interface; session is the booking session of the agent
+func New(flights ports.FlightService, reservations ports.ReservationService, seats *flight.SeatService, session string) *UI {
+	return &UI{
+		flights:         flights,
+		reservations:    reservations,
+		seats:           seats,
+		session:         session,
+		refreshInterval: DefaultRefreshInterval,
+	}
+}
+
+// Run shows the interface on the terminal of in until the agent quits
+func (ui *UI) Run(in *os.File, out io.Writer) error {
+	t, err := openTerminal(in, out)
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	keys := make(chan key, 16)
+	go readKeys(in, keys)
+
+	ticker := time.NewTicker(ui.refreshInterval)
+	defer ticker.Stop()
+
+	ui.refresh()
+	for !ui.quit {
+		ui.draw(t)
+
+		select {
+		case k, open := <-keys:
+			if !open {
+				return nil
+			}
+			ui.handle(k)
+		case <-ticker.C:
+			ui.refresh()
+		}
+	}
+	return nil
+}
+
+// draw draws the current view
+func (ui *UI) draw(t *terminal) {
+	width, height := t.size()
+	c := newCanvas(width, height)
+
+	if width < minWidth || height < minHeight {
+		c.text(0, 0, fmt.Sprintf("The terminal must be at least %dx%d.", minWidth, minHeight), styleError)
+		io.WriteString(t.out, c.render())
+		return
+	}
+
+	switch {
+	case ui.picker != nil:
+		ui.picker.draw(c)
+	default:
+		ui.drawBoard(c)
+		if ui.form != nil {
+			ui.form.draw(c)
+		}
+	}
+	io.WriteString(t.out, c.render())
+}
+
+// handle handles a key press in the current view
+func (ui *UI) handle(k key) {
+	if k.code == keyCtrlC {
+		ui.quit = true
+		return
+	}
+
+	switch {
+	case ui.picker != nil:
+		ui.picker.handle(k)
+	case ui.form != nil:
+		ui.form.handle(k)
+	case ui.filtering:
+		ui.handleFilter(k)
+	default:
+		ui.handleBoard(k)
+	}
+}
+
+// setMessage shows a message in the status line
+func (ui *UI) setMessage(style, format string, args ...interface{}) {
+	ui.message = fmt.Sprintf(format, args...)
+	ui.messageStyle = style
+}
+
+// refresh reloads the flights and the reservations of the selected flight, and the open seat map
+func (ui *UI) refresh() {
+	flights, err := ui.flights.ListAllFlights()
+	if err != nil {
+		ui.setMessage(styleError, "Error loading flights: %v", err)
+		return
+	}
+	ui.allFlights = flights
+	ui.applyFilter()
+	ui.loadPassengers()
+
+	if ui.picker != nil {
+		ui.picker.reload()
+	}
+}
+
+// applyFilter lists the flights matching the filter, keeping the selected flight selected
+func (ui *UI) applyFilter() {
+	selected := ui.selectedFlight()
+
+	ui.visible = ui.visible[:0]
+	filter := strings.ToLower(strings.TrimSpace(ui.filter))
+	for _, f := range ui.allFlights {
+		if filter == "" || strings.Contains(strings.ToLower(f.FlightNumber+" "+f.DepartureCity+" "+f.DestinationCity+" "+f.CurrentStatus()), filter) {
+			ui.visible = append(ui.visible, f)
+		}
+	}
+
+	if selected != nil {
+		for i, f := range ui.visible {
+			if f.FlightNumber == selected.FlightNumber {
+				ui.flightList.cursor = i
+			}
+		}
+	}
+	ui.flightList.move(0, len(ui.visible))
+}
+
+// selectedFlight returns the flight under the cursor, or nil
+func (ui *UI) selectedFlight() *domain.Flight {
+	if ui.flightList.cursor < len(ui.visible) {
+		return ui.visible[ui.flightList.cursor]
+	}
+	return nil
+}
+
+// selectedPassenger returns the reservation under the cursor, or nil
+func (ui *UI) selectedPassenger() *domain.Reservation {
+	if ui.passengerList.cursor < len(ui.passengers) {
+		return ui.passengers[ui.passengerList.cursor]
+	}
+	return nil
+}
+
+// loadPassengers loads the reservations of the selected flight
+func (ui *UI) loadPassengers() {
+	selected := ui.selectedFlight()
+	if selected == nil {
+		ui.passengers = nil
+		return
+	}
+
+	passengers, err := ui.reservations.GetReservationsForFlight(selected.FlightNumber)
+	if err != nil {
+		ui.setMessage(styleError, "Error loading reservations: %v", err)
+		return
+	}
+	ui.passengers = passengers
+	ui.passengerList.move(0, len(passengers))
+}
+
+// handleBoard handles a key press on the board
+func (ui *UI) handleBoard(k key) {
+	current, n := &ui.flightList, len(ui.visible)
+	if ui.focus == reservationsPanel {
+		current, n = &ui.passengerList, len(ui.passengers)
+	}
+
+	switch k.code {
+	case keyUp:
+		current.move(-1, n)
+	case keyDown:
+		current.move(1, n)
+	case keyPageUp:
+		current.move(-current.height, n)
+	case keyPageDown:
+		current.move(current.height, n)
+	case keyHome:
+		current.move(-n, n)
+	case keyEnd:
+		current.move(n, n)
+	case keyTab, keyBackTab, keyLeft, keyRight:
+		if ui.focus == flightsPanel {
+			ui.focus = reservationsPanel
+		} else {
+			ui.focus = flightsPanel
+		}
+		return
+	case keyEscape:
+		ui.filter = ""
+		ui.applyFilter()
+	case keyRune:
+		ui.handleCommand(k.r)
+		return
+	default:
+		return
+	}
+
+	if ui.focus == flightsPanel {
+		ui.passengerList = list{height: ui.passengerList.height}
+		ui.loadPassengers()
+	}
+}
+
+// handleCommand handles a letter key on the board
+func (ui *UI) handleCommand(r rune) {
+	switch r {
+	case 'q':
+		ui.quit = true
+	case '/':
+		ui.filtering = true
+	case 'r':
+		ui.refresh()
+		ui.setMessage(styleNormal, "Refreshed at %s", time.Now().Format("15:04:05"))
+	case 'b':
+		if selected := ui.selectedFlight(); selected != nil {
+			ui.form = newBookingForm(ui, selected)
+		}
+	case 'c':
+		passenger := ui.selectedPassenger()
+		if ui.focus != reservationsPanel || passenger == nil {
+			ui.setMessage(styleError, "Select a reservation with Tab and the arrow keys to check it in")
+			return
+		}
+		if passenger.CheckedIn {
+			ui.setMessage(styleError, "Reservation %s is already checked in on seat %s", passenger.ReservationID, passenger.SeatLocation)
+			return
+		}
+		ui.picker = newSeatPicker(ui, passenger)
+	}
+}
+
+// handleFilter handles a key press while the filter is typed
+func (ui *UI) handleFilter(k key) {
+	switch k.code {
+	case keyEnter:
+		ui.filtering = false
+	case keyEscape:
+		ui.filtering = false
+		ui.filter = ""
+	case keyBackspace:
+		if runes := []rune(ui.filter); len(runes) > 0 {
+			ui.filter = string(runes[:len(runes)-1])
+		}
+	case keyRune:
+		ui.filter += string(k.r)
+	default:
+		return
+	}
+	ui.applyFilter()
+	ui.loadPassengers()
+}
+
+// drawBoard draws the flight list, the reservations panel and the status lines
+func (ui *UI) drawBoard(c *canvas) {
+	c.line(0, 0, c.width, " AIRLINE MANAGEMENT SYSTEM  ·  Agent console", styleTitle)
+	clock := time.Now().Format("02/01/2006 15:04:05")
+	c.text(c.width-len(clock)-1, 0, clock, styleTitle)
+
+	leftWidth := c.width * 3 / 5
+	boxHeight := c.height - 3
+
+	// Flights
+	title := fmt.Sprintf("Flights %d/%d", len(ui.visible), len(ui.allFlights))
+	if ui.filter != "" {
+		title += " · filter: " + ui.filter
+	}
+	c.box(0, 1, leftWidth, boxHeight, title, ui.focus == flightsPanel)
+	ui.flightList.height = boxHeight - 3
+	ui.flightList.scroll()
+	c.line(1, 2, leftWidth-2, fmt.Sprintf(" %-7s %-14s %-14s %-16s %5s  %s", "Flight", "From", "To", "Departure", "Seats", "Status"), styleHeader)
+	for i := 0; i < ui.flightList.height && ui.flightList.offset+i < len(ui.visible); i++ {
+		index := ui.flightList.offset + i
+		f := ui.visible[index]
+		style := styleNormal
+		if index == ui.flightList.cursor {
+			style = styleReverse
+			if ui.focus == flightsPanel {
+				style = styleFocus
+			}
+		}
+		c.line(1, 3+i, leftWidth-2, fmt.Sprintf(" %-7s %-14.14s %-14.14s %-16s %5d  %s", f.FlightNumber, f.DepartureCity,
+			f.DestinationCity, f.DepartureTime.Format("02/01/2006 15:04"), f.AvailableSeat, f.CurrentStatus()), style)
+	}
+	if len(ui.visible) == 0 {
+		c.text(3, 4, "No flights match.", styleDim)
+	}
+
+	// Reservations of the selected flight
+	rightWidth := c.width - leftWidth
+	title = "Reservations"
+	if selected := ui.selectedFlight(); selected != nil {
+		title = fmt.Sprintf("Reservations of %s · %d · live", selected.FlightNumber, len(ui.passengers))
+	}
+	c.box(leftWidth, 1, rightWidth, boxHeight, title, ui.focus == reservationsPanel)
+	ui.passengerList.height = boxHeight - 3
+	ui.passengerList.scroll()
+	c.line(leftWidth+1, 2, rightWidth-2, fmt.Sprintf(" %-6s %-16s %-9s %s", "ID", "Name", "Class", "Seat"), styleHeader)
+	for i := 0; i < ui.passengerList.height && ui.passengerList.offset+i < len(ui.passengers); i++ {
+		index := ui.passengerList.offset + i
+		r := ui.passengers[index]
+		seat := r.SeatLocation
+		switch {
+		case !r.IsConfirmed():
+			seat = r.Status
+		case !r.CheckedIn:
+			seat = "not checked in"
+		}
+		style := styleNormal
+		if index == ui.passengerList.cursor && ui.focus == reservationsPanel {
+			style = styleFocus
+		}
+		c.line(leftWidth+1, 3+i, rightWidth-2, fmt.Sprintf(" %-6s %-16.16s %-9s %s", r.ReservationID, r.Name, r.Class, seat), style)
+	}
+
+	// Status lines
+	if ui.filtering {
+		c.line(0, c.height-2, c.width, " Filter: "+ui.filter+"█", styleBold)
+	} else {
+		c.line(0, c.height-2, c.width, " "+ui.message, ui.messageStyle)
+	}
+	c.line(0, c.height-1, c.width, " ↑↓ move  Tab switch panel  / filter  b book  c check in  r refresh  q quit", styleReverse)
+}
@@ -0,0 +1,7 @@
+package utils
+
+// Pagination defaults of the list calls of the HTTP and gRPC APIs
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
@@ -2,11 +2,14 @@ package utils
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
 	"strings"
 	"time"
+
+	"golang.org/x/term"
 )
 
 // ValidationService implements validation utilities
@@ -23,24 +26,36 @@ func NewValidationService() *ValidationService {
 
 // ValidateFlightNumber checks if a flight number matches the required format (Fxxxx)
 func (v *ValidationService) ValidateFlightNumber(flightNumber string) bool {
-	pattern := regexp.MustCompile(`^F\d{4}$`)
-	return pattern.MatchString(flightNumber)
+	return IsFlightNumber(flightNumber)
+}
+
+// flightNumberPattern matches flight numbers of the form Fxxxx
+var flightNumberPattern = regexp.MustCompile(`^F\d{4}$`)
+
+// IsFlightNumber reports whether a string is a flight number of the form Fxxxx
+func IsFlightNumber(flightNumber string) bool {
+	return flightNumberPattern.MatchString(flightNumber)
 }
 
 // ValidateDates checks if the departure and arrival dates are valid
 func (v *ValidationService) ValidateDates(departureTime, arrivalTime time.Time) bool {
-	currentTime := time.Now()
+	if err := CheckDates(departureTime, arrivalTime, time.Now()); err != nil {
+		fmt.Printf("Invalid dates: %v.\n", err)
+		return false
+	}
+	return true
+}
 
+// CheckDates returns why the departure and arrival dates of a flight are invalid, or nil
+func CheckDates(departureTime, arrivalTime, now time.Time) error {
 	// Departure must be at least 3 hours in the future
-	if departureTime.Before(currentTime.Add(3 * time.Hour)) {
-		fmt.Println("Departure time must be at least 3 hours from now.")
-		return false
+	if departureTime.Before(now.Add(3 * time.Hour)) {
+		return errors.New("departure time must be at least 3 hours from now")
 	}
 
 	// Arrival must be after departure
 	if arrivalTime.Before(departureTime) || arrivalTime.Equal(departureTime) {
-		fmt.Println("Arrival time must be after departure time.")
-		return false
+		return errors.New("arrival time must be after departure time")
 	}
 
 	// Calculate duration between departure and arrival
@@ -51,11 +66,10 @@ func (v *ValidationService) ValidateDates(departureTime, arrivalTime time.Time)
 	maxDuration := 24 * time.Hour
 
 	if duration < minDuration || duration > maxDuration {
-		fmt.Printf("Flight duration must be between 30 minutes and 24 hours. Current: %v\n", duration)
-		return false
+		return fmt.Errorf("flight duration must be between 30 minutes and 24 hours, got %v", duration)
 	}
 
-	return true
+	return nil
 }
 
 // CheckYesOrNo prompts the user with a yes/no question and returns the result
@@ -114,6 +128,19 @@ func (v *ValidationService) GetString(prompt string, errorMsg string, allowEmpty
 	}
 }
 
+// GetPassword prompts for a password without echoing it when the input is a terminal
+func (v *ValidationService) GetPassword(prompt string) string {
+	fmt.Print(prompt)
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		password, _ := term.ReadPassword(fd)
+		fmt.Println()
+		return string(password)
+	}
+
+	input, _ := v.reader.ReadString('\n')
+	return strings.TrimRight(input, "\r\n")
+}
+
 // GetDate prompts for a date input in the specified format
 func (v *ValidationService) GetDate(prompt string, errorMsg string, format string, allowEmpty bool) time.Time {
 	for {
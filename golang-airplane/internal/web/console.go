@@ -0,0 +1,277 @@
+// Package web is the browser console for operations and sales staff: server-rendered pages over the
+// flight, reservation and seat services, behind a session login with CSRF protection
+package web
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/components/documents"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed templates static
+var assets embed.FS
+
+// maxFormBytes limits the size of posted forms
+const maxFormBytes = 1 << 20
+
+// pages are the templates rendered inside the layout
+var pages = []string{"login", "board", "flight", "book", "reservation", "crew", "error"}
+
+// Console serves the web console
+type Console struct {
+	services  ports.ServiceFactory
+	seats     *flight.SeatService
+	documents *documents.Renderer
+	auth      *auth.Service
+	sessions  *sessionStore
+	templates map[string]*template.Template
+	routes    []route
+	static    http.Handler
+	logger    *log.Logger
+	mutex     sync.RWMutex // The JSON storage is not transactional, so changes are made one at a time
+}
+
+// route is a page of the console; patterns name path parameters as {name}
+type route struct {
+	method  string
+	pattern string
+	public  bool // Served without login
+	handler func(w http.ResponseWriter, r *http.Request, sess *session)
+}
+
+// Context keys of the path parameters and the logged-in user of a request
+type (
+	paramsKey struct{}
+	userKey   struct{}
+)
+
+// NewConsole creates the web console; users log in with their accounts, act with the permissions of their roles
+// and through the services that services makes for them
+func NewConsole(services ports.ServiceFactory, seats *flight.SeatService, renderer *documents.Renderer,
+	users *auth.Service, logger *log.Logger) (*Console, error) {
+	c := &Console{
+		services:  services,
+		seats:     seats,
+		documents: renderer,
+		auth:      users,
+		sessions:  newSessionStore(),
+		templates: make(map[string]*template.Template),
+		logger:    logger,
+	}
+
+	for _, page := range pages {
+		tmpl, err := template.New(page).Funcs(templateFuncs).ParseFS(assets, "templates/layout.html", "templates/"+page+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the %s page: %w", page, err)
+		}
+		c.templates[page] = tmpl
+	}
+
+	static, err := fs.Sub(assets, "static")
+	if err != nil {
+		return nil, err
+	}
+	c.static = http.StripPrefix("/static/", http.FileServer(http.FS(static)))
+
+	c.routes = []route{
+		{method: http.MethodGet, pattern: "/login", public: true, handler: c.loginPage},
+		{method: http.MethodPost, pattern: "/login", public: true, handler: c.login},
+		{method: http.MethodPost, pattern: "/logout", handler: c.logout},
+		{method: http.MethodGet, pattern: "/", handler: c.board},
+		{method: http.MethodGet, pattern: "/flights/{flightNumber}", handler: c.flightPage},
+		{method: http.MethodGet, pattern: "/flights/{flightNumber}/book", handler: c.bookPage},
+		{method: http.MethodPost, pattern: "/flights/{flightNumber}/book", handler: c.book},
+		{method: http.MethodGet, pattern: "/flights/{flightNumber}/crew", handler: c.crewPage},
+		{method: http.MethodPost, pattern: "/flights/{flightNumber}/crew", handler: c.assignCrew},
+		{method: http.MethodPost, pattern: "/flights/{flightNumber}/crew/add", handler: c.addCrewMember},
+		{method: http.MethodPost, pattern: "/flights/{flightNumber}/crew/remove", handler: c.removeCrewMember},
+		{method: http.MethodGet, pattern: "/reservations", handler: c.findReservation},
+		{method: http.MethodGet, pattern: "/reservations/{reservationID}", handler: c.reservationPage},
+		{method: http.MethodPost, pattern: "/reservations/{reservationID}/check-in", handler: c.checkIn},
+		{method: http.MethodGet, pattern: "/reservations/{reservationID}/boarding-pass.pdf", handler: c.boardingPass},
+	}
+	return c, nil
+}
+
+// ServeHTTP serves a request: static files as they are, pages after checking the login and, for
+// forms, the CSRF token
+func (c *Console) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	header.Set("X-Frame-Options", "DENY")
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Referrer-Policy", "same-origin")
+	header.Set("Content-Security-Policy", "default-src 'self'; img-src 'self' data:; style-src 'self'; form-action 'self'; frame-ancestors 'none'")
+
+	if strings.HasPrefix(r.URL.Path, "/static/") {
+		c.static.ServeHTTP(w, r)
+		return
+	}
+
+	sess := c.sessions.get(r)
+	matched, params, pathFound := c.match(r)
+	if matched == nil {
+		status, message := http.StatusNotFound, "This page does not exist."
+		if pathFound {
+			status, message = http.StatusMethodNotAllowed, "This page does not accept the request."
+		}
+		c.renderError(w, sess, status, message)
+		return
+	}
+
+	if !matched.public && (sess == nil || sess.user == "") {
+		target := "/login"
+		if r.Method == http.MethodGet && r.URL.Path != "/" {
+			target += "?next=" + url.QueryEscape(r.URL.RequestURI())
+		}
+		http.Redirect(w, r, target, http.StatusSeeOther)
+		return
+	}
+
+	// Disabled accounts are logged out at their next request
+	ctx := context.WithValue(r.Context(), paramsKey{}, params)
+	if !matched.public {
+		user, err := c.auth.ActiveUser(sess.user)
+		if errors.Is(err, domain.ErrUnauthorized) {
+			c.sessions.end(w, sess)
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		if err != nil {
+			c.renderError(w, sess, http.StatusInternalServerError, err.Error())
+			return
+		}
+		ctx = context.WithValue(ctx, userKey{}, user)
+	}
+
+	if r.Method == http.MethodPost {
+		r.Body = http.MaxBytesReader(w, r.Body, maxFormBytes)
+		if err := r.ParseForm(); err != nil || sess == nil || !validCSRF(r, sess) {
+			c.renderError(w, sess, http.StatusForbidden, "The form has expired. Go back, reload the page and try again.")
+			return
+		}
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	} else {
+		c.mutex.RLock()
+		defer c.mutex.RUnlock()
+	}
+
+	matched.handler(w, r.WithContext(ctx), sess)
+}
+
+// match finds the route of a request; pathFound reports a path served for other methods
+func (c *Console) match(r *http.Request) (*route, map[string]string, bool) {
+	pathFound := false
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	for i := range c.routes {
+		candidate := &c.routes[i]
+		patternSegments := strings.Split(strings.Trim(candidate.pattern, "/"), "/")
+		if len(patternSegments) != len(segments) {
+			continue
+		}
+
+		params := make(map[string]string)
+		matches := true
+		for j, segment := range patternSegments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && segments[j] != "" {
+				params[segment[1:len(segment)-1]] = segments[j]
+			} else if segment != segments[j] {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		if candidate.method != r.Method {
+			pathFound = true
+			continue
+		}
+		return candidate, params, true
+	}
+	return nil, nil, pathFound
+}
+
+// param returns a path parameter of a request
+func param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return params[name]
+}
+
+// flightsFor returns the flight service acting for the user of a request
+func (c *Console) flightsFor(r *http.Request) ports.FlightService {
+	user, _ := r.Context().Value(userKey{}).(*domain.User)
+	return c.auth.GuardFlights(c.services(user.Username).Flights, user)
+}
+
+// reservationsFor returns the reservation service acting for the user of a request
+func (c *Console) reservationsFor(r *http.Request) ports.ReservationService {
+	user, _ := r.Context().Value(userKey{}).(*domain.User)
+	return c.auth.GuardReservations(c.services(user.Username).Reservations, user)
+}
+
+// pageData is what every page template is executed with
+type pageData struct {
+	Title     string
+	User      string
+	CSRF      string
+	Flash     string
+	FlashErr  bool
+	CSRFField string
+	Data      interface{}
+}
+
+// render renders a page inside the layout
+func (c *Console) render(w http.ResponseWriter, sess *session, status int, page, title string, data interface{}) {
+	view := pageData{Title: title, CSRFField: csrfField, Data: data}
+	if sess != nil {
+		view.User, view.CSRF = sess.user, sess.csrf
+		view.Flash, view.FlashErr = c.sessions.takeFlash(sess)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := c.templates[page].ExecuteTemplate(w, "layout", view); err != nil && c.logger != nil {
+		c.logger.Printf("error rendering the %s page: %v", page, err)
+	}
+}
+
+// renderError renders the error page
+func (c *Console) renderError(w http.ResponseWriter, sess *session, status int, message string) {
+	c.render(w, sess, status, "error", http.StatusText(status), message)
+}
+
+// redirect redirects after a form, with a message for the next page
+func (c *Console) redirect(w http.ResponseWriter, r *http.Request, sess *session, target, message string, isError bool) {
+	if message != "" {
+		c.sessions.setFlash(sess, message, isError)
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// templateFuncs are the helpers available to the templates
+var templateFuncs = template.FuncMap{
+	"datetime": func(t time.Time) string {
+		return t.Format("02/01/2006 15:04")
+	},
+	"amount": func(amount int64) string {
+		return domain.FormatAmount(amount, flight.DefaultFareRules.Currency)
+	},
+	"attributes": domain.FormatSeatAttributes,
+}
@@ -0,0 +1,433 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"golang-airplane/internal/components/seatmap"
+	"golang-airplane/internal/core/domain"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// crewRows is the number of crew rows of the crew assignment form
+const crewRows = 8
+
+// crewPositions are the positions offered on the crew forms
+var crewPositions = []string{domain.PositionPilot, domain.PositionAttendant, domain.PositionGroundStaff}
+
+// serviceError renders the error page for an error of a service that prevents showing a page
+func (c *Console) serviceError(w http.ResponseWriter, sess *session, err error) {
+	if errors.Is(err, domain.ErrNotFound) {
+		c.renderError(w, sess, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, domain.ErrForbidden) {
+		c.renderError(w, sess, http.StatusForbidden, err.Error())
+		return
+	}
+	c.renderError(w, sess, http.StatusInternalServerError, err.Error())
+}
+
+// loginPage shows the login form, starting an anonymous session to carry its CSRF token
+func (c *Console) loginPage(w http.ResponseWriter, r *http.Request, sess *session) {
+	if sess != nil && sess.user != "" {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	if sess == nil {
+		var err error
+		if sess, err = c.sessions.start(w, r, ""); err != nil {
+			c.renderError(w, nil, http.StatusInternalServerError, "Could not start a session.")
+			return
+		}
+	}
+	c.render(w, sess, http.StatusOK, "login", "Log in", map[string]string{"Next": r.URL.Query().Get("next")})
+}
+
+// login checks the credentials and replaces the anonymous session with one of the user, so a
+// session ID set before login is never logged in
+func (c *Console) login(w http.ResponseWriter, r *http.Request, sess *session) {
+	username := strings.TrimSpace(r.PostFormValue("username"))
+	next := r.PostFormValue("next")
+
+	user, err := c.auth.Authenticate(username, r.PostFormValue("password"))
+	if err != nil && !errors.Is(err, domain.ErrUnauthorized) {
+		c.renderError(w, sess, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Printf("failed console login for %q from %s", username, r.RemoteAddr)
+		}
+		c.sessions.setFlash(sess, "Wrong username or password.", true)
+		c.render(w, sess, http.StatusUnauthorized, "login", "Log in", map[string]string{"Next": next, "Username": username})
+		return
+	}
+
+	c.sessions.end(w, sess)
+	if _, err := c.sessions.start(w, r, user.Username); err != nil {
+		c.renderError(w, nil, http.StatusInternalServerError, "Could not start a session.")
+		return
+	}
+
+	// Only go on to pages of the console
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		next = "/"
+	}
+	http.Redirect(w, r, next, http.StatusSeeOther)
+}
+
+// logout ends the session
+func (c *Console) logout(w http.ResponseWriter, r *http.Request, sess *session) {
+	c.sessions.end(w, sess)
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// board shows the flight board, filtered on flight number, city or status
+func (c *Console) board(w http.ResponseWriter, r *http.Request, sess *session) {
+	flights, err := c.flightsFor(r).ListAllFlights()
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query != "" {
+		filter := strings.ToLower(query)
+		matching := flights[:0:0]
+		for _, f := range flights {
+			if strings.Contains(strings.ToLower(f.FlightNumber+" "+f.DepartureCity+" "+f.DestinationCity+" "+f.CurrentStatus()), filter) {
+				matching = append(matching, f)
+			}
+		}
+		flights = matching
+	}
+
+	c.render(w, sess, http.StatusOK, "board", "Flight board", map[string]interface{}{
+		"Flights": flights,
+		"Query":   query,
+	})
+}
+
+// findReservation opens the reservation searched from the board
+func (c *Console) findReservation(w http.ResponseWriter, r *http.Request, sess *session) {
+	id := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("id")))
+	if id == "" {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/reservations/"+url.PathEscape(id), http.StatusSeeOther)
+}
+
+// seatMapSVG draws the seat map of a flight as seen by the session, for inlining in a page
+func (c *Console) seatMapSVG(flightNumber string, sess *session, selected string) (template.HTML, error) {
+	seatMap, err := c.seats.SeatMap(flightNumber, sess.booking, selected)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := seatmap.WriteSVG(&buf, seatMap); err != nil {
+		return "", err
+	}
+	// The SVG writer escapes every text it draws
+	return template.HTML(buf.String()), nil
+}
+
+// flightPage shows a flight with its seat map, crew and reservations
+func (c *Console) flightPage(w http.ResponseWriter, r *http.Request, sess *session) {
+	f, err := c.flightsFor(r).GetFlight(param(r, "flightNumber"))
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+
+	reservations, err := c.reservationsFor(r).GetReservationsForFlight(f.FlightNumber)
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+	seatMap, err := c.seatMapSVG(f.FlightNumber, sess, "")
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+
+	c.render(w, sess, http.StatusOK, "flight", "Flight "+f.FlightNumber, map[string]interface{}{
+		"Flight":       f,
+		"Reservations": reservations,
+		"SeatMap":      seatMap,
+	})
+}
+
+// bookingForm is the booking form with the errors of its fields
+type bookingForm struct {
+	Name               string
+	Address            string
+	PhoneNumber        string
+	IdentityCardNumber string
+	Class              string
+	Errors             map[string]string
+}
+
+// validate checks the fields of the booking form
+func (form *bookingForm) validate() (int64, int64, bool) {
+	form.Errors = make(map[string]string)
+	if form.Name == "" {
+		form.Errors["Name"] = "Enter the name of the passenger."
+	}
+	if form.Address == "" {
+		form.Errors["Address"] = "Enter the address of the passenger."
+	}
+	phone, err := strconv.ParseInt(form.PhoneNumber, 10, 64)
+	if err != nil || phone <= 0 {
+		form.Errors["PhoneNumber"] = "Enter the phone number as digits only."
+	}
+	identityCard, err := strconv.ParseInt(form.IdentityCardNumber, 10, 64)
+	if err != nil || identityCard <= 0 {
+		form.Errors["IdentityCardNumber"] = "Enter the identity card number as digits only."
+	}
+	return phone, identityCard, len(form.Errors) == 0
+}
+
+// bookPage shows the booking form of a flight
+func (c *Console) bookPage(w http.ResponseWriter, r *http.Request, sess *session) {
+	c.renderBooking(w, r, sess, http.StatusOK, &bookingForm{Class: domain.ClassEconomy}, "")
+}
+
+// renderBooking renders the booking form of the flight of a request
+func (c *Console) renderBooking(w http.ResponseWriter, r *http.Request, sess *session, status int, form *bookingForm, failure string) {
+	f, err := c.flightsFor(r).GetFlight(param(r, "flightNumber"))
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+	bookable, err := c.reservationsFor(r).BookableSeats(f.FlightNumber)
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+
+	c.render(w, sess, status, "book", "Book flight "+f.FlightNumber, map[string]interface{}{
+		"Flight":   f,
+		"Bookable": bookable,
+		"Form":     form,
+		"Classes":  []string{domain.ClassEconomy, domain.ClassBusiness},
+		"Failure":  failure,
+	})
+}
+
+// book books a flight
+func (c *Console) book(w http.ResponseWriter, r *http.Request, sess *session) {
+	form := &bookingForm{
+		Name:               strings.TrimSpace(r.PostFormValue("name")),
+		Address:            strings.TrimSpace(r.PostFormValue("address")),
+		PhoneNumber:        strings.TrimSpace(r.PostFormValue("phone_number")),
+		IdentityCardNumber: strings.TrimSpace(r.PostFormValue("identity_card_number")),
+		Class:              r.PostFormValue("class"),
+	}
+	phone, identityCard, valid := form.validate()
+	if !valid {
+		c.renderBooking(w, r, sess, http.StatusUnprocessableEntity, form, "")
+		return
+	}
+
+	reservation, err := c.reservationsFor(r).BookFlight(form.Name, form.Address, phone, identityCard, param(r, "flightNumber"), form.Class, sess.booking)
+	if err != nil {
+		c.renderBooking(w, r, sess, http.StatusUnprocessableEntity, form, err.Error())
+		return
+	}
+
+	c.redirect(w, r, sess, "/reservations/"+reservation.ReservationID,
+		fmt.Sprintf("Booked reservation %s for %s.", reservation.ReservationID, reservation.Name), false)
+}
+
+// seatOption is a seat offered at check-in
+type seatOption struct {
+	Number     string
+	Attributes []string
+	Charge     int64
+}
+
+// reservationPage shows a reservation with its check-in form or its boarding pass
+func (c *Console) reservationPage(w http.ResponseWriter, r *http.Request, sess *session) {
+	reservation, err := c.reservationsFor(r).GetReservation(param(r, "reservationID"))
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+	f, err := c.flightsFor(r).GetFlight(reservation.ReservationFlightNumber)
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Reservation": reservation,
+		"Flight":      f,
+	}
+
+	if reservation.IsConfirmed() && !reservation.CheckedIn {
+		seatMap, err := c.seats.SeatMap(f.FlightNumber, sess.booking, "")
+		if err != nil {
+			c.serviceError(w, sess, err)
+			return
+		}
+
+		// Free seats of the passenger's cabin, with what choosing them costs the passenger
+		var options []seatOption
+		for _, row := range seatMap.Rows {
+			for _, seat := range row.Seats {
+				if seat.State != domain.SeatStateAvailable || !f.InCabinOf(seat.Number, reservation) {
+					continue
+				}
+				option := seatOption{Number: seat.Number, Attributes: seat.Attributes}
+				if seat.Price > 0 {
+					if option.Charge, err = c.seats.SeatCharge(reservation.ReservationID, seat.Number); err != nil {
+						c.serviceError(w, sess, err)
+						return
+					}
+				}
+				options = append(options, option)
+			}
+		}
+		data["Seats"] = options
+
+		svg, err := c.seatMapSVG(f.FlightNumber, sess, "")
+		if err != nil {
+			c.serviceError(w, sess, err)
+			return
+		}
+		data["SeatMap"] = svg
+	}
+
+	c.render(w, sess, http.StatusOK, "reservation", "Reservation "+reservation.ReservationID, data)
+}
+
+// checkIn checks a reservation in on the chosen seat, or an assigned one
+func (c *Console) checkIn(w http.ResponseWriter, r *http.Request, sess *session) {
+	reservationID := param(r, "reservationID")
+	target := "/reservations/" + url.PathEscape(reservationID)
+
+	seat := strings.ToUpper(strings.TrimSpace(r.PostFormValue("seat")))
+	if err := c.reservationsFor(r).CheckIn(reservationID, seat, sess.booking); err != nil {
+		c.redirect(w, r, sess, target, "Check-in failed: "+err.Error(), true)
+		return
+	}
+
+	reservation, err := c.reservationsFor(r).GetReservation(reservationID)
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+	c.redirect(w, r, sess, target, fmt.Sprintf("Checked in %s on seat %s.", reservation.Name, reservation.SeatLocation), false)
+}
+
+// boardingPass sends the PDF boarding pass of a checked-in reservation, to print from the browser
+func (c *Console) boardingPass(w http.ResponseWriter, r *http.Request, sess *session) {
+	reservationID := param(r, "reservationID")
+
+	var buf bytes.Buffer
+	if err := c.documents.BoardingPass(&buf, reservationID, r.URL.Query().Get("brand")); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.renderError(w, sess, http.StatusNotFound, err.Error())
+			return
+		}
+		c.renderError(w, sess, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", reservationID+"-boarding-pass.pdf"))
+	w.Write(buf.Bytes())
+}
+
+// crewPage shows the crew of a flight with the forms changing it
+func (c *Console) crewPage(w http.ResponseWriter, r *http.Request, sess *session) {
+	c.renderCrew(w, r, sess, http.StatusOK, nil, "")
+}
+
+// renderCrew renders the crew page of the flight of a request; rows refills the assignment form
+func (c *Console) renderCrew(w http.ResponseWriter, r *http.Request, sess *session, status int, rows []domain.Crew, failure string) {
+	f, err := c.flightsFor(r).GetFlight(param(r, "flightNumber"))
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+	changes, err := c.flightsFor(r).GetCrewChanges(f.FlightNumber)
+	if err != nil {
+		c.serviceError(w, sess, err)
+		return
+	}
+
+	for len(rows) < crewRows {
+		rows = append(rows, domain.Crew{})
+	}
+
+	c.render(w, sess, status, "crew", "Crew of flight "+f.FlightNumber, map[string]interface{}{
+		"Flight":    f,
+		"Changes":   changes,
+		"Rows":      rows,
+		"Positions": crewPositions,
+		"Failure":   failure,
+	})
+}
+
+// assignCrew assigns the crew of a flight from the rows of the form that have a name
+func (c *Console) assignCrew(w http.ResponseWriter, r *http.Request, sess *session) {
+	names, positions := r.PostForm["name"], r.PostForm["position"]
+
+	var crew []domain.Crew
+	for i, name := range names {
+		if name = strings.TrimSpace(name); name != "" && i < len(positions) {
+			crew = append(crew, domain.Crew{Name: name, Position: positions[i]})
+		}
+	}
+
+	if err := domain.ValidateCrew(crew); err != nil {
+		c.renderCrew(w, r, sess, http.StatusUnprocessableEntity, crew, err.Error())
+		return
+	}
+
+	flightNumber := param(r, "flightNumber")
+	if err := c.flightsFor(r).AssignCrew(flightNumber, crew); err != nil {
+		c.renderCrew(w, r, sess, http.StatusUnprocessableEntity, crew, err.Error())
+		return
+	}
+	c.redirect(w, r, sess, "/flights/"+url.PathEscape(flightNumber)+"/crew", "Crew assigned.", false)
+}
+
+// addCrewMember adds a crew member to a flight, recording the user as the author of the change
+func (c *Console) addCrewMember(w http.ResponseWriter, r *http.Request, sess *session) {
+	flightNumber := param(r, "flightNumber")
+	target := "/flights/" + url.PathEscape(flightNumber) + "/crew"
+
+	member := domain.Crew{Name: strings.TrimSpace(r.PostFormValue("name")), Position: r.PostFormValue("position")}
+	if member.Name == "" {
+		c.redirect(w, r, sess, target, "Enter the name of the crew member.", true)
+		return
+	}
+
+	if err := c.flightsFor(r).AddCrewMember(flightNumber, member, sess.user); err != nil {
+		c.redirect(w, r, sess, target, err.Error(), true)
+		return
+	}
+	c.redirect(w, r, sess, target, fmt.Sprintf("Added %s as %s.", member.Name, member.Position), false)
+}
+
+// removeCrewMember removes a crew member from a flight, recording the user as the author of the change
+func (c *Console) removeCrewMember(w http.ResponseWriter, r *http.Request, sess *session) {
+	flightNumber := param(r, "flightNumber")
+	target := "/flights/" + url.PathEscape(flightNumber) + "/crew"
+
+	member := r.PostFormValue("member")
+	if err := c.flightsFor(r).RemoveCrewMember(flightNumber, member, sess.user); err != nil {
+		c.redirect(w, r, sess, target, err.Error(), true)
+		return
+	}
+	c.redirect(w, r, sess, target, fmt.Sprintf("Removed %s.", member), false)
+}
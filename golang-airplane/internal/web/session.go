@@ -0,0 +1,146 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Session cookie settings
+const (
+	sessionCookie = "console_session"
+	sessionTTL    = 8 * time.Hour // Sessions end after this long without a request
+)
+
+// csrfField is the form field carrying the CSRF token of the session
+const csrfField = "csrf_token"
+
+// session is the state of a browser; it has no user before login
+type session struct {
+	id       string
+	user     string
+	csrf     string // Token every form posted in the session must carry
+	booking  string // Booking session tying the seat holds of the browser together
+	flash    string // Message shown on the next page
+	flashErr bool
+	expires  time.Time
+}
+
+// sessionStore keeps the sessions in memory, so a restart logs everyone out
+type sessionStore struct {
+	sessions map[string]*session
+	mutex    sync.Mutex
+}
+
+// newSessionStore creates an empty session store
+func newSessionStore() *sessionStore {
+	return &sessionStore{sessions: make(map[string]*session)}
+}
+
+// randomToken returns a random URL-safe token
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// get returns the session of a request, or nil when it has none or it expired, and extends it
+func (s *sessionStore) get(r *http.Request) *session {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sess, ok := s.sessions[cookie.Value]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(sess.expires) {
+		delete(s.sessions, sess.id)
+		return nil
+	}
+	sess.expires = time.Now().Add(sessionTTL)
+	return sess
+}
+
+// start creates a session for a user, which is empty before login, and sets its cookie
+func (s *sessionStore) start(w http.ResponseWriter, r *http.Request, user string) (*session, error) {
+	id, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	csrf, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	booking, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &session{id: id, user: user, csrf: csrf, booking: booking, expires: time.Now().Add(sessionTTL)}
+
+	s.mutex.Lock()
+	s.sessions[id] = sess
+	s.removeExpired()
+	s.mutex.Unlock()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    id,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return sess, nil
+}
+
+// end removes a session and clears its cookie
+func (s *sessionStore) end(w http.ResponseWriter, sess *session) {
+	s.mutex.Lock()
+	delete(s.sessions, sess.id)
+	s.mutex.Unlock()
+
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: "", Path: "/", MaxAge: -1, HttpOnly: true})
+}
+
+// removeExpired drops expired sessions; the caller holds the lock
+func (s *sessionStore) removeExpired() {
+	now := time.Now()
+	for id, sess := range s.sessions {
+		if now.After(sess.expires) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// setFlash stores a message for the next page of a session
+func (s *sessionStore) setFlash(sess *session, message string, isError bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	sess.flash, sess.flashErr = message, isError
+}
+
+// takeFlash returns and clears the message of a session
+func (s *sessionStore) takeFlash(sess *session) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	message, isError := sess.flash, sess.flashErr
+	sess.flash, sess.flashErr = "", false
+	return message, isError
+}
+
+// validCSRF reports whether a posted form carries the CSRF token of the session
+func validCSRF(r *http.Request, sess *session) bool {
+	token := r.PostFormValue(csrfField)
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(sess.csrf)) == 1
+}
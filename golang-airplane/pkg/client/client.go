@@ -0,0 +1,353 @@
+// Package client calls the airline management HTTP API from other Go services
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SessionHeader carries the booking session token that ties seat holds to a client
+const SessionHeader = "X-Session-Token"
+
+// Errors matched by the API errors of the same kind, for use with errors.Is
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+)
+
+// Client calls the API at a base URL as a user
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	username     string
+	password     string
+	SessionToken string // Sent with changes so seat holds are tied to this client; may be empty
+}
+
+// NewClient creates a client of the API at a base URL such as http://localhost:8080, calling it as the user with a
+// username and password, or anonymously, which only lets it check the health, for an empty username; a nil HTTP
+// client uses http.DefaultClient
+func NewClient(baseURL, username, password string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		username:   username,
+		password:   password,
+	}
+}
+
+// APIError is returned for error responses of the API
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+// Error returns the message of the error response
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (%d %s)", e.Message, e.StatusCode, e.Code)
+}
+
+// Is lets errors.Is match API errors against ErrNotFound, ErrAlreadyExists, ErrUnauthorized and ErrForbidden
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrAlreadyExists:
+		return e.Code == "already_exists"
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// Pages of the list endpoints
+type (
+	// FlightPage is a page of flights
+	FlightPage struct {
+		Items   []*Flight `json:"items"`
+		Page    int       `json:"page"`
+		PerPage int       `json:"per_page"`
+		Total   int       `json:"total"`
+	}
+
+	// ReservationPage is a page of reservations
+	ReservationPage struct {
+		Items   []*Reservation `json:"items"`
+		Page    int            `json:"page"`
+		PerPage int            `json:"per_page"`
+		Total   int            `json:"total"`
+	}
+
+	// CrewPage is a page of crew members of a flight
+	CrewPage struct {
+		Items   []Crew `json:"items"`
+		Page    int    `json:"page"`
+		PerPage int    `json:"per_page"`
+		Total   int    `json:"total"`
+	}
+
+	// CrewChangePage is a page of crew changes of a flight
+	CrewChangePage struct {
+		Items   []*CrewChange `json:"items"`
+		Page    int           `json:"page"`
+		PerPage int           `json:"per_page"`
+		Total   int           `json:"total"`
+	}
+
+	// AirplanePage is a page of airplanes
+	AirplanePage struct {
+		Items   []Airplane `json:"items"`
+		Page    int        `json:"page"`
+		PerPage int        `json:"per_page"`
+		Total   int        `json:"total"`
+	}
+)
+
+// Health checks that the API is up
+func (c *Client) Health(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/health", nil, nil, nil)
+}
+
+// ListFlights lists flights, newest departure first; page and perPage may be 0 for the defaults
+func (c *Client) ListFlights(ctx context.Context, page, perPage int) (*FlightPage, error) {
+	var result FlightPage
+	if err := c.do(ctx, http.MethodGet, "/flights", paging(nil, page, perPage), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchFlights lists the flights departing from or arriving at a location on a date
+func (c *Client) SearchFlights(ctx context.Context, location string, date time.Time, page, perPage int) (*FlightPage, error) {
+	query := url.Values{"location": {location}, "date": {date.Format("2006-01-02")}}
+	var result FlightPage
+	if err := c.do(ctx, http.MethodGet, "/flights", paging(query, page, perPage), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateFlight creates a flight
+func (c *Client) CreateFlight(ctx context.Context, req CreateFlightRequest) (*Flight, error) {
+	var flight Flight
+	if err := c.do(ctx, http.MethodPost, "/flights", nil, req, &flight); err != nil {
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// GetFlight returns a flight
+func (c *Client) GetFlight(ctx context.Context, flightNumber string) (*Flight, error) {
+	var flight Flight
+	if err := c.do(ctx, http.MethodGet, "/flights/"+url.PathEscape(flightNumber), nil, nil, &flight); err != nil {
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// ListCrew lists the crew of a flight
+func (c *Client) ListCrew(ctx context.Context, flightNumber string, page, perPage int) (*CrewPage, error) {
+	var result CrewPage
+	if err := c.do(ctx, http.MethodGet, "/flights/"+url.PathEscape(flightNumber)+"/crew", paging(nil, page, perPage), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AssignCrew assigns the crew of a flight without crew
+func (c *Client) AssignCrew(ctx context.Context, flightNumber string, crew []Crew) (*Flight, error) {
+	var flight Flight
+	if err := c.do(ctx, http.MethodPut, "/flights/"+url.PathEscape(flightNumber)+"/crew", nil,
+		assignCrewRequest{Crew: crew}, &flight); err != nil {
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// AddCrewMember adds a crew member to a flight, recorded as changed by the client's user
+func (c *Client) AddCrewMember(ctx context.Context, flightNumber string, member Crew) (*Flight, error) {
+	var flight Flight
+	if err := c.do(ctx, http.MethodPost, "/flights/"+url.PathEscape(flightNumber)+"/crew", nil,
+		crewMemberRequest{Member: member}, &flight); err != nil {
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// RemoveCrewMember removes a crew member, by registry ID or name, from a flight, recorded as changed by the client's
+// user
+func (c *Client) RemoveCrewMember(ctx context.Context, flightNumber, idOrName string) (*Flight, error) {
+	var flight Flight
+	if err := c.do(ctx, http.MethodDelete, "/flights/"+url.PathEscape(flightNumber)+"/crew/"+url.PathEscape(idOrName),
+		nil, nil, &flight); err != nil {
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// ListCrewChanges lists the crew change audit trail of a flight
+func (c *Client) ListCrewChanges(ctx context.Context, flightNumber string, page, perPage int) (*CrewChangePage, error) {
+	var result CrewChangePage
+	if err := c.do(ctx, http.MethodGet, "/flights/"+url.PathEscape(flightNumber)+"/crew-changes", paging(nil, page, perPage), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListReservations lists the reservations of a flight
+func (c *Client) ListReservations(ctx context.Context, flightNumber string, page, perPage int) (*ReservationPage, error) {
+	var result ReservationPage
+	if err := c.do(ctx, http.MethodGet, "/flights/"+url.PathEscape(flightNumber)+"/reservations", paging(nil, page, perPage), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BookFlight books a flight
+func (c *Client) BookFlight(ctx context.Context, req BookFlightRequest) (*Reservation, error) {
+	var reservation Reservation
+	if err := c.do(ctx, http.MethodPost, "/reservations", nil, req, &reservation); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// GetReservation returns a reservation
+func (c *Client) GetReservation(ctx context.Context, reservationID string) (*Reservation, error) {
+	var reservation Reservation
+	if err := c.do(ctx, http.MethodGet, "/reservations/"+url.PathEscape(reservationID), nil, nil, &reservation); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// CheckIn checks a reservation in on a seat, or on the seat that suits the passenger best when seat is empty
+func (c *Client) CheckIn(ctx context.Context, reservationID, seat string) (*Reservation, error) {
+	var reservation Reservation
+	if err := c.do(ctx, http.MethodPost, "/reservations/"+url.PathEscape(reservationID)+"/check-in", nil,
+		checkInRequest{Seat: seat}, &reservation); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// CancelReservation cancels a reservation
+func (c *Client) CancelReservation(ctx context.Context, reservationID string) (*Reservation, error) {
+	var reservation Reservation
+	if err := c.do(ctx, http.MethodPost, "/reservations/"+url.PathEscape(reservationID)+"/cancel", nil, nil, &reservation); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// ListAirplanes lists the airplanes
+func (c *Client) ListAirplanes(ctx context.Context, page, perPage int) (*AirplanePage, error) {
+	var result AirplanePage
+	if err := c.do(ctx, http.MethodGet, "/airplanes", paging(nil, page, perPage), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateAirplane registers an airplane
+func (c *Client) CreateAirplane(ctx context.Context, req CreateAirplaneRequest) (*Airplane, error) {
+	var airplane Airplane
+	if err := c.do(ctx, http.MethodPost, "/airplanes", nil, req, &airplane); err != nil {
+		return nil, err
+	}
+	return &airplane, nil
+}
+
+// GetAirplane returns an airplane
+func (c *Client) GetAirplane(ctx context.Context, airplaneID string) (*Airplane, error) {
+	var airplane Airplane
+	if err := c.do(ctx, http.MethodGet, "/airplanes/"+url.PathEscape(airplaneID), nil, nil, &airplane); err != nil {
+		return nil, err
+	}
+	return &airplane, nil
+}
+
+// paging adds the page and per_page query parameters when they are set
+func paging(query url.Values, page, perPage int) url.Values {
+	if query == nil {
+		query = url.Values{}
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		query.Set("per_page", strconv.Itoa(perPage))
+	}
+	return query
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into result
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.SessionToken != "" {
+		req.Header.Set(SessionHeader, c.SessionToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var errorBody errorBody
+		if err := json.NewDecoder(resp.Body).Decode(&errorBody); err != nil || errorBody.Error.Message == "" {
+			return &APIError{StatusCode: resp.StatusCode, Code: "unknown", Message: resp.Status}
+		}
+		return &APIError{StatusCode: resp.StatusCode, Code: errorBody.Error.Code, Message: errorBody.Error.Message}
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
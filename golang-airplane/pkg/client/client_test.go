@@ -0,0 +1,340 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang-airplane/internal/api/rest"
+	"golang-airplane/internal/components/airplane"
+	"golang-airplane/internal/components/auth"
+	"golang-airplane/internal/components/flight"
+	"golang-airplane/internal/core/domain"
+	"golang-airplane/internal/core/ports"
+	"golang-airplane/internal/storage/json"
+	"golang-airplane/pkg/client"
+)
+
+// testPassword is the password of every test user account
+const testPassword = "test password"
+
+// newTestServer serves the HTTP API over empty JSON storage, with an account named after each role, and returns
+// its URL
+func newTestServer(t *testing.T) string {
+	t.Helper()
+
+	storage := json.NewStorage(t.TempDir())
+	flightRepo := json.NewFlightRepository(storage)
+	reservationRepo := json.NewReservationRepository(storage)
+	airplaneRepo := json.NewAirplaneRepository(storage)
+	holds := flight.NewHoldService(flightRepo, json.NewSeatHoldRepository(storage), flight.DefaultHoldTTL)
+
+	flights := flight.NewService(flightRepo, reservationRepo, json.NewCrewChangeRepository(storage), airplaneRepo, holds, nil)
+	overbooking := flight.NewOverbookingService(flightRepo, reservationRepo, json.NewOverbookingPolicyRepository(storage))
+	checkInRules := flight.NewCheckInRules(json.NewCheckInPolicyRepository(storage))
+	reservations := flight.NewReservationService(flightRepo, reservationRepo, overbooking, holds, checkInRules, nil)
+	airplanes := airplane.NewAirplaneService(airplaneRepo, flightRepo)
+
+	access := auth.NewService(json.NewUserRepository(storage), json.NewActivityRepository(storage))
+	admin, err := access.CreateUser(nil, domain.RoleAdmin, testPassword, []string{domain.RoleAdmin})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	for _, role := range []string{domain.RoleScheduler, domain.RoleSalesAgent, domain.RoleCrewPlanner} {
+		if _, err := access.CreateUser(admin, role, testPassword, []string{role}); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+
+	servicesAs := func(actor string) ports.Services {
+		return ports.Services{Flights: flights, Reservations: reservations, Airplanes: airplanes}
+	}
+	server := httptest.NewServer(rest.NewHandler(servicesAs, access))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// newTestClient serves the HTTP API over empty JSON storage and returns a client of it acting as an administrator
+func newTestClient(t *testing.T) *client.Client {
+	t.Helper()
+	return client.NewClient(newTestServer(t), domain.RoleAdmin, testPassword, nil)
+}
+
+// createFlight creates a flight departing tomorrow
+func createFlight(t *testing.T, c *client.Client, flightNumber string) *client.Flight {
+	t.Helper()
+
+	departure := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	created, err := c.CreateFlight(context.Background(), client.CreateFlightRequest{
+		FlightNumber:    flightNumber,
+		DepartureCity:   "Hanoi",
+		DestinationCity: "Saigon",
+		DepartureTime:   departure,
+		ArrivalTime:     departure.Add(2 * time.Hour),
+		Capacity:        40,
+	})
+	if err != nil {
+		t.Fatalf("CreateFlight: %v", err)
+	}
+	return created
+}
+
+func TestFlights(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	if err := c.Health(ctx); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+
+	created := createFlight(t, c, "F1001")
+	if created.FlightNumber != "F1001" || created.FlightCapacity != 40 || created.AvailableSeat != 40 {
+		t.Errorf("CreateFlight returned %+v", created)
+	}
+	if len(created.SeatList) != 40 {
+		t.Errorf("CreateFlight returned %d seats, want 40", len(created.SeatList))
+	}
+
+	got, err := c.GetFlight(ctx, "F1001")
+	if err != nil {
+		t.Fatalf("GetFlight: %v", err)
+	}
+	if got.DepartureCity != "Hanoi" || !got.DepartureTime.Equal(created.DepartureTime) {
+		t.Errorf("GetFlight returned %+v, want the created flight", got)
+	}
+
+	createFlight(t, c, "F1002")
+	page, err := c.ListFlights(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("ListFlights: %v", err)
+	}
+	if page.Total != 2 || len(page.Items) != 1 || page.PerPage != 1 {
+		t.Errorf("ListFlights returned %d of %d flights, per page %d", len(page.Items), page.Total, page.PerPage)
+	}
+
+	found, err := c.SearchFlights(ctx, "Saigon", created.DepartureTime, 0, 0)
+	if err != nil {
+		t.Fatalf("SearchFlights: %v", err)
+	}
+	if found.Total != 2 {
+		t.Errorf("SearchFlights found %d flights, want 2", found.Total)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	createFlight(t, c, "F1001")
+
+	_, err := c.GetFlight(ctx, "F9999")
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("GetFlight of an unknown flight returned %v, want ErrNotFound", err)
+	}
+
+	_, err = c.CreateFlight(ctx, client.CreateFlightRequest{
+		FlightNumber:    "F1001",
+		DepartureCity:   "Hanoi",
+		DestinationCity: "Hue",
+		DepartureTime:   time.Now().Add(24 * time.Hour),
+		ArrivalTime:     time.Now().Add(26 * time.Hour),
+		Capacity:        40,
+	})
+	if !errors.Is(err, client.ErrAlreadyExists) {
+		t.Errorf("CreateFlight of an existing flight returned %v, want ErrAlreadyExists", err)
+	}
+
+	_, err = c.CreateFlight(ctx, client.CreateFlightRequest{FlightNumber: "bad"})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("CreateFlight of an invalid flight returned %v, want a 400 APIError", err)
+	}
+}
+
+func TestReservations(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	createFlight(t, c, "F1001")
+
+	booked, err := c.BookFlight(ctx, client.BookFlightRequest{
+		Name:               "Nguyen Van An",
+		Address:            "Hanoi",
+		PhoneNumber:        912345678,
+		IdentityCardNumber: 123456789,
+		FlightNumber:       "F1001",
+		Class:              client.ClassBusiness,
+	})
+	if err != nil {
+		t.Fatalf("BookFlight: %v", err)
+	}
+	if booked.ReservationID == "" || booked.ReservationFlightNumber != "F1001" || booked.Class != client.ClassBusiness {
+		t.Errorf("BookFlight returned %+v", booked)
+	}
+
+	got, err := c.GetReservation(ctx, booked.ReservationID)
+	if err != nil {
+		t.Fatalf("GetReservation: %v", err)
+	}
+	if got.Name != "Nguyen Van An" || got.IdentityCardNumber != 123456789 {
+		t.Errorf("GetReservation returned %+v, want the booked reservation", got)
+	}
+
+	page, err := c.ListReservations(ctx, "F1001", 0, 0)
+	if err != nil {
+		t.Fatalf("ListReservations: %v", err)
+	}
+	if page.Total != 1 || page.Items[0].ReservationID != booked.ReservationID {
+		t.Errorf("ListReservations returned %+v", page.Items)
+	}
+
+	flight, err := c.GetFlight(ctx, "F1001")
+	if err != nil {
+		t.Fatalf("GetFlight: %v", err)
+	}
+	if flight.AvailableSeat != 39 {
+		t.Errorf("flight has %d available seats after a booking, want 39", flight.AvailableSeat)
+	}
+
+	cancelled, err := c.CancelReservation(ctx, booked.ReservationID)
+	if err != nil {
+		t.Fatalf("CancelReservation: %v", err)
+	}
+	if cancelled.Status != "cancelled" {
+		t.Errorf("CancelReservation left status %q", cancelled.Status)
+	}
+}
+
+func TestCrew(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	createFlight(t, c, "F1001")
+
+	crew := []client.Crew{
+		{Name: "Tran Minh", Position: client.PositionPilot},
+		{Name: "Le Hoa", Position: client.PositionAttendant},
+		{Name: "Pham Nam", Position: client.PositionGroundStaff},
+	}
+	if _, err := c.AssignCrew(ctx, "F1001", crew); err != nil {
+		t.Fatalf("AssignCrew: %v", err)
+	}
+
+	flight, err := c.AddCrewMember(ctx, "F1001", client.Crew{Name: "Vo Lan", Position: client.PositionAttendant})
+	if err != nil {
+		t.Fatalf("AddCrewMember: %v", err)
+	}
+	if len(flight.CrewMembers) != 4 {
+		t.Errorf("flight has %d crew members after adding one, want 4", len(flight.CrewMembers))
+	}
+
+	if _, err := c.RemoveCrewMember(ctx, "F1001", "Le Hoa"); err != nil {
+		t.Fatalf("RemoveCrewMember: %v", err)
+	}
+
+	members, err := c.ListCrew(ctx, "F1001", 0, 0)
+	if err != nil {
+		t.Fatalf("ListCrew: %v", err)
+	}
+	if members.Total != 3 {
+		t.Errorf("ListCrew returned %d members, want 3", members.Total)
+	}
+
+	changes, err := c.ListCrewChanges(ctx, "F1001", 0, 0)
+	if err != nil {
+		t.Fatalf("ListCrewChanges: %v", err)
+	}
+	if changes.Total != 2 || changes.Items[0].Added == nil || changes.Items[0].Added.Name != "Vo Lan" {
+		t.Errorf("ListCrewChanges returned %+v", changes.Items)
+	}
+	for _, change := range changes.Items {
+		if change.ChangedBy != domain.RoleAdmin {
+			t.Errorf("crew change recorded as made by %q, want the authenticated user", change.ChangedBy)
+		}
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	url := newTestServer(t)
+	ctx := context.Background()
+
+	anonymous := client.NewClient(url, "", "", nil)
+	if err := anonymous.Health(ctx); err != nil {
+		t.Errorf("Health without credentials: %v", err)
+	}
+	if _, err := anonymous.ListFlights(ctx, 0, 0); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("ListFlights without credentials returned %v, want ErrUnauthorized", err)
+	}
+
+	wrong := client.NewClient(url, domain.RoleScheduler, "wrong password", nil)
+	if _, err := wrong.ListFlights(ctx, 0, 0); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("ListFlights with a wrong password returned %v, want ErrUnauthorized", err)
+	}
+
+	scheduler := client.NewClient(url, domain.RoleScheduler, testPassword, nil)
+	createFlight(t, scheduler, "F1001")
+
+	agent := client.NewClient(url, domain.RoleSalesAgent, testPassword, nil)
+	if _, err := agent.GetFlight(ctx, "F1001"); err != nil {
+		t.Errorf("GetFlight as a sales agent: %v", err)
+	}
+	_, err := agent.CreateFlight(ctx, client.CreateFlightRequest{
+		FlightNumber:    "F1002",
+		DepartureCity:   "Hanoi",
+		DestinationCity: "Hue",
+		DepartureTime:   time.Now().Add(24 * time.Hour),
+		ArrivalTime:     time.Now().Add(26 * time.Hour),
+		Capacity:        40,
+	})
+	if !errors.Is(err, client.ErrForbidden) {
+		t.Errorf("CreateFlight as a sales agent returned %v, want ErrForbidden", err)
+	}
+
+	planner := client.NewClient(url, domain.RoleCrewPlanner, testPassword, nil)
+	if _, err := planner.AssignCrew(ctx, "F1001", []client.Crew{
+		{Name: "Tran Minh", Position: client.PositionPilot},
+		{Name: "Le Hoa", Position: client.PositionAttendant},
+		{Name: "Pham Nam", Position: client.PositionGroundStaff},
+	}); err != nil {
+		t.Fatalf("AssignCrew as a crew planner: %v", err)
+	}
+	if _, err := planner.AddCrewMember(ctx, "F1001", client.Crew{Name: "Vo Lan", Position: client.PositionAttendant}); err != nil {
+		t.Fatalf("AddCrewMember as a crew planner: %v", err)
+	}
+	changes, err := planner.ListCrewChanges(ctx, "F1001", 0, 0)
+	if err != nil {
+		t.Fatalf("ListCrewChanges: %v", err)
+	}
+	if changes.Total != 1 || changes.Items[0].ChangedBy != domain.RoleCrewPlanner {
+		t.Errorf("ListCrewChanges returned %+v, want the change of the crew planner", changes.Items)
+	}
+}
+
+func TestAirplanes(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	created, err := c.CreateAirplane(ctx, client.CreateAirplaneRequest{ID: "VN-A321", Model: "A321", Capacity: 184})
+	if err != nil {
+		t.Fatalf("CreateAirplane: %v", err)
+	}
+	if created.ID != "VN-A321" || created.Capacity != 184 {
+		t.Errorf("CreateAirplane returned %+v", created)
+	}
+
+	got, err := c.GetAirplane(ctx, "VN-A321")
+	if err != nil {
+		t.Fatalf("GetAirplane: %v", err)
+	}
+	if got.Model != "A321" {
+		t.Errorf("GetAirplane returned %+v, want the created airplane", got)
+	}
+
+	page, err := c.ListAirplanes(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ListAirplanes: %v", err)
+	}
+	if page.Total != 1 || page.Items[0].ID != "VN-A321" {
+		t.Errorf("ListAirplanes returned %+v", page.Items)
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import "time"
+
+// Crew positions
+const (
+	PositionPilot       = "Pilot"
+	PositionAttendant   = "Attendant"
+	PositionGroundStaff = "Ground Staff"
+)
+
+// Travel classes
+const (
+	ClassEconomy  = "Economy"
+	ClassBusiness = "Business"
+)
+
+// Flight is a flight as returned by the API
+type Flight struct {
+	FlightNumber    string              `json:"flight_number"`
+	DepartureCity   string              `json:"departure_city"`
+	DestinationCity string              `json:"destination_city"`
+	DepartureTime   time.Time           `json:"departure_time"`
+	ArrivalTime     time.Time           `json:"arrival_time"`
+	FlightCapacity  int                 `json:"flight_capacity"`
+	AvailableSeat   int                 `json:"available_seat"`
+	CrewMembers     []Crew              `json:"crew_members"`
+	AirplaneID      string              `json:"airplane_id,omitempty"`
+	Status          string              `json:"status,omitempty"`
+	Gate            string              `json:"gate,omitempty"`
+	Fares           map[string]int64    `json:"fares,omitempty"` // Base fare per class, in minor currency units
+	ExitRows        []int               `json:"exit_rows,omitempty"`
+	BusinessRows    int                 `json:"business_rows,omitempty"`
+	SeatList        map[string]bool     `json:"seat_list"` // Seat number to whether it is still available
+	SeatAttributes  map[string][]string `json:"seat_attributes,omitempty"`
+}
+
+// Crew is a member of the crew of a flight
+type Crew struct {
+	ID       string `json:"id,omitempty"` // Registry ID, empty for crew entered by hand
+	Name     string `json:"name"`
+	Position string `json:"position"`
+}
+
+// CrewChange is an entry of the crew change audit trail of a flight
+type CrewChange struct {
+	FlightNumber string    `json:"flight_number"`
+	Action       string    `json:"action"`
+	Removed      *Crew     `json:"removed,omitempty"`
+	Added        *Crew     `json:"added,omitempty"`
+	ChangedBy    string    `json:"changed_by"`
+	ChangedAt    time.Time `json:"changed_at"`
+}
+
+// Reservation is a reservation as returned by the API
+type Reservation struct {
+	ReservationID           string     `json:"reservation_id"`
+	Name                    string     `json:"name"`
+	Address                 string     `json:"address"`
+	PhoneNumber             int64      `json:"phone_number"`
+	IdentityCardNumber      int64      `json:"identity_card_number"`
+	ReservationFlightNumber string     `json:"reservation_flight_number"`
+	Class                   string     `json:"class,omitempty"`
+	Status                  string     `json:"status,omitempty"`
+	SeatLocation            string     `json:"seat_location"`
+	CheckedIn               bool       `json:"checked_in"`
+	CheckInSequence         int        `json:"check_in_sequence,omitempty"`
+	BoardedAt               *time.Time `json:"boarded_at,omitempty"`
+	Fare                    *Fare      `json:"fare,omitempty"`
+	GroupID                 string     `json:"group_id,omitempty"`
+	ReservationTime         time.Time  `json:"reservation_time"`
+}
+
+// Fare is the price of a reservation, in minor currency units
+type Fare struct {
+	Currency string          `json:"currency"`
+	Base     int64           `json:"base"`
+	Taxes    []FareComponent `json:"taxes,omitempty"`
+	Fees     []FareComponent `json:"fees,omitempty"`
+}
+
+// Total returns the base fare plus all taxes and fees
+func (f *Fare) Total() int64 {
+	total := f.Base
+	for _, component := range f.Taxes {
+		total += component.Amount
+	}
+	for _, component := range f.Fees {
+		total += component.Amount
+	}
+	return total
+}
+
+// FareComponent is a tax or fee of a fare
+type FareComponent struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Amount      int64  `json:"amount"`
+}
+
+// Airplane is an airplane as returned by the API
+type Airplane struct {
+	ID          string             `json:"id"`
+	Model       string             `json:"model"`
+	Capacity    int                `json:"capacity"`
+	Maintenance []MaintenanceEvent `json:"maintenance,omitempty"`
+}
+
+// MaintenanceEvent is a period an airplane is out of service
+type MaintenanceEvent struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Location string    `json:"location"`
+}
+
+// CreateFlightRequest is the body of a request creating a flight
+type CreateFlightRequest struct {
+	FlightNumber    string    `json:"flight_number"`
+	DepartureCity   string    `json:"departure_city"`
+	DestinationCity string    `json:"destination_city"`
+	DepartureTime   time.Time `json:"departure_time"`
+	ArrivalTime     time.Time `json:"arrival_time"`
+	Capacity        int       `json:"capacity"`
+}
+
+// BookFlightRequest is the body of a request booking a flight
+type BookFlightRequest struct {
+	Name               string `json:"name"`
+	Address            string `json:"address"`
+	PhoneNumber        int64  `json:"phone_number"`
+	IdentityCardNumber int64  `json:"identity_card_number"`
+	FlightNumber       string `json:"flight_number"`
+	Class              string `json:"class,omitempty"` // Economy when empty
+}
+
+// CreateAirplaneRequest is the body of a request registering an airplane
+type CreateAirplaneRequest struct {
+	ID       string `json:"id"`
+	Model    string `json:"model"`
+	Capacity int    `json:"capacity"`
+}
+
+// Bodies of the other requests and of the error responses
+type (
+	assignCrewRequest struct {
+		Crew []Crew `json:"crew"`
+	}
+
+	crewMemberRequest struct {
+		Member Crew `json:"member"`
+	}
+
+	checkInRequest struct {
+		Seat string `json:"seat,omitempty"`
+	}
+
+	errorBody struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+)